@@ -18,12 +18,14 @@ package main
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"io/ioutil"
+	"krak8s/commands"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
-	"strings"
 	"sync"
 	"time"
 
@@ -58,19 +60,97 @@ type ProjectObject struct {
 	OID        string        `json:"oid,omitempty"`
 	ObjType    string        `json:"objType,omitempty"`
 	Name       string        `json:"name,omitempty"`
+	Target     string        `json:"target,omitempty"`
 	CreatedAt  time.Time     `json:"createdAt,omitempty"`
 	UpdatedAt  time.Time     `json:"updatedAt,omitempty"`
 	Namespaces []*ObjectLink `json:"namespaces,omitempty"`
+	Isolated   bool          `json:"isolated,omitempty"`
+	Peers      []NetworkPeer `json:"peers,omitempty"`
+	// callers with the admin role, and the roles of the other callers
+	Owners  []string          `json:"owners,omitempty"`
+	Members map[string]string `json:"members,omitempty"`
+	// named registry credentials of the project's applications
+	Credentials map[string]*CredentialObject `json:"credentials,omitempty"`
+}
+
+// CredentialObject a named registry credential of a project, its password
+// sealed like the applications' registry passwords
+type CredentialObject struct {
+	Name           string    `json:"name,omitempty"`
+	Server         string    `json:"server,omitempty"`
+	Username       string    `json:"username,omitempty"`
+	Password       string    `json:"password,omitempty"`
+	PasswordSealed bool      `json:"passwordSealed,omitempty"`
+	CreatedAt      time.Time `json:"createdAt,omitempty"`
+	UpdatedAt      time.Time `json:"updatedAt,omitempty"`
+}
+
+// NetworkPeer a source of ingress traffic allowed in an isolated project
+type NetworkPeer struct {
+	Project         string            `json:"project,omitempty"`
+	NamespaceLabels map[string]string `json:"namespaceLabels,omitempty"`
+	PodLabels       map[string]string `json:"podLabels,omitempty"`
 }
 
 // NamespaceObject resource type
 type NamespaceObject struct {
-	OID          string        `json:"oid,omitempty"`
-	ObjType      string        `json:"objType,omitempty"`
-	Name         string        `json:"name,omitempty"`
-	CreatedAt    time.Time     `json:"createdAt,omitempty"`
-	Resources    *ObjectLink   `json:"resources,omitempty"`
-	Applications []*ObjectLink `json:"applications,omitempty"`
+	OID          string          `json:"oid,omitempty"`
+	ObjType      string          `json:"objType,omitempty"`
+	Name         string          `json:"name,omitempty"`
+	CreatedAt    time.Time       `json:"createdAt,omitempty"`
+	Resources    []*ObjectLink   `json:"resources,omitempty"`
+	Applications []*ObjectLink   `json:"applications,omitempty"`
+	Phase        string          `json:"phase,omitempty"`
+	Quota        *NamespaceQuota `json:"quota,omitempty"`
+}
+
+// NamespaceQuota resource quota of a namespace, Kubernetes quantities
+type NamespaceQuota struct {
+	CPU           string `json:"cpu,omitempty"`
+	Memory        string `json:"memory,omitempty"`
+	Pods          *int   `json:"pods,omitempty"`
+	Storage       string `json:"storage,omitempty"`
+	DefaultCPU    string `json:"defaultCpu,omitempty"`
+	DefaultMemory string `json:"defaultMemory,omitempty"`
+}
+
+// NamespaceObject Phase strings, the Kubernetes namespace phases and the
+// failure to create the Kubernetes namespace.
+const (
+	// NamespaceActive phase string
+	NamespaceActive = "Active"
+	// NamespaceTerminating phase string
+	NamespaceTerminating = "Terminating"
+	// NamespaceFailed phase string
+	NamespaceFailed = "Failed"
+)
+
+// UnmarshalJSON - unmarshal the namespace, accepting the single resources
+// link of the persistence files written before a namespace could have more
+// than one cluster resource.
+func (obj *NamespaceObject) UnmarshalJSON(data []byte) error {
+	type namespaceObject NamespaceObject
+	var ns struct {
+		*namespaceObject
+		Resources json.RawMessage `json:"resources,omitempty"`
+	}
+	ns.namespaceObject = (*namespaceObject)(obj)
+	if err := json.Unmarshal(data, &ns); err != nil {
+		return err
+	}
+	obj.Resources = nil
+	if len(ns.Resources) == 0 || string(ns.Resources) == "null" {
+		return nil
+	}
+	if ns.Resources[0] == '{' {
+		var link ObjectLink
+		if err := json.Unmarshal(ns.Resources, &link); err != nil {
+			return err
+		}
+		obj.Resources = []*ObjectLink{&link}
+		return nil
+	}
+	return json.Unmarshal(ns.Resources, &obj.Resources)
 }
 
 // ApplicationStatusObject State strings
@@ -91,6 +171,15 @@ const (
 	ApplicationFailed = "FAILED"
 )
 
+// ApplicationObject Deployer strings
+const (
+	// DeployerHelm - the application is deployed by krak8s with helm
+	DeployerHelm = "helm"
+	// DeployerKraken - the application's chart is added to the Kraken
+	// configuration's helmConfigs and deployed by k2
+	DeployerKraken = "kraken"
+)
+
 // ApplicationStatusObject nested object type
 type ApplicationStatusObject struct {
 	DeployedAt time.Time `json:"deployedAt,omitempty"`
@@ -100,22 +189,28 @@ type ApplicationStatusObject struct {
 
 // ApplicationObject base resource type
 type ApplicationObject struct {
-	OID           string                   `json:"oid,omitempty"`
-	ObjType       string                   `json:"objType,omitempty"`
-	NamespaceID   string                   `json:"namespaceId,omitempty"`
-	Deployment    string                   `json:"deploymentName,omitempty"`
-	Server        string                   `json:"resgistryServer,omitempty"`
-	ChartRegistry string                   `json:"chartRegistry,omitempty"`
-	ChartName     string                   `json:"chartName,omitempty"`
-	ChartVersion  string                   `json:"chartVersion,omitempty"`
-	Channel       string                   `json:"channel,omitempty"`
-	Username      string                   `json:"username,omitempty"`
-	Password      string                   `json:"password,omitempty"`
-	Config        string                   `json:"config,omitempty"`
-	JSONValues    string                   `json:"jsonValues,omitempty"`
-	CreatedAt     time.Time                `json:"createdAt,omitempty"`
-	UpdatedAt     time.Time                `json:"updatedAt,omitempty"`
-	Status        *ApplicationStatusObject `json:"status,omitempty"`
+	OID             string                   `json:"oid,omitempty"`
+	ObjType         string                   `json:"objType,omitempty"`
+	NamespaceID     string                   `json:"namespaceId,omitempty"`
+	Deployment      string                   `json:"deploymentName,omitempty"`
+	Server          string                   `json:"resgistryServer,omitempty"`
+	ChartRegistry   string                   `json:"chartRegistry,omitempty"`
+	ChartName       string                   `json:"chartName,omitempty"`
+	ChartVersion    string                   `json:"chartVersion,omitempty"`
+	ChartConstraint string                   `json:"chartConstraint,omitempty"`
+	Channel         string                   `json:"channel,omitempty"`
+	Username        string                   `json:"username,omitempty"`
+	Password        string                   `json:"password,omitempty"`
+	PasswordSealed  bool                     `json:"passwordSealed,omitempty"`
+	Credential      string                   `json:"credential,omitempty"`
+	Config          string                   `json:"config,omitempty"`
+	JSONValues      string                   `json:"jsonValues,omitempty"`
+	Values          map[string]interface{}   `json:"values,omitempty"`
+	Cluster         string                   `json:"cluster,omitempty"`
+	Deployer        string                   `json:"deployer,omitempty"`
+	CreatedAt       time.Time                `json:"createdAt,omitempty"`
+	UpdatedAt       time.Time                `json:"updatedAt,omitempty"`
+	Status          *ApplicationStatusObject `json:"status,omitempty"`
 }
 
 // ResourceObject State strings
@@ -140,15 +235,36 @@ const (
 	ResourceDeleted = "deleted"
 )
 
-// ResourceObject base resource type
+// ResourceObject base resource type, Name is empty for the namespace's
+// default node pool
 type ResourceObject struct {
-	OID          string    `json:"oid,omitempty"`
-	ObjType      string    `json:"objType,omitempty"`
-	NodePoolSize int       `json:"nodePoolSize,omitempty"`
-	CreatedAt    time.Time `json:"createdAt,omitempty"`
-	UpdatedAt    time.Time `json:"updatedAt,omitempty"`
-	State        string    `json:"state,omitempty"`
-	NamespaceID  string    `json:"namespaceId,omitempty"`
+	OID          string       `json:"oid,omitempty"`
+	ObjType      string       `json:"objType,omitempty"`
+	Name         string       `json:"name,omitempty"`
+	NodePoolSize int          `json:"nodePoolSize,omitempty"`
+	CreatedAt    time.Time    `json:"createdAt,omitempty"`
+	UpdatedAt    time.Time    `json:"updatedAt,omitempty"`
+	State        string       `json:"state,omitempty"`
+	NamespaceID  string       `json:"namespaceId,omitempty"`
+	NodePoolName string       `json:"nodePoolName,omitempty"`
+	NodePool     NodePoolSpec `json:"nodePool,omitempty"`
+}
+
+// NodePoolSpec - the Kraken definitions, labels and taints requested for a
+// cluster resource's node pool, empty for the defaults.
+type NodePoolSpec struct {
+	NodeConfig      string            `json:"nodeConfig,omitempty"`
+	OSConfig        string            `json:"osConfig,omitempty"`
+	ContainerConfig string            `json:"containerConfig,omitempty"`
+	Labels          map[string]string `json:"labels,omitempty"`
+	Taints          []TaintObject     `json:"taints,omitempty"`
+}
+
+// TaintObject - a node taint
+type TaintObject struct {
+	Key    string `json:"key"`
+	Value  string `json:"value,omitempty"`
+	Effect string `json:"effect"`
 }
 
 // DataModel the actual structure for the API's data.
@@ -170,9 +286,10 @@ func (data *DataModel) Reset() {
 // DataStore in-memory data synchronization structure for API data.
 type DataStore struct {
 	sync.Mutex
-	archive chan bool
-	data    DataModel
-	persist string
+	archive     chan bool
+	data        DataModel
+	persist     string
+	credentials *CredentialCipher
 }
 
 // NewDataStore initializes a new "DataStore"
@@ -285,13 +402,15 @@ func (ds *DataStore) NewProjectObject() *ProjectObject {
 	return &obj
 }
 
-// NewProject creates ProjectObject with given name and unique object id.
-func (ds *DataStore) NewProject(name string) *ProjectObject {
+// NewProject creates ProjectObject with given name, cluster target and unique
+// object id.
+func (ds *DataStore) NewProject(name, target string) *ProjectObject {
 	obj := ds.NewProjectObject()
 	if obj == nil {
 		return nil
 	}
 	obj.Name = name
+	obj.Target = target
 	obj.UpdatedAt = time.Now()
 	ds.archive <- true
 	return obj
@@ -337,6 +456,165 @@ func (ds *DataStore) DeleteProject(obj *ProjectObject) {
 	ds.archive <- true
 }
 
+// UpdateProjectNetwork updates the ProjectObject's network isolation.
+func (ds *DataStore) UpdateProjectNetwork(obj *ProjectObject, isolated bool, peers []NetworkPeer) {
+	obj.Isolated = isolated
+	obj.Peers = peers
+	obj.UpdatedAt = time.Now()
+	ds.archive <- true
+}
+
+// MemberRole returns the caller's role on the ProjectObject, "" without one.
+func (obj *ProjectObject) MemberRole(caller string) string {
+	for _, owner := range obj.Owners {
+		if owner == caller {
+			return RoleAdmin
+		}
+	}
+	return obj.Members[caller]
+}
+
+// HasNamespaceLink returns true if the project links to the namespace.
+func (obj *ProjectObject) HasNamespaceLink(oid string) bool {
+	for _, link := range obj.Namespaces {
+		if link.OID == oid {
+			return true
+		}
+	}
+	return false
+}
+
+func (obj *ProjectObject) removeMember(caller string) {
+	for i, owner := range obj.Owners {
+		if owner == caller {
+			obj.Owners = append(obj.Owners[:i], obj.Owners[i+1:]...)
+			break
+		}
+	}
+	delete(obj.Members, caller)
+}
+
+// UpdateProjectMember binds the caller to the role on the ProjectObject, the
+// admin role making the caller one of its owners.
+func (ds *DataStore) UpdateProjectMember(obj *ProjectObject, caller, role string) {
+	ds.Lock()
+	obj.removeMember(caller)
+	if role == RoleAdmin {
+		obj.Owners = append(obj.Owners, caller)
+	} else {
+		if obj.Members == nil {
+			obj.Members = make(map[string]string)
+		}
+		obj.Members[caller] = role
+	}
+	obj.UpdatedAt = time.Now()
+	ds.Unlock()
+	ds.archive <- true
+}
+
+// DeleteProjectMember removes the caller's role on the ProjectObject.
+func (ds *DataStore) DeleteProjectMember(obj *ProjectObject, caller string) {
+	ds.Lock()
+	obj.removeMember(caller)
+	obj.UpdatedAt = time.Now()
+	ds.Unlock()
+	ds.archive <- true
+}
+
+// ProjectCredential returns the ProjectObject's named registry credential.
+func (ds *DataStore) ProjectCredential(obj *ProjectObject, name string) (*CredentialObject, bool) {
+	ds.Lock()
+	cred, ok := obj.Credentials[name]
+	ds.Unlock()
+	return cred, ok
+}
+
+// ProjectCredentials returns the ProjectObject's registry credentials, by name.
+func (ds *DataStore) ProjectCredentials(obj *ProjectObject) []*CredentialObject {
+	ds.Lock()
+	names := make([]string, 0, len(obj.Credentials))
+	for name := range obj.Credentials {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	collection := make([]*CredentialObject, len(names))
+	for i, name := range names {
+		collection[i] = obj.Credentials[name]
+	}
+	ds.Unlock()
+	return collection
+}
+
+// UpdateProjectCredential creates the ProjectObject's named registry
+// credential, or rotates it, replacing it with one of the server, username and
+// password.  The password is sealed.
+func (ds *DataStore) UpdateProjectCredential(obj *ProjectObject, name, server, username, password string) (*CredentialObject, error) {
+	ds.Lock()
+	stored, sealed, err := sealPassword(ds.credentials, password)
+	if err != nil {
+		ds.Unlock()
+		return nil, err
+	}
+	now := time.Now()
+	cred := &CredentialObject{
+		Name:           name,
+		Server:         server,
+		Username:       username,
+		Password:       stored,
+		PasswordSealed: sealed,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if old, ok := obj.Credentials[name]; ok {
+		cred.CreatedAt = old.CreatedAt
+	}
+	if obj.Credentials == nil {
+		obj.Credentials = make(map[string]*CredentialObject)
+	}
+	obj.Credentials[name] = cred
+	obj.UpdatedAt = now
+	ds.Unlock()
+	ds.archive <- true
+	return cred, nil
+}
+
+// DeleteProjectCredential removes the ProjectObject's named registry credential.
+func (ds *DataStore) DeleteProjectCredential(obj *ProjectObject, name string) {
+	ds.Lock()
+	delete(obj.Credentials, name)
+	obj.UpdatedAt = time.Now()
+	ds.Unlock()
+	ds.archive <- true
+}
+
+// CredentialApplications returns the ProjectObject's applications that log in
+// with its named registry credential.
+func (ds *DataStore) CredentialApplications(obj *ProjectObject, name string) []*ApplicationObject {
+	collection := []*ApplicationObject{}
+	ds.Lock()
+	for _, link := range obj.Namespaces {
+		ns, ok := ds.data.Namespaces[link.OID]
+		if !ok {
+			continue
+		}
+		for _, appLink := range ns.Applications {
+			if app, ok := ds.data.Applications[appLink.OID]; ok && app.Credential == name {
+				collection = append(collection, app)
+			}
+		}
+	}
+	ds.Unlock()
+	return collection
+}
+
+// CredentialPassword returns the credential's registry password, opened.
+func (ds *DataStore) CredentialPassword(obj *CredentialObject) (string, error) {
+	ds.Lock()
+	c := ds.credentials
+	ds.Unlock()
+	return openPassword(c, obj.Password, obj.PasswordSealed)
+}
+
 // NewNamespaceObject creates aa default NamespaceObject with a valid unique
 // object id, type value and created at timestamp.
 func (ds *DataStore) NewNamespaceObject() *NamespaceObject {
@@ -367,6 +645,12 @@ func (ds *DataStore) NewNamespace(name string) *NamespaceObject {
 	return obj
 }
 
+// UpdateNamespaceQuota updates the NamespaceObject's resource quota.
+func (ds *DataStore) UpdateNamespaceQuota(obj *NamespaceObject, quota *NamespaceQuota) {
+	obj.Quota = quota
+	ds.archive <- true
+}
+
 // NamespacesCollection returns all Namespaces for a project
 func (ds *DataStore) NamespacesCollection(projectOID string) []*NamespaceObject {
 	proj, ok := ds.data.Projects[projectOID]
@@ -407,10 +691,10 @@ func (ds *DataStore) DeleteNamespace(obj *NamespaceObject) {
 			ds.data.Namespaces[obj.OID].Applications[i] = nil
 		}
 	}
-	if res := ds.data.Namespaces[obj.OID].Resources; res != nil {
-		ds.DeleteResource(ds.data.Resources[res.OID])
-		ds.data.Namespaces[obj.OID].Resources = nil
+	for _, link := range ds.data.Namespaces[obj.OID].Resources {
+		ds.DeleteResource(ds.data.Resources[link.OID])
 	}
+	ds.data.Namespaces[obj.OID].Resources = nil
 	ds.Lock()
 	delete(ds.data.Namespaces, obj.OID)
 	ds.Unlock()
@@ -436,9 +720,13 @@ func (ds *DataStore) NewApplicationObject(nsOID string) *ApplicationObject {
 	return &obj
 }
 
-// NewApplication creates a new application resource.
-func (ds *DataStore) NewApplication(namespace, deployment, server, registry, name, version string, channel,
-	username, password, set, jsonValues *string) *ApplicationObject {
+// NewApplication creates a new application resource.  The version is the
+// chart version resolved from the requested version constraint, the credential
+// the name of the project's registry credential the application logs in with,
+// the cluster the name of the namespace's cluster resource the application
+// runs on, and the deployer either DeployerHelm or DeployerKraken.
+func (ds *DataStore) NewApplication(namespace, deployment, server, registry, name, version, constraint string, channel,
+	username, password, credential, set, jsonValues, cluster, deployer *string, values map[string]interface{}) *ApplicationObject {
 	obj := ds.NewApplicationObject(namespace)
 	if obj == nil {
 		return nil
@@ -448,6 +736,7 @@ func (ds *DataStore) NewApplication(namespace, deployment, server, registry, nam
 	obj.ChartRegistry = registry
 	obj.ChartName = name
 	obj.ChartVersion = version
+	obj.ChartConstraint = constraint
 	if channel != nil {
 		obj.Channel = *channel
 	}
@@ -455,24 +744,128 @@ func (ds *DataStore) NewApplication(namespace, deployment, server, registry, nam
 		obj.Username = *username
 	}
 	if password != nil {
-		obj.Password = *password
+		stored, sealed, err := sealPassword(ds.credentials, *password)
+		if err != nil {
+			glog.Errorf("unable to seal the registry password of application %s: %v", obj.OID, err)
+			ds.DeleteApplication(obj)
+			return nil
+		}
+		obj.Password, obj.PasswordSealed = stored, sealed
+	}
+	if credential != nil {
+		obj.Credential = *credential
 	}
 
-	// removed escaped "\" input from stored values
-	rep := strings.NewReplacer("\\", "")
-
-	// Optional fields, may be unset (nil)
+	// Optional fields, may be unset (nil).  These are stored as given, the
+	// set string's escapes are part of helm's --set syntax and those in the
+	// json values are part of the JSON.
 	if set != nil {
-		obj.Config = rep.Replace(*set)
+		obj.Config = *set
 	}
 	if jsonValues != nil {
-		obj.JSONValues = rep.Replace(*jsonValues)
+		obj.JSONValues = *jsonValues
+	}
+	if cluster != nil {
+		obj.Cluster = *cluster
+	}
+	if deployer != nil {
+		obj.Deployer = *deployer
 	}
+	obj.Values = values
 	obj.UpdatedAt = time.Now()
 	ds.archive <- true
 	return obj
 }
 
+// sealPassword returns the password as it's stored, sealed with the cipher,
+// and true, or as is and false without a cipher or a password.
+func sealPassword(c *CredentialCipher, password string) (string, bool, error) {
+	if c == nil || password == "" {
+		return password, false, nil
+	}
+	sealed, err := c.Seal(password)
+	if err != nil {
+		return "", false, err
+	}
+	return sealed, true, nil
+}
+
+// openPassword returns the stored password, opened with the cipher if it's
+// sealed.
+func openPassword(c *CredentialCipher, password string, sealed bool) (string, error) {
+	if !sealed {
+		return password, nil
+	}
+	return c.Open(password)
+}
+
+// SetCredentialCipher seals the registry passwords of the applications and of
+// the projects' credentials with the cipher, stored from then on.  Passwords
+// stored in plain text, or sealed with another of the cipher's keys, are
+// sealed with its current key.
+func (ds *DataStore) SetCredentialCipher(c *CredentialCipher) error {
+	ds.Lock()
+	ds.credentials = c
+	if c == nil {
+		ds.Unlock()
+		return nil
+	}
+	resealed := 0
+	var err error
+	reseal := func(password *string, sealed *bool, owner string) {
+		if *password == "" || (*sealed && c.SealedWith(*password)) {
+			return
+		}
+		opened, oerr := openPassword(c, *password, *sealed)
+		if oerr == nil {
+			opened, oerr = c.Seal(opened)
+		}
+		if oerr != nil {
+			err = fmt.Errorf("%s: %v", owner, oerr)
+			return
+		}
+		*password, *sealed = opened, true
+		resealed++
+	}
+	for _, obj := range ds.data.Applications {
+		reseal(&obj.Password, &obj.PasswordSealed, "application "+obj.OID)
+	}
+	for _, proj := range ds.data.Projects {
+		for _, cred := range proj.Credentials {
+			reseal(&cred.Password, &cred.PasswordSealed, "project "+proj.OID+" credential "+cred.Name)
+		}
+	}
+	ds.Unlock()
+	if resealed > 0 {
+		glog.Infof("sealed %d registry passwords", resealed)
+		ds.archive <- true
+	}
+	return err
+}
+
+// ApplicationPassword returns the application's registry password, opened.
+func (ds *DataStore) ApplicationPassword(obj *ApplicationObject) (string, error) {
+	ds.Lock()
+	c := ds.credentials
+	ds.Unlock()
+	return openPassword(c, obj.Password, obj.PasswordSealed)
+}
+
+// ApplicationLogin returns the registry username and password, opened, the
+// application logs in with, those of its project's credential if it names one.
+func (ds *DataStore) ApplicationLogin(proj *ProjectObject, obj *ApplicationObject) (string, string, error) {
+	if obj.Credential == "" {
+		password, err := ds.ApplicationPassword(obj)
+		return obj.Username, password, err
+	}
+	cred, ok := ds.ProjectCredential(proj, obj.Credential)
+	if !ok {
+		return "", "", fmt.Errorf("project %s has no credential %s", proj.Name, obj.Credential)
+	}
+	password, err := ds.CredentialPassword(cred)
+	return cred.Username, password, err
+}
+
 // Application returns the app with the given oid if found
 func (ds *DataStore) Application(oid string) (*ApplicationObject, bool) {
 	ds.Lock()
@@ -532,17 +925,27 @@ func (ds *DataStore) NewResourceObject(nsOID string) *ResourceObject {
 	return &obj
 }
 
-// NewResource creates a new ResourceObject resource.
-func (ds *DataStore) NewResource(namespace string, nodes int) *ResourceObject {
+// NewResource creates a new ResourceObject resource, the named node pool of
+// the namespace.
+func (ds *DataStore) NewResource(namespace, name string, nodes int, spec NodePoolSpec) *ResourceObject {
 	obj := ds.NewResourceObject(namespace)
 	if obj == nil {
 		return nil
 	}
+	obj.Name = name
 	obj.NodePoolSize = nodes
+	obj.NodePool = spec
 	ds.archive <- true
 	return obj
 }
 
+// UpdateResource updates the ResourceObject's requested node pool size.
+func (ds *DataStore) UpdateResource(obj *ResourceObject, nodes int) {
+	obj.NodePoolSize = nodes
+	obj.UpdatedAt = time.Now()
+	ds.archive <- true
+}
+
 // Resource returns the app with the given oid if found
 func (ds *DataStore) Resource(oid string) (*ResourceObject, bool) {
 	ds.Lock()
@@ -551,15 +954,122 @@ func (ds *DataStore) Resource(oid string) (*ResourceObject, bool) {
 	return res, ok
 }
 
-// ResourceObject return the resource object from the indicated namespace.
-func (ds *DataStore) ResourceObject(nsOID string) (*ResourceObject, bool) {
-	ns, ok := ds.data.Namespaces[nsOID]
+// ResourceObject return the named resource object from the indicated
+// namespace, the empty name is the namespace's default node pool.
+func (ds *DataStore) ResourceObject(nsOID, name string) (*ResourceObject, bool) {
+	ns, ok := ds.Namespace(nsOID)
 	if !ok {
 		return nil, false
 	}
-	ds.Lock()
-	defer ds.Unlock()
-	return ds.Resource(ns.Resources.OID)
+	for _, res := range ds.NamespaceResources(ns) {
+		if res.Name == name {
+			return res, true
+		}
+	}
+	return nil, false
+}
+
+// NamespaceResources returns the namespace's resource objects.
+func (ds *DataStore) NamespaceResources(ns *NamespaceObject) []*ResourceObject {
+	collection := []*ResourceObject{}
+	for _, link := range ns.Resources {
+		if res, ok := ds.Resource(link.OID); ok {
+			collection = append(collection, res)
+		}
+	}
+	return collection
+}
+
+// targetResources calls fn with the node pool name of each cluster resource
+// of the target's projects, and the resource.
+func (ds *DataStore) targetResources(target string, fn func(name string, res *ResourceObject)) {
+	for _, proj := range ds.ProjectsCollection() {
+		if ProjectTarget(proj).Name != target {
+			continue
+		}
+		for _, nsLink := range proj.Namespaces {
+			ns, ok := ds.Namespace(nsLink.OID)
+			if !ok {
+				continue
+			}
+			for _, res := range ds.NamespaceResources(ns) {
+				name := res.NodePoolName
+				if name == "" {
+					name = commands.ProjectNodePoolName(proj.Name, res.Name)
+				}
+				fn(name, res)
+			}
+		}
+	}
+}
+
+// TargetNodePools returns the node counts of the node pools of the cluster
+// resources of the target's projects, by node pool name, and the names of
+// the node pools whose resources are being created or deleted.  Deleted
+// resources, and those that failed to start, have no node pool.
+func (ds *DataStore) TargetNodePools(target string) (map[string]int, []string) {
+	counts := make(map[string]int)
+	changing := []string{}
+	ds.targetResources(target, func(name string, res *ResourceObject) {
+		switch res.State {
+		case ResourceDeleted, ResourceErrorStarting:
+		case ResourceCreateRequested, ResourceStarting, ResourceDeleteRequested, ResourceDeleting:
+			changing = append(changing, name)
+		default:
+			counts[name] = res.NodePoolSize
+		}
+	})
+	sort.Strings(changing)
+	return counts, changing
+}
+
+// ResizeTargetNodePools sets the node pool size of the cluster resources of
+// the target's projects whose node pool is one of sizes, by node pool name.
+func (ds *DataStore) ResizeTargetNodePools(target string, sizes map[string]int) {
+	ds.targetResources(target, func(name string, res *ResourceObject) {
+		switch res.State {
+		case ResourceDeleted, ResourceErrorStarting:
+			return
+		}
+		if nodes, ok := sizes[name]; ok && nodes != res.NodePoolSize {
+			ds.UpdateResource(res, nodes)
+		}
+	})
+}
+
+// TargetHasNodePool returns true if a cluster resource of one of the target's
+// projects has, or is creating or deleting, the named node pool.
+func (ds *DataStore) TargetHasNodePool(target, name string) bool {
+	counts, changing := ds.TargetNodePools(target)
+	if _, ok := counts[name]; ok {
+		return true
+	}
+	for _, pool := range changing {
+		if pool == name {
+			return true
+		}
+	}
+	return false
+}
+
+// HasResourceLink returns true if the namespace links to the resource.
+func (ns *NamespaceObject) HasResourceLink(oid string) bool {
+	for _, link := range ns.Resources {
+		if link.OID == oid {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveResourceLink removes the resource's link from the namespace.
+func (ns *NamespaceObject) RemoveResourceLink(oid string) {
+	for i, link := range ns.Resources {
+		if link.OID == oid {
+			ns.Resources = append(ns.Resources[:i], ns.Resources[i+1:]...)
+			return
+		}
+	}
 }
 
 // DeleteResource deletes specified application
@@ -18,7 +18,9 @@ package main
 
 import (
 	"io/ioutil"
+	"krak8s/commands"
 	"os"
+	"reflect"
 	"testing"
 
 	"github.com/ghodss/yaml"
@@ -404,6 +406,10 @@ func TestNewValidDataStoreLoad(t *testing.T) {
 			len(ds.data.Projects), len(ds.data.Namespaces), len(ds.data.Resources), len(ds.data.Applications),
 			validProjects, validNamesapces, validResources, validApps)
 	}
+	// the single resources link of earlier persistence files
+	if ns := ds.data.Namespaces["96f6162c"]; ns == nil || len(ns.Resources) != 1 || ns.Resources[0].OID != "4b3ff7db" {
+		t.Errorf("NewDataStore(%s) namespace 96f6162c resources = %v, want [4b3ff7db]", file.Name(), ns)
+	}
 }
 
 func TestNewInalidDataStoreLoad(t *testing.T) {
@@ -466,7 +472,7 @@ func TestNewNamedProject(t *testing.T) {
 	if ds == nil {
 		t.Errorf("NewDataStore() = nil, want: valid datastore")
 	}
-	obj := ds.NewProject("test_object")
+	obj := ds.NewProject("test_object", "")
 	if obj == nil {
 		t.Errorf("NewProject() = nil, want: valid project")
 	}
@@ -497,11 +503,11 @@ func TestProjectCollection(t *testing.T) {
 	}
 	go ds.Archiver()
 	defer close(ds.archive)
-	ds.NewProject("test_project_1")
-	ds.NewProject("test_project_2")
-	ds.NewProject("test_project_3")
-	ds.NewProject("test_project_4")
-	ds.NewProject("test_project_5")
+	ds.NewProject("test_project_1", "")
+	ds.NewProject("test_project_2", "")
+	ds.NewProject("test_project_3", "")
+	ds.NewProject("test_project_4", "")
+	ds.NewProject("test_project_5", "")
 	col := ds.ProjectsCollection()
 	if len(col) != 5 {
 		t.Errorf("ProjectsCollection() projects = %d, want projects = 5", len(col))
@@ -515,17 +521,17 @@ func TestProject(t *testing.T) {
 	}
 	go ds.Archiver()
 	defer close(ds.archive)
-	ds.NewProject("test_project_1")
-	ds.NewProject("test_project_2")
-	obj := ds.NewProject("test_project_3")
+	ds.NewProject("test_project_1", "")
+	ds.NewProject("test_project_2", "")
+	obj := ds.NewProject("test_project_3", "")
 	if obj == nil {
 		t.Errorf("NewProject() = nil, want: valid project")
 	}
 	if obj.Name != "test_project_3" {
 		t.Errorf("NewProject() Name = %s, want Name == `test_object`", obj.Name)
 	}
-	ds.NewProject("test_project_4")
-	ds.NewProject("test_project_5")
+	ds.NewProject("test_project_4", "")
+	ds.NewProject("test_project_5", "")
 
 	proj, found := ds.Project(obj.OID)
 	if !found {
@@ -643,7 +649,7 @@ func TestNewNamedNamespace(t *testing.T) {
 	}
 	go ds.Archiver()
 	defer close(ds.archive)
-	obj := ds.NewProject("test_object")
+	obj := ds.NewProject("test_object", "")
 	if obj == nil {
 		t.Errorf("NewProject() = nil, want: valid project")
 	}
@@ -678,7 +684,7 @@ func TestNamespaceCollection(t *testing.T) {
 	}
 	go ds.Archiver()
 	defer close(ds.archive)
-	proj := ds.NewProject("test_project")
+	proj := ds.NewProject("test_project", "")
 	if proj == nil {
 		t.Errorf("NewProject() = nil, want: valid project")
 	}
@@ -699,7 +705,7 @@ func TestNamespace(t *testing.T) {
 	}
 	go ds.Archiver()
 	defer close(ds.archive)
-	proj := ds.NewProject("test_project")
+	proj := ds.NewProject("test_project", "")
 	if proj == nil {
 		t.Errorf("NewProject() = nil, want: valid project")
 	}
@@ -825,7 +831,7 @@ func TestNewNamedApplication(t *testing.T) {
 	}
 	go ds.Archiver()
 	defer close(ds.archive)
-	obj := ds.NewProject("test_object")
+	obj := ds.NewProject("test_object", "")
 	if obj == nil {
 		t.Errorf("NewProject() = nil, want: valid project")
 	}
@@ -836,7 +842,7 @@ func TestNewNamedApplication(t *testing.T) {
 	chn := "test_channel"
 	pwd := "test_password"
 	app := ds.NewApplication(ns.OID, "test_deployment", "test_server", "test_registry",
-		"test_chart", "test_version", &chn, nil, &pwd, nil, nil)
+		"test_chart", "test_version", "test_constraint", &chn, nil, &pwd, nil, nil, nil, nil, nil, nil)
 	if app == nil {
 		t.Errorf("NewApplication(%s), have: nil, want: Application object", ns.OID)
 	}
@@ -867,6 +873,9 @@ func TestNewNamedApplication(t *testing.T) {
 	if app.ChartVersion != "test_version" {
 		t.Errorf("NewApplication(%s), have version(%s), want version(`test_version`)", ns.OID, app.ChartVersion)
 	}
+	if app.ChartConstraint != "test_constraint" {
+		t.Errorf("NewApplication(%s), have constraint(%s), want constraint(`test_constraint`)", ns.OID, app.ChartConstraint)
+	}
 	if app.Channel != "test_channel" {
 		t.Errorf("NewApplication(%s), have channel(%s), want channel(`test_channel`)", ns.OID, app.Channel)
 	}
@@ -891,7 +900,7 @@ func TestNewApplicationValues(t *testing.T) {
 	}
 	go ds.Archiver()
 	defer close(ds.archive)
-	obj := ds.NewProject("test_object")
+	obj := ds.NewProject("test_object", "")
 	if obj == nil {
 		t.Errorf("NewProject() = nil, want: valid project")
 	}
@@ -902,10 +911,11 @@ func TestNewApplicationValues(t *testing.T) {
 	chn := "test_channel"
 	pwd := "test_password"
 	rawSet := "list --namespace neptune-test"
-	rawValues := "{\"ingress\":{\"defaultHost\":{\"hostname\":\"neptune.getreaction.io\"}},\"app\":{\"envVars\":[{\"key\":\"ROOT_URL\",\"value\":\"https://neptune.getreaction.io\"}]},\"mongo\":{\"deploymentName\":\"neptune-mongodb\"}}"
-	storedValues := `{"ingress":{"defaultHost":{"hostname":"neptune.getreaction.io"}},"app":{"envVars":[{"key":"ROOT_URL","value":"https://neptune.getreaction.io"}]},"mongo":{"deploymentName":"neptune-mongodb"}}`
+	rawValues := "{\"ingress\":{\"defaultHost\":{\"hostname\":\"neptune.getreaction.io\"}},\"app\":{\"envVars\":[{\"key\":\"ROOT_URL\",\"value\":\"https://neptune.getreaction.io\"},{\"key\":\"MOTD\",\"value\":\"say \\\"hi\\\"\"}]},\"mongo\":{\"deploymentName\":\"neptune-mongodb\"}}"
+	storedValues := `{"ingress":{"defaultHost":{"hostname":"neptune.getreaction.io"}},"app":{"envVars":[{"key":"ROOT_URL","value":"https://neptune.getreaction.io"},{"key":"MOTD","value":"say \"hi\""}]},"mongo":{"deploymentName":"neptune-mongodb"}}`
+	values := map[string]interface{}{"replicas": 3}
 	app := ds.NewApplication(ns.OID, "test_deployment", "test_server", "test_registry",
-		"test_chart", "test_version", &chn, nil, &pwd, &rawSet, &rawValues)
+		"test_chart", "test_version", "test_constraint", &chn, nil, &pwd, nil, &rawSet, &rawValues, nil, nil, values)
 	if app == nil {
 		t.Errorf("NewApplication(%s), have: nil, want: Application object", ns.OID)
 	}
@@ -915,6 +925,9 @@ func TestNewApplicationValues(t *testing.T) {
 	if app.JSONValues != storedValues {
 		t.Errorf("NewApplication(%s), have json(%s), want json(%s)", ns.OID, app.JSONValues, storedValues)
 	}
+	if app.Values["replicas"] != 3 {
+		t.Errorf("NewApplication(%s), have values(%v), want values(%v)", ns.OID, app.Values, values)
+	}
 	glog.Infof("raw json: %s\n", app.JSONValues)
 	json := []byte(app.JSONValues)
 	yaml, err := yaml.JSONToYAML(json)
@@ -931,7 +944,7 @@ func TestApplicationCollection(t *testing.T) {
 	}
 	go ds.Archiver()
 	defer close(ds.archive)
-	proj := ds.NewProject("test_project")
+	proj := ds.NewProject("test_project", "")
 	if proj == nil {
 		t.Errorf("NewProject(), have: nil, want: project object")
 	}
@@ -941,7 +954,7 @@ func TestApplicationCollection(t *testing.T) {
 	}
 	for i := 0; i < 5; i++ {
 		app := ds.NewApplication(ns.OID, "test_deployment", "test_server", "test_registry",
-			"test_chart", "test_version", nil, nil, nil, nil, nil)
+			"test_chart", "test_version", "test_constraint", nil, nil, nil, nil, nil, nil, nil, nil, nil)
 		ns.Applications = append(ns.Applications, &ObjectLink{OID: app.OID, URL: ""})
 	}
 	col := ds.ApplicationsCollection(ns.OID)
@@ -957,7 +970,7 @@ func TestApplication(t *testing.T) {
 	}
 	go ds.Archiver()
 	defer close(ds.archive)
-	proj := ds.NewProject("test_project")
+	proj := ds.NewProject("test_project", "")
 	if proj == nil {
 		t.Errorf("NewProject(), have: nil, want: project object")
 	}
@@ -968,7 +981,7 @@ func TestApplication(t *testing.T) {
 	var obj *ApplicationObject
 	for i := 0; i < 5; i++ {
 		app := ds.NewApplication(ns.OID, "test_deployment", "test_server", "test_registry",
-			"test_chart", "test_version", nil, nil, nil, nil, nil)
+			"test_chart", "test_version", "test_constraint", nil, nil, nil, nil, nil, nil, nil, nil, nil)
 		if i == 2 {
 			obj = app
 		}
@@ -1092,7 +1105,7 @@ func TestNewNamedResource(t *testing.T) {
 	}
 	go ds.Archiver()
 	defer close(ds.archive)
-	obj := ds.NewProject("test_object")
+	obj := ds.NewProject("test_object", "")
 	if obj == nil {
 		t.Errorf("NewProject() = nil, want: valid project")
 	}
@@ -1100,7 +1113,7 @@ func TestNewNamedResource(t *testing.T) {
 	if ns == nil {
 		t.Errorf("NewNamespace(%s), have: nil, want: valid Namespace", "test_namespace")
 	}
-	res := ds.NewResource(ns.OID, 3)
+	res := ds.NewResource(ns.OID, "", 3, NodePoolSpec{})
 	if res == nil {
 		t.Errorf("NewResource(%s), have: nil, want: Resource object", ns.OID)
 	}
@@ -1121,6 +1134,65 @@ func TestNewNamedResource(t *testing.T) {
 	}
 }
 
+func TestTargetNodePools(t *testing.T) {
+	ds := NewDataStore("")
+	go ds.Archiver()
+	defer close(ds.archive)
+	proj := ds.NewProject("acme", "")
+	ns := ds.NewNamespace("acme")
+	proj.Namespaces = append(proj.Namespaces, &ObjectLink{OID: ns.OID, URL: ""})
+	for _, pool := range []struct {
+		name, state string
+		nodes       int
+	}{
+		{"", ResourceActive, 2},
+		{"db", ResourceErrorDeleting, 3},
+		{"cache", ResourceStarting, 1},
+		{"old", ResourceDeleted, 1},
+	} {
+		res := ds.NewResource(ns.OID, pool.name, pool.nodes, NodePoolSpec{})
+		res.State = pool.state
+		ns.Resources = append(ns.Resources, &ObjectLink{OID: res.OID, URL: ""})
+	}
+	clusterTargets["staging"] = &ClusterTarget{Name: "staging"}
+	defer delete(clusterTargets, "staging")
+	other := ds.NewProject("other", "staging")
+	otherNs := ds.NewNamespace("other")
+	other.Namespaces = append(other.Namespaces, &ObjectLink{OID: otherNs.OID, URL: ""})
+	res := ds.NewResource(otherNs.OID, "", 1, NodePoolSpec{})
+	res.State = ResourceActive
+	otherNs.Resources = append(otherNs.Resources, &ObjectLink{OID: res.OID, URL: ""})
+
+	counts, changing := ds.TargetNodePools(DefaultTarget)
+	want := map[string]int{
+		commands.ProjectNodePoolName("acme", ""):   2,
+		commands.ProjectNodePoolName("acme", "db"): 3,
+	}
+	if !reflect.DeepEqual(counts, want) {
+		t.Errorf("TargetNodePools() counts have %v, want %v", counts, want)
+	}
+	if len(changing) != 1 || changing[0] != commands.ProjectNodePoolName("acme", "cache") {
+		t.Errorf("TargetNodePools() changing have %v, want [%s]", changing, commands.ProjectNodePoolName("acme", "cache"))
+	}
+	// project acme-db's default node pool would be acme's db node pool
+	if !ds.TargetHasNodePool(DefaultTarget, commands.ProjectNodePoolName("acme-db", "")) {
+		t.Errorf("TargetHasNodePool(acme-db) have false, want true")
+	}
+	if ds.TargetHasNodePool("staging", commands.ProjectNodePoolName("acme", "")) {
+		t.Errorf("TargetHasNodePool(staging acme) have true, want false")
+	}
+
+	ds.ResizeTargetNodePools(DefaultTarget, map[string]int{commands.ProjectNodePoolName("acme", "db"): 5})
+	counts, _ = ds.TargetNodePools(DefaultTarget)
+	want[commands.ProjectNodePoolName("acme", "db")] = 5
+	if !reflect.DeepEqual(counts, want) {
+		t.Errorf("ResizeTargetNodePools() counts have %v, want %v", counts, want)
+	}
+	if res.NodePoolSize != 1 {
+		t.Errorf("ResizeTargetNodePools() resized staging's resource to %d, want 1", res.NodePoolSize)
+	}
+}
+
 func TestResource(t *testing.T) {
 	ds := NewDataStore("")
 	if ds == nil {
@@ -1128,7 +1200,7 @@ func TestResource(t *testing.T) {
 	}
 	go ds.Archiver()
 	defer close(ds.archive)
-	proj := ds.NewProject("test_project")
+	proj := ds.NewProject("test_project", "")
 	if proj == nil {
 		t.Errorf("NewProject(), have: nil, want: project object")
 	}
@@ -1136,11 +1208,23 @@ func TestResource(t *testing.T) {
 	if ns == nil {
 		t.Errorf("NewNamespace(%s), have: nil, want: namespace object", "test_namespace")
 	}
-	res := ds.NewResource(ns.OID, 7)
+	res := ds.NewResource(ns.OID, "", 7, NodePoolSpec{})
 	if res == nil {
 		t.Errorf("NewResource(%s), have: nil, want: Resource object", ns.OID)
 	}
-	ns.Resources = &ObjectLink{OID: res.OID, URL: ""}
+	ns.Resources = append(ns.Resources, &ObjectLink{OID: res.OID, URL: ""})
+	db := ds.NewResource(ns.OID, "db", 3, NodePoolSpec{})
+	ns.Resources = append(ns.Resources, &ObjectLink{OID: db.OID, URL: ""})
+	if rsrc, found := ds.ResourceObject(ns.OID, "db"); !found || rsrc != db {
+		t.Errorf("ResourceObject(%s, db) = %v, want %v", ns.OID, rsrc, db)
+	}
+	if rsrc, found := ds.ResourceObject(ns.OID, ""); !found || rsrc != res {
+		t.Errorf("ResourceObject(%s, ``) = %v, want %v", ns.OID, rsrc, res)
+	}
+	ns.RemoveResourceLink(db.OID)
+	if ns.HasResourceLink(db.OID) || !ns.HasResourceLink(res.OID) {
+		t.Errorf("RemoveResourceLink(%s) resources = %v, want only %s", db.OID, ns.Resources, res.OID)
+	}
 
 	rsrc, found := ds.Resource(res.OID)
 	if !found {
@@ -17,9 +17,13 @@ limitations under the License.
 package main
 
 import (
+	"net/http"
 	"path"
+	"strings"
 
+	"github.com/golang/glog"
 	"k8s.io/client-go/kubernetes"
+	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
 
 	"github.com/goadesign/goa"
 	"github.com/goadesign/goa/middleware"
@@ -46,6 +50,7 @@ type apiServer struct {
 	clientset *kubernetes.Clientset
 	server    *goa.Service
 	ds        *DataStore
+	audit     *AuditLog
 }
 
 func newAPIServer(clientset *kubernetes.Clientset, cfg *config, backend *Runner) *apiServer {
@@ -61,13 +66,43 @@ func newAPIServer(clientset *kubernetes.Clientset, cfg *config, backend *Runner)
 		ds:        NewDataStore(dataStore),
 	}
 	go as.ds.Archiver()
+	// serving with stored credentials left in plain text, or sealed with
+	// another key, would hide them from the logins that need them
+	if err := as.ds.SetCredentialCipher(newCredentialCipher(clientset, cfg)); err != nil {
+		panic("unable to seal stored registry credentials: " + err.Error())
+	}
+
+	auditLog := *cfg.auditLog
+	if auditLog == "" {
+		auditLog = path.Join(*krak8sCfg.krakenConfigDir, "audit.log")
+	}
+	var err error
+	if as.audit, err = NewAuditLog(auditLog); err != nil {
+		panic(err.Error())
+	}
+	backend.SetAuditLog(as.audit)
 
-	// Mount middleware
+	// Mount middleware, the audit log's inside the request id's and outside
+	// the error handler's.  The request details are logged by krak8s, the goa
+	// verbose logger would log the callers' credentials.
 	as.server.Use(middleware.RequestID())
-	as.server.Use(middleware.LogRequest(true))
+	as.server.Use(middleware.LogRequest(false))
+	as.server.Use(LogRequestDetails())
+	as.server.Use(as.audit.Middleware())
 	as.server.Use(middleware.ErrorHandler(as.server, true))
 	as.server.Use(middleware.Recover())
 
+	// Both security schemes are served by the one authenticator, which takes
+	// an API key or a JWT bearer token, and the authorizer of the caller's
+	// role on the request's project
+	auth := newAuthenticator(clientset, cfg)
+	authz := NewAuthorizer(as.ds, strings.Split(*cfg.admins, ","))
+	security := func(h goa.Handler) goa.Handler {
+		return auth.Middleware()(authz.Middleware()(h))
+	}
+	app.UseJWTMiddleware(as.server, security)
+	app.UseAPIKeyMiddleware(as.server, security)
+
 	// Create and Mount the resource controllers
 	swagger := NewSwaggerController(as.server)
 	app.MountSwaggerController(as.server, swagger)
@@ -87,14 +122,111 @@ func newAPIServer(clientset *kubernetes.Clientset, cfg *config, backend *Runner)
 	cluster := NewClusterController(as.server, as.ds, backend)
 	app.MountClusterController(as.server, cluster)
 
+	credential := NewCredentialController(as.server, as.ds, backend)
+	app.MountCredentialController(as.server, credential)
+
+	kubeconfig := NewKubeconfigController(as.server, as.ds, backend)
+	app.MountKubeconfigController(as.server, kubeconfig)
+
+	member := NewMemberController(as.server, as.ds, backend)
+	app.MountMemberController(as.server, member)
+
+	revision := NewRevisionController(as.server, as.ds, backend)
+	app.MountRevisionController(as.server, revision)
+
+	audit := NewAuditController(as.server, as.audit)
+	app.MountAuditController(as.server, audit)
+
 	health := NewHealthController(as.server)
 	app.MountHealthController(as.server, health)
 
 	return &as
 }
 
+// newAuthenticator - the authenticator of the configured API keys and JWT
+// keys, Kubernetes Secret sources read through the clientset.
+func newAuthenticator(clientset *kubernetes.Clientset, cfg *config) *Authenticator {
+	if *cfg.noAuth {
+		glog.Warningf("serving the API without authentication")
+		return NewAuthenticator(nil, nil, true)
+	}
+	secrets := kubeSecrets(clientset)
+	var apiKeys, jwtKeys KeySource
+	var err error
+	if *cfg.apiKeys != "" {
+		if apiKeys, err = NewKeySource(*cfg.apiKeys, secrets, true); err != nil {
+			panic(err.Error())
+		}
+	}
+	if *cfg.jwtKeys != "" {
+		if jwtKeys, err = NewKeySource(*cfg.jwtKeys, secrets, false); err != nil {
+			panic(err.Error())
+		}
+	}
+	if apiKeys == nil && jwtKeys == nil {
+		glog.Warningf("no --api-keys or --jwt-keys, every API request will be rejected")
+	}
+	return NewAuthenticator(apiKeys, jwtKeys, false)
+}
+
+// newCredentialCipher - the cipher of the configured credentials keys, nil
+// without them.
+func newCredentialCipher(clientset *kubernetes.Clientset, cfg *config) *CredentialCipher {
+	if *cfg.credentialsKey == "" {
+		glog.Warningf("no --credentials-key, registry credentials are stored in plain text")
+		return nil
+	}
+	source, err := NewKeySource(*cfg.credentialsKey, kubeSecrets(clientset), false)
+	if err != nil {
+		panic(err.Error())
+	}
+	c, err := NewCredentialCipher(source)
+	if err != nil {
+		panic(err.Error())
+	}
+	return c
+}
+
+// kubeSecrets - the Kubernetes Secrets of a namespace, read through the
+// clientset
+func kubeSecrets(clientset *kubernetes.Clientset) func(namespace string) v1core.SecretInterface {
+	return func(namespace string) v1core.SecretInterface {
+		return clientset.Core().Secrets(namespace)
+	}
+}
+
+// newCertReloader - the reloader of the configured TLS certificate, and of
+// the client CA, nil when the API is served over plain HTTP.
+func newCertReloader(cfg *config) *CertReloader {
+	if *cfg.tlsCertFile == "" && *cfg.tlsKeyFile == "" {
+		if *cfg.tlsClientCAFile != "" {
+			panic("--tls-client-ca-file needs --tls-cert-file and --tls-key-file")
+		}
+		glog.Warningf("no --tls-cert-file, serving the API over plain HTTP")
+		return nil
+	}
+	reloader, err := NewCertReloader(*cfg.tlsCertFile, *cfg.tlsKeyFile, *cfg.tlsClientCAFile)
+	if err != nil {
+		panic(err.Error())
+	}
+	return reloader
+}
+
 func (as *apiServer) run() {
-	if err := as.server.ListenAndServe(":8080"); err != nil {
+	addr := *as.cfg.listenAddress
+	var err error
+	if reloader := newCertReloader(as.cfg); reloader != nil {
+		server := &http.Server{
+			Addr:      addr,
+			Handler:   as.server.Mux,
+			TLSConfig: reloader.TLSConfig(),
+		}
+		as.server.LogInfo("listen", "transport", "https", "addr", addr)
+		err = server.ListenAndServeTLS("", "")
+	} else {
+		err = as.server.ListenAndServe(addr)
+	}
+	if err != nil {
 		as.server.LogError("startup", "err", err)
 	}
 }
@@ -14,6 +14,8 @@ import (
 	"context"
 	"github.com/goadesign/goa"
 	"net/http"
+	"strconv"
+	"time"
 	"unicode/utf8"
 )
 
@@ -235,12 +237,115 @@ func (ctx *ListApplicationContext) NotFound() error {
 	return nil
 }
 
+// OutdatedApplicationContext provides the application outdated action context.
+type OutdatedApplicationContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	Projectid string
+}
+
+// NewOutdatedApplicationContext parses the incoming request URL and body, performs validations and creates the
+// context used by the application controller outdated action.
+func NewOutdatedApplicationContext(ctx context.Context, r *http.Request, service *goa.Service) (*OutdatedApplicationContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := OutdatedApplicationContext{Context: ctx, ResponseData: resp, RequestData: req}
+	paramProjectid := req.Params["projectid"]
+	if len(paramProjectid) > 0 {
+		rawProjectid := paramProjectid[0]
+		rctx.Projectid = rawProjectid
+	}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *OutdatedApplicationContext) OK(r ApplicationVersionCollection) error {
+	ctx.ResponseData.Header().Set("Content-Type", "application/application.version+json; type=collection")
+	if r == nil {
+		r = ApplicationVersionCollection{}
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *OutdatedApplicationContext) NotFound() error {
+	ctx.ResponseData.WriteHeader(404)
+	return nil
+}
+
+// ListAuditContext provides the audit list action context.
+type ListAuditContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	Object *string
+	Since  *time.Time
+	Until  *time.Time
+}
+
+// NewListAuditContext parses the incoming request URL and body, performs validations and creates the
+// context used by the audit controller list action.
+func NewListAuditContext(ctx context.Context, r *http.Request, service *goa.Service) (*ListAuditContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := ListAuditContext{Context: ctx, ResponseData: resp, RequestData: req}
+	paramObject := req.Params["object"]
+	if len(paramObject) > 0 {
+		rawObject := paramObject[0]
+		rctx.Object = &rawObject
+	}
+	paramSince := req.Params["since"]
+	if len(paramSince) > 0 {
+		rawSince := paramSince[0]
+		if since, err2 := time.Parse(time.RFC3339, rawSince); err2 == nil {
+			tmp2 := &since
+			rctx.Since = tmp2
+		} else {
+			err = goa.MergeErrors(err, goa.InvalidParamTypeError("since", rawSince, "datetime"))
+		}
+	}
+	paramUntil := req.Params["until"]
+	if len(paramUntil) > 0 {
+		rawUntil := paramUntil[0]
+		if until, err2 := time.Parse(time.RFC3339, rawUntil); err2 == nil {
+			tmp4 := &until
+			rctx.Until = tmp4
+		} else {
+			err = goa.MergeErrors(err, goa.InvalidParamTypeError("until", rawUntil, "datetime"))
+		}
+	}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *ListAuditContext) OK(r AuditEntryCollection) error {
+	ctx.ResponseData.Header().Set("Content-Type", "application/audit.entry+json; type=collection")
+	if r == nil {
+		r = AuditEntryCollection{}
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *ListAuditContext) BadRequest(r error) error {
+	ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
 // CreateClusterContext provides the cluster create action context.
 type CreateClusterContext struct {
 	context.Context
 	*goa.ResponseData
 	*goa.RequestData
 	Projectid string
+	Plan      bool
 	Payload   *ClusterPostBody
 }
 
@@ -258,9 +363,26 @@ func NewCreateClusterContext(ctx context.Context, r *http.Request, service *goa.
 		rawProjectid := paramProjectid[0]
 		rctx.Projectid = rawProjectid
 	}
+	paramPlan := req.Params["plan"]
+	if len(paramPlan) == 0 {
+		rctx.Plan = false
+	} else {
+		rawPlan := paramPlan[0]
+		if plan, err2 := strconv.ParseBool(rawPlan); err2 == nil {
+			rctx.Plan = plan
+		} else {
+			err = goa.MergeErrors(err, goa.InvalidParamTypeError("plan", rawPlan, "boolean"))
+		}
+	}
 	return &rctx, err
 }
 
+// OK sends a HTTP response with status code 200.
+func (ctx *CreateClusterContext) OK(r *ClusterPlan) error {
+	ctx.ResponseData.Header().Set("Content-Type", "application/cluster.plan+json")
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
 // Accepted sends a HTTP response with status code 202.
 func (ctx *CreateClusterContext) Accepted(r *Cluster) error {
 	ctx.ResponseData.Header().Set("Content-Type", "application/cluster+json")
@@ -298,6 +420,7 @@ type DeleteClusterContext struct {
 	*goa.RequestData
 	Projectid  string
 	ResourceID string
+	Plan       bool
 }
 
 // NewDeleteClusterContext parses the incoming request URL and body, performs validations and creates the
@@ -319,9 +442,26 @@ func NewDeleteClusterContext(ctx context.Context, r *http.Request, service *goa.
 		rawResourceID := paramResourceID[0]
 		rctx.ResourceID = rawResourceID
 	}
+	paramPlan := req.Params["plan"]
+	if len(paramPlan) == 0 {
+		rctx.Plan = false
+	} else {
+		rawPlan := paramPlan[0]
+		if plan, err2 := strconv.ParseBool(rawPlan); err2 == nil {
+			rctx.Plan = plan
+		} else {
+			err = goa.MergeErrors(err, goa.InvalidParamTypeError("plan", rawPlan, "boolean"))
+		}
+	}
 	return &rctx, err
 }
 
+// OK sends a HTTP response with status code 200.
+func (ctx *DeleteClusterContext) OK(r *ClusterPlan) error {
+	ctx.ResponseData.Header().Set("Content-Type", "application/cluster.plan+json")
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
 // NoContent sends a HTTP response with status code 204.
 func (ctx *DeleteClusterContext) NoContent() error {
 	ctx.ResponseData.WriteHeader(204)
@@ -383,6 +523,283 @@ func (ctx *GetClusterContext) NotFound() error {
 	return nil
 }
 
+// UpdateClusterContext provides the cluster update action context.
+type UpdateClusterContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	Projectid  string
+	ResourceID string
+	Plan       bool
+	Payload    *ClusterPutBody
+}
+
+// NewUpdateClusterContext parses the incoming request URL and body, performs validations and creates the
+// context used by the cluster controller update action.
+func NewUpdateClusterContext(ctx context.Context, r *http.Request, service *goa.Service) (*UpdateClusterContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := UpdateClusterContext{Context: ctx, ResponseData: resp, RequestData: req}
+	paramProjectid := req.Params["projectid"]
+	if len(paramProjectid) > 0 {
+		rawProjectid := paramProjectid[0]
+		rctx.Projectid = rawProjectid
+	}
+	paramResourceID := req.Params["resource_id"]
+	if len(paramResourceID) > 0 {
+		rawResourceID := paramResourceID[0]
+		rctx.ResourceID = rawResourceID
+	}
+	paramPlan := req.Params["plan"]
+	if len(paramPlan) == 0 {
+		rctx.Plan = false
+	} else {
+		rawPlan := paramPlan[0]
+		if plan, err2 := strconv.ParseBool(rawPlan); err2 == nil {
+			rctx.Plan = plan
+		} else {
+			err = goa.MergeErrors(err, goa.InvalidParamTypeError("plan", rawPlan, "boolean"))
+		}
+	}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *UpdateClusterContext) OK(r *ClusterPlan) error {
+	ctx.ResponseData.Header().Set("Content-Type", "application/cluster.plan+json")
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// Accepted sends a HTTP response with status code 202.
+func (ctx *UpdateClusterContext) Accepted(r *Cluster) error {
+	ctx.ResponseData.Header().Set("Content-Type", "application/cluster+json")
+	return ctx.ResponseData.Service.Send(ctx.Context, 202, r)
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *UpdateClusterContext) BadRequest(r error) error {
+	ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *UpdateClusterContext) NotFound() error {
+	ctx.ResponseData.WriteHeader(404)
+	return nil
+}
+
+// Conflict sends a HTTP response with status code 409.
+func (ctx *UpdateClusterContext) Conflict() error {
+	ctx.ResponseData.WriteHeader(409)
+	return nil
+}
+
+// DeleteCredentialContext provides the credential delete action context.
+type DeleteCredentialContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	Name      string
+	Projectid string
+}
+
+// NewDeleteCredentialContext parses the incoming request URL and body, performs validations and creates the
+// context used by the credential controller delete action.
+func NewDeleteCredentialContext(ctx context.Context, r *http.Request, service *goa.Service) (*DeleteCredentialContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := DeleteCredentialContext{Context: ctx, ResponseData: resp, RequestData: req}
+	paramName := req.Params["name"]
+	if len(paramName) > 0 {
+		rawName := paramName[0]
+		rctx.Name = rawName
+	}
+	paramProjectid := req.Params["projectid"]
+	if len(paramProjectid) > 0 {
+		rawProjectid := paramProjectid[0]
+		rctx.Projectid = rawProjectid
+	}
+	return &rctx, err
+}
+
+// NoContent sends a HTTP response with status code 204.
+func (ctx *DeleteCredentialContext) NoContent() error {
+	ctx.ResponseData.WriteHeader(204)
+	return nil
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *DeleteCredentialContext) BadRequest(r error) error {
+	ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *DeleteCredentialContext) NotFound() error {
+	ctx.ResponseData.WriteHeader(404)
+	return nil
+}
+
+// GetCredentialContext provides the credential get action context.
+type GetCredentialContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	Name      string
+	Projectid string
+}
+
+// NewGetCredentialContext parses the incoming request URL and body, performs validations and creates the
+// context used by the credential controller get action.
+func NewGetCredentialContext(ctx context.Context, r *http.Request, service *goa.Service) (*GetCredentialContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := GetCredentialContext{Context: ctx, ResponseData: resp, RequestData: req}
+	paramName := req.Params["name"]
+	if len(paramName) > 0 {
+		rawName := paramName[0]
+		rctx.Name = rawName
+	}
+	paramProjectid := req.Params["projectid"]
+	if len(paramProjectid) > 0 {
+		rawProjectid := paramProjectid[0]
+		rctx.Projectid = rawProjectid
+	}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *GetCredentialContext) OK(r *Credential) error {
+	ctx.ResponseData.Header().Set("Content-Type", "application/credential+json")
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *GetCredentialContext) NotFound() error {
+	ctx.ResponseData.WriteHeader(404)
+	return nil
+}
+
+// ListCredentialContext provides the credential list action context.
+type ListCredentialContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	Projectid string
+}
+
+// NewListCredentialContext parses the incoming request URL and body, performs validations and creates the
+// context used by the credential controller list action.
+func NewListCredentialContext(ctx context.Context, r *http.Request, service *goa.Service) (*ListCredentialContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := ListCredentialContext{Context: ctx, ResponseData: resp, RequestData: req}
+	paramProjectid := req.Params["projectid"]
+	if len(paramProjectid) > 0 {
+		rawProjectid := paramProjectid[0]
+		rctx.Projectid = rawProjectid
+	}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *ListCredentialContext) OK(r CredentialCollection) error {
+	ctx.ResponseData.Header().Set("Content-Type", "application/credential+json; type=collection")
+	if r == nil {
+		r = CredentialCollection{}
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *ListCredentialContext) NotFound() error {
+	ctx.ResponseData.WriteHeader(404)
+	return nil
+}
+
+// UpdateCredentialContext provides the credential update action context.
+type UpdateCredentialContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	Name      string
+	Projectid string
+	Redeploy  bool
+	Payload   *CredentialPutBody
+}
+
+// NewUpdateCredentialContext parses the incoming request URL and body, performs validations and creates the
+// context used by the credential controller update action.
+func NewUpdateCredentialContext(ctx context.Context, r *http.Request, service *goa.Service) (*UpdateCredentialContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := UpdateCredentialContext{Context: ctx, ResponseData: resp, RequestData: req}
+	paramName := req.Params["name"]
+	if len(paramName) > 0 {
+		rawName := paramName[0]
+		rctx.Name = rawName
+		if ok := goa.ValidatePattern(`^[a-z][a-z0-9-]{0,62}$`, rctx.Name); !ok {
+			err = goa.MergeErrors(err, goa.InvalidPatternError(`name`, rctx.Name, `^[a-z][a-z0-9-]{0,62}$`))
+		}
+	}
+	paramProjectid := req.Params["projectid"]
+	if len(paramProjectid) > 0 {
+		rawProjectid := paramProjectid[0]
+		rctx.Projectid = rawProjectid
+	}
+	paramRedeploy := req.Params["redeploy"]
+	if len(paramRedeploy) == 0 {
+		rctx.Redeploy = false
+	} else {
+		rawRedeploy := paramRedeploy[0]
+		if redeploy, err2 := strconv.ParseBool(rawRedeploy); err2 == nil {
+			rctx.Redeploy = redeploy
+		} else {
+			err = goa.MergeErrors(err, goa.InvalidParamTypeError("redeploy", rawRedeploy, "boolean"))
+		}
+	}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *UpdateCredentialContext) OK(r *Credential) error {
+	ctx.ResponseData.Header().Set("Content-Type", "application/credential+json")
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *UpdateCredentialContext) BadRequest(r error) error {
+	ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *UpdateCredentialContext) NotFound() error {
+	ctx.ResponseData.WriteHeader(404)
+	return nil
+}
+
+// InternalServerError sends a HTTP response with status code 500.
+func (ctx *UpdateCredentialContext) InternalServerError() error {
+	ctx.ResponseData.WriteHeader(500)
+	return nil
+}
+
 // HealthHealthContext provides the health health action context.
 type HealthHealthContext struct {
 	context.Context
@@ -390,24 +807,349 @@ type HealthHealthContext struct {
 	*goa.RequestData
 }
 
-// NewHealthHealthContext parses the incoming request URL and body, performs validations and creates the
-// context used by the health controller health action.
-func NewHealthHealthContext(ctx context.Context, r *http.Request, service *goa.Service) (*HealthHealthContext, error) {
+// NewHealthHealthContext parses the incoming request URL and body, performs validations and creates the
+// context used by the health controller health action.
+func NewHealthHealthContext(ctx context.Context, r *http.Request, service *goa.Service) (*HealthHealthContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := HealthHealthContext{Context: ctx, ResponseData: resp, RequestData: req}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *HealthHealthContext) OK(resp []byte) error {
+	ctx.ResponseData.Header().Set("Content-Type", "text/plain")
+	ctx.ResponseData.WriteHeader(200)
+	_, err := ctx.ResponseData.Write(resp)
+	return err
+}
+
+// GetKubeconfigContext provides the kubeconfig get action context.
+type GetKubeconfigContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	Projectid string
+}
+
+// NewGetKubeconfigContext parses the incoming request URL and body, performs validations and creates the
+// context used by the kubeconfig controller get action.
+func NewGetKubeconfigContext(ctx context.Context, r *http.Request, service *goa.Service) (*GetKubeconfigContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := GetKubeconfigContext{Context: ctx, ResponseData: resp, RequestData: req}
+	paramProjectid := req.Params["projectid"]
+	if len(paramProjectid) > 0 {
+		rawProjectid := paramProjectid[0]
+		rctx.Projectid = rawProjectid
+	}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *GetKubeconfigContext) OK(r *Kubeconfig) error {
+	ctx.ResponseData.Header().Set("Content-Type", "application/kubeconfig+json")
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *GetKubeconfigContext) BadRequest(r error) error {
+	ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *GetKubeconfigContext) NotFound() error {
+	ctx.ResponseData.WriteHeader(404)
+	return nil
+}
+
+// InternalServerError sends a HTTP response with status code 500.
+func (ctx *GetKubeconfigContext) InternalServerError() error {
+	ctx.ResponseData.WriteHeader(500)
+	return nil
+}
+
+// RevokeKubeconfigContext provides the kubeconfig revoke action context.
+type RevokeKubeconfigContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	Projectid string
+}
+
+// NewRevokeKubeconfigContext parses the incoming request URL and body, performs validations and creates the
+// context used by the kubeconfig controller revoke action.
+func NewRevokeKubeconfigContext(ctx context.Context, r *http.Request, service *goa.Service) (*RevokeKubeconfigContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := RevokeKubeconfigContext{Context: ctx, ResponseData: resp, RequestData: req}
+	paramProjectid := req.Params["projectid"]
+	if len(paramProjectid) > 0 {
+		rawProjectid := paramProjectid[0]
+		rctx.Projectid = rawProjectid
+	}
+	return &rctx, err
+}
+
+// NoContent sends a HTTP response with status code 204.
+func (ctx *RevokeKubeconfigContext) NoContent() error {
+	ctx.ResponseData.WriteHeader(204)
+	return nil
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *RevokeKubeconfigContext) NotFound() error {
+	ctx.ResponseData.WriteHeader(404)
+	return nil
+}
+
+// InternalServerError sends a HTTP response with status code 500.
+func (ctx *RevokeKubeconfigContext) InternalServerError() error {
+	ctx.ResponseData.WriteHeader(500)
+	return nil
+}
+
+// RotateKubeconfigContext provides the kubeconfig rotate action context.
+type RotateKubeconfigContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	Projectid string
+}
+
+// NewRotateKubeconfigContext parses the incoming request URL and body, performs validations and creates the
+// context used by the kubeconfig controller rotate action.
+func NewRotateKubeconfigContext(ctx context.Context, r *http.Request, service *goa.Service) (*RotateKubeconfigContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := RotateKubeconfigContext{Context: ctx, ResponseData: resp, RequestData: req}
+	paramProjectid := req.Params["projectid"]
+	if len(paramProjectid) > 0 {
+		rawProjectid := paramProjectid[0]
+		rctx.Projectid = rawProjectid
+	}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *RotateKubeconfigContext) OK(r *Kubeconfig) error {
+	ctx.ResponseData.Header().Set("Content-Type", "application/kubeconfig+json")
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *RotateKubeconfigContext) BadRequest(r error) error {
+	ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *RotateKubeconfigContext) NotFound() error {
+	ctx.ResponseData.WriteHeader(404)
+	return nil
+}
+
+// InternalServerError sends a HTTP response with status code 500.
+func (ctx *RotateKubeconfigContext) InternalServerError() error {
+	ctx.ResponseData.WriteHeader(500)
+	return nil
+}
+
+// DeleteMemberContext provides the member delete action context.
+type DeleteMemberContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	Caller    string
+	Projectid string
+}
+
+// NewDeleteMemberContext parses the incoming request URL and body, performs validations and creates the
+// context used by the member controller delete action.
+func NewDeleteMemberContext(ctx context.Context, r *http.Request, service *goa.Service) (*DeleteMemberContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := DeleteMemberContext{Context: ctx, ResponseData: resp, RequestData: req}
+	paramCaller := req.Params["caller"]
+	if len(paramCaller) > 0 {
+		rawCaller := paramCaller[0]
+		rctx.Caller = rawCaller
+	}
+	paramProjectid := req.Params["projectid"]
+	if len(paramProjectid) > 0 {
+		rawProjectid := paramProjectid[0]
+		rctx.Projectid = rawProjectid
+	}
+	return &rctx, err
+}
+
+// NoContent sends a HTTP response with status code 204.
+func (ctx *DeleteMemberContext) NoContent() error {
+	ctx.ResponseData.WriteHeader(204)
+	return nil
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *DeleteMemberContext) BadRequest(r error) error {
+	ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *DeleteMemberContext) NotFound() error {
+	ctx.ResponseData.WriteHeader(404)
+	return nil
+}
+
+// ListMemberContext provides the member list action context.
+type ListMemberContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	Projectid string
+}
+
+// NewListMemberContext parses the incoming request URL and body, performs validations and creates the
+// context used by the member controller list action.
+func NewListMemberContext(ctx context.Context, r *http.Request, service *goa.Service) (*ListMemberContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := ListMemberContext{Context: ctx, ResponseData: resp, RequestData: req}
+	paramProjectid := req.Params["projectid"]
+	if len(paramProjectid) > 0 {
+		rawProjectid := paramProjectid[0]
+		rctx.Projectid = rawProjectid
+	}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *ListMemberContext) OK(r MemberCollection) error {
+	ctx.ResponseData.Header().Set("Content-Type", "application/member+json; type=collection")
+	if r == nil {
+		r = MemberCollection{}
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *ListMemberContext) NotFound() error {
+	ctx.ResponseData.WriteHeader(404)
+	return nil
+}
+
+// UpdateMemberContext provides the member update action context.
+type UpdateMemberContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	Caller    string
+	Projectid string
+	Payload   *UpdateMemberPayload
+}
+
+// NewUpdateMemberContext parses the incoming request URL and body, performs validations and creates the
+// context used by the member controller update action.
+func NewUpdateMemberContext(ctx context.Context, r *http.Request, service *goa.Service) (*UpdateMemberContext, error) {
 	var err error
 	resp := goa.ContextResponse(ctx)
 	resp.Service = service
 	req := goa.ContextRequest(ctx)
 	req.Request = r
-	rctx := HealthHealthContext{Context: ctx, ResponseData: resp, RequestData: req}
+	rctx := UpdateMemberContext{Context: ctx, ResponseData: resp, RequestData: req}
+	paramCaller := req.Params["caller"]
+	if len(paramCaller) > 0 {
+		rawCaller := paramCaller[0]
+		rctx.Caller = rawCaller
+	}
+	paramProjectid := req.Params["projectid"]
+	if len(paramProjectid) > 0 {
+		rawProjectid := paramProjectid[0]
+		rctx.Projectid = rawProjectid
+	}
 	return &rctx, err
 }
 
+// updateMemberPayload is the member update action payload.
+type updateMemberPayload struct {
+	// Role of the caller on the project
+	Role *string `form:"role,omitempty" json:"role,omitempty" xml:"role,omitempty"`
+}
+
+// Validate runs the validation rules defined in the design.
+func (payload *updateMemberPayload) Validate() (err error) {
+	if payload.Role == nil {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`raw`, "role"))
+	}
+	if payload.Role != nil {
+		if !(*payload.Role == "admin" || *payload.Role == "operator" || *payload.Role == "viewer") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError(`raw.role`, *payload.Role, []interface{}{"admin", "operator", "viewer"}))
+		}
+	}
+	return
+}
+
+// Publicize creates UpdateMemberPayload from updateMemberPayload
+func (payload *updateMemberPayload) Publicize() *UpdateMemberPayload {
+	var pub UpdateMemberPayload
+	if payload.Role != nil {
+		pub.Role = *payload.Role
+	}
+	return &pub
+}
+
+// UpdateMemberPayload is the member update action payload.
+type UpdateMemberPayload struct {
+	// Role of the caller on the project
+	Role string `form:"role" json:"role" xml:"role"`
+}
+
+// Validate runs the validation rules defined in the design.
+func (payload *UpdateMemberPayload) Validate() (err error) {
+	if payload.Role == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`raw`, "role"))
+	}
+	if !(payload.Role == "admin" || payload.Role == "operator" || payload.Role == "viewer") {
+		err = goa.MergeErrors(err, goa.InvalidEnumValueError(`raw.role`, payload.Role, []interface{}{"admin", "operator", "viewer"}))
+	}
+	return
+}
+
 // OK sends a HTTP response with status code 200.
-func (ctx *HealthHealthContext) OK(resp []byte) error {
-	ctx.ResponseData.Header().Set("Content-Type", "text/plain")
-	ctx.ResponseData.WriteHeader(200)
-	_, err := ctx.ResponseData.Write(resp)
-	return err
+func (ctx *UpdateMemberContext) OK(r *Member) error {
+	ctx.ResponseData.Header().Set("Content-Type", "application/member+json")
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *UpdateMemberContext) BadRequest(r error) error {
+	ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *UpdateMemberContext) NotFound() error {
+	ctx.ResponseData.WriteHeader(404)
+	return nil
 }
 
 // CreateNamespaceContext provides the namespace create action context.
@@ -439,6 +1181,8 @@ func NewCreateNamespaceContext(ctx context.Context, r *http.Request, service *go
 // createNamespacePayload is the namespace create action payload.
 type createNamespacePayload struct {
 	Name *string `form:"name,omitempty" json:"name,omitempty" xml:"name,omitempty"`
+	// resource quota of the namespace
+	Quota *namespaceQuota `form:"quota,omitempty" json:"quota,omitempty" xml:"quota,omitempty"`
 }
 
 // Validate runs the validation rules defined in the design.
@@ -446,6 +1190,11 @@ func (payload *createNamespacePayload) Validate() (err error) {
 	if payload.Name == nil {
 		err = goa.MergeErrors(err, goa.MissingAttributeError(`raw`, "name"))
 	}
+	if payload.Quota != nil {
+		if err2 := payload.Quota.Validate(); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	}
 	return
 }
 
@@ -455,12 +1204,17 @@ func (payload *createNamespacePayload) Publicize() *CreateNamespacePayload {
 	if payload.Name != nil {
 		pub.Name = *payload.Name
 	}
+	if payload.Quota != nil {
+		pub.Quota = payload.Quota.Publicize()
+	}
 	return &pub
 }
 
 // CreateNamespacePayload is the namespace create action payload.
 type CreateNamespacePayload struct {
 	Name string `form:"name" json:"name" xml:"name"`
+	// resource quota of the namespace
+	Quota *NamespaceQuota `form:"quota,omitempty" json:"quota,omitempty" xml:"quota,omitempty"`
 }
 
 // Validate runs the validation rules defined in the design.
@@ -468,6 +1222,11 @@ func (payload *CreateNamespacePayload) Validate() (err error) {
 	if payload.Name == "" {
 		err = goa.MergeErrors(err, goa.MissingAttributeError(`raw`, "name"))
 	}
+	if payload.Quota != nil {
+		if err2 := payload.Quota.Validate(); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	}
 	return
 }
 
@@ -627,6 +1386,109 @@ func (ctx *ListNamespaceContext) NotFound() error {
 	return nil
 }
 
+// UpdateNamespaceContext provides the namespace update action context.
+type UpdateNamespaceContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	Namespaceid string
+	Projectid   string
+	Payload     *UpdateNamespacePayload
+}
+
+// NewUpdateNamespaceContext parses the incoming request URL and body, performs validations and creates the
+// context used by the namespace controller update action.
+func NewUpdateNamespaceContext(ctx context.Context, r *http.Request, service *goa.Service) (*UpdateNamespaceContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := UpdateNamespaceContext{Context: ctx, ResponseData: resp, RequestData: req}
+	paramNamespaceid := req.Params["namespaceid"]
+	if len(paramNamespaceid) > 0 {
+		rawNamespaceid := paramNamespaceid[0]
+		rctx.Namespaceid = rawNamespaceid
+	}
+	paramProjectid := req.Params["projectid"]
+	if len(paramProjectid) > 0 {
+		rawProjectid := paramProjectid[0]
+		rctx.Projectid = rawProjectid
+	}
+	return &rctx, err
+}
+
+// updateNamespacePayload is the namespace update action payload.
+type updateNamespacePayload struct {
+	// resource quota of the namespace
+	Quota *namespaceQuota `form:"quota,omitempty" json:"quota,omitempty" xml:"quota,omitempty"`
+}
+
+// Validate runs the validation rules defined in the design.
+func (payload *updateNamespacePayload) Validate() (err error) {
+	if payload.Quota == nil {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`raw`, "quota"))
+	}
+	if payload.Quota != nil {
+		if err2 := payload.Quota.Validate(); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	}
+	return
+}
+
+// Publicize creates UpdateNamespacePayload from updateNamespacePayload
+func (payload *updateNamespacePayload) Publicize() *UpdateNamespacePayload {
+	var pub UpdateNamespacePayload
+	if payload.Quota != nil {
+		pub.Quota = payload.Quota.Publicize()
+	}
+	return &pub
+}
+
+// UpdateNamespacePayload is the namespace update action payload.
+type UpdateNamespacePayload struct {
+	// resource quota of the namespace
+	Quota *NamespaceQuota `form:"quota" json:"quota" xml:"quota"`
+}
+
+// Validate runs the validation rules defined in the design.
+func (payload *UpdateNamespacePayload) Validate() (err error) {
+	if payload.Quota == nil {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`raw`, "quota"))
+	}
+	if payload.Quota != nil {
+		if err2 := payload.Quota.Validate(); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	}
+	return
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *UpdateNamespaceContext) OK(r *Namespace) error {
+	ctx.ResponseData.Header().Set("Content-Type", "application/namespace+json")
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *UpdateNamespaceContext) BadRequest(r error) error {
+	ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *UpdateNamespaceContext) NotFound() error {
+	ctx.ResponseData.WriteHeader(404)
+	return nil
+}
+
+// InternalServerError sends a HTTP response with status code 500.
+func (ctx *UpdateNamespaceContext) InternalServerError() error {
+	ctx.ResponseData.WriteHeader(500)
+	return nil
+}
+
 // CreateProjectContext provides the project create action context.
 type CreateProjectContext struct {
 	context.Context
@@ -649,8 +1511,14 @@ func NewCreateProjectContext(ctx context.Context, r *http.Request, service *goa.
 
 // createProjectPayload is the project create action payload.
 type createProjectPayload struct {
+	// deny ingress traffic to the project's namespaces from outside of the project and its peers
+	Isolated *bool `form:"isolated,omitempty" json:"isolated,omitempty" xml:"isolated,omitempty"`
 	// name of project
 	Name *string `form:"name,omitempty" json:"name,omitempty" xml:"name,omitempty"`
+	// additional sources of ingress traffic allowed in an isolated project
+	Peers []*networkPeer `form:"peers,omitempty" json:"peers,omitempty" xml:"peers,omitempty"`
+	// cluster target the project is placed on, the default target when not given
+	Target *string `form:"target,omitempty" json:"target,omitempty" xml:"target,omitempty"`
 }
 
 // Validate runs the validation rules defined in the design.
@@ -669,16 +1537,34 @@ func (payload *createProjectPayload) Validate() (err error) {
 // Publicize creates CreateProjectPayload from createProjectPayload
 func (payload *createProjectPayload) Publicize() *CreateProjectPayload {
 	var pub CreateProjectPayload
+	if payload.Isolated != nil {
+		pub.Isolated = payload.Isolated
+	}
 	if payload.Name != nil {
 		pub.Name = *payload.Name
 	}
+	if payload.Peers != nil {
+		pub.Peers = make([]*NetworkPeer, len(payload.Peers))
+		for i2, elem2 := range payload.Peers {
+			pub.Peers[i2] = elem2.Publicize()
+		}
+	}
+	if payload.Target != nil {
+		pub.Target = payload.Target
+	}
 	return &pub
 }
 
 // CreateProjectPayload is the project create action payload.
 type CreateProjectPayload struct {
+	// deny ingress traffic to the project's namespaces from outside of the project and its peers
+	Isolated *bool `form:"isolated,omitempty" json:"isolated,omitempty" xml:"isolated,omitempty"`
 	// name of project
 	Name string `form:"name" json:"name" xml:"name"`
+	// additional sources of ingress traffic allowed in an isolated project
+	Peers []*NetworkPeer `form:"peers,omitempty" json:"peers,omitempty" xml:"peers,omitempty"`
+	// cluster target the project is placed on, the default target when not given
+	Target *string `form:"target,omitempty" json:"target,omitempty" xml:"target,omitempty"`
 }
 
 // Validate runs the validation rules defined in the design.
@@ -823,3 +1709,224 @@ func (ctx *ListProjectContext) OK(r ProjectCollection) error {
 	}
 	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
 }
+
+// UpdateProjectContext provides the project update action context.
+type UpdateProjectContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	Projectid string
+	Payload   *UpdateProjectPayload
+}
+
+// NewUpdateProjectContext parses the incoming request URL and body, performs validations and creates the
+// context used by the project controller update action.
+func NewUpdateProjectContext(ctx context.Context, r *http.Request, service *goa.Service) (*UpdateProjectContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := UpdateProjectContext{Context: ctx, ResponseData: resp, RequestData: req}
+	paramProjectid := req.Params["projectid"]
+	if len(paramProjectid) > 0 {
+		rawProjectid := paramProjectid[0]
+		rctx.Projectid = rawProjectid
+	}
+	return &rctx, err
+}
+
+// updateProjectPayload is the project update action payload.
+type updateProjectPayload struct {
+	// deny ingress traffic to the project's namespaces from outside of the project and its peers
+	Isolated *bool `form:"isolated,omitempty" json:"isolated,omitempty" xml:"isolated,omitempty"`
+	// additional sources of ingress traffic allowed in an isolated project
+	Peers []*networkPeer `form:"peers,omitempty" json:"peers,omitempty" xml:"peers,omitempty"`
+}
+
+// Publicize creates UpdateProjectPayload from updateProjectPayload
+func (payload *updateProjectPayload) Publicize() *UpdateProjectPayload {
+	var pub UpdateProjectPayload
+	if payload.Isolated != nil {
+		pub.Isolated = payload.Isolated
+	}
+	if payload.Peers != nil {
+		pub.Peers = make([]*NetworkPeer, len(payload.Peers))
+		for i2, elem2 := range payload.Peers {
+			pub.Peers[i2] = elem2.Publicize()
+		}
+	}
+	return &pub
+}
+
+// UpdateProjectPayload is the project update action payload.
+type UpdateProjectPayload struct {
+	// deny ingress traffic to the project's namespaces from outside of the project and its peers
+	Isolated *bool `form:"isolated,omitempty" json:"isolated,omitempty" xml:"isolated,omitempty"`
+	// additional sources of ingress traffic allowed in an isolated project
+	Peers []*NetworkPeer `form:"peers,omitempty" json:"peers,omitempty" xml:"peers,omitempty"`
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *UpdateProjectContext) OK(r *Project) error {
+	ctx.ResponseData.Header().Set("Content-Type", "application/project+json")
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *UpdateProjectContext) BadRequest(r error) error {
+	ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *UpdateProjectContext) NotFound() error {
+	ctx.ResponseData.WriteHeader(404)
+	return nil
+}
+
+// InternalServerError sends a HTTP response with status code 500.
+func (ctx *UpdateProjectContext) InternalServerError() error {
+	ctx.ResponseData.WriteHeader(500)
+	return nil
+}
+
+// DiffRevisionContext provides the revision diff action context.
+type DiffRevisionContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	Revision string
+	To       string
+}
+
+// NewDiffRevisionContext parses the incoming request URL and body, performs validations and creates the
+// context used by the revision controller diff action.
+func NewDiffRevisionContext(ctx context.Context, r *http.Request, service *goa.Service) (*DiffRevisionContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := DiffRevisionContext{Context: ctx, ResponseData: resp, RequestData: req}
+	paramRevision := req.Params["revision"]
+	if len(paramRevision) > 0 {
+		rawRevision := paramRevision[0]
+		rctx.Revision = rawRevision
+	}
+	paramTo := req.Params["to"]
+	if len(paramTo) == 0 {
+		rctx.To = "current"
+	} else {
+		rawTo := paramTo[0]
+		rctx.To = rawTo
+	}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *DiffRevisionContext) OK(r *ConfigDiff) error {
+	ctx.ResponseData.Header().Set("Content-Type", "application/config.diff+json")
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *DiffRevisionContext) BadRequest(r error) error {
+	ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *DiffRevisionContext) NotFound() error {
+	ctx.ResponseData.WriteHeader(404)
+	return nil
+}
+
+// ListRevisionContext provides the revision list action context.
+type ListRevisionContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+}
+
+// NewListRevisionContext parses the incoming request URL and body, performs validations and creates the
+// context used by the revision controller list action.
+func NewListRevisionContext(ctx context.Context, r *http.Request, service *goa.Service) (*ListRevisionContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := ListRevisionContext{Context: ctx, ResponseData: resp, RequestData: req}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *ListRevisionContext) OK(r ConfigRevisionCollection) error {
+	ctx.ResponseData.Header().Set("Content-Type", "application/config.revision+json; type=collection")
+	if r == nil {
+		r = ConfigRevisionCollection{}
+	}
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// RestoreRevisionContext provides the revision restore action context.
+type RestoreRevisionContext struct {
+	context.Context
+	*goa.ResponseData
+	*goa.RequestData
+	Revision string
+	Update   bool
+}
+
+// NewRestoreRevisionContext parses the incoming request URL and body, performs validations and creates the
+// context used by the revision controller restore action.
+func NewRestoreRevisionContext(ctx context.Context, r *http.Request, service *goa.Service) (*RestoreRevisionContext, error) {
+	var err error
+	resp := goa.ContextResponse(ctx)
+	resp.Service = service
+	req := goa.ContextRequest(ctx)
+	req.Request = r
+	rctx := RestoreRevisionContext{Context: ctx, ResponseData: resp, RequestData: req}
+	paramRevision := req.Params["revision"]
+	if len(paramRevision) > 0 {
+		rawRevision := paramRevision[0]
+		rctx.Revision = rawRevision
+	}
+	paramUpdate := req.Params["update"]
+	if len(paramUpdate) == 0 {
+		rctx.Update = false
+	} else {
+		rawUpdate := paramUpdate[0]
+		if update, err2 := strconv.ParseBool(rawUpdate); err2 == nil {
+			rctx.Update = update
+		} else {
+			err = goa.MergeErrors(err, goa.InvalidParamTypeError("update", rawUpdate, "boolean"))
+		}
+	}
+	return &rctx, err
+}
+
+// OK sends a HTTP response with status code 200.
+func (ctx *RestoreRevisionContext) OK(r *ConfigRevision) error {
+	ctx.ResponseData.Header().Set("Content-Type", "application/config.revision+json")
+	return ctx.ResponseData.Service.Send(ctx.Context, 200, r)
+}
+
+// BadRequest sends a HTTP response with status code 400.
+func (ctx *RestoreRevisionContext) BadRequest(r error) error {
+	ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	return ctx.ResponseData.Service.Send(ctx.Context, 400, r)
+}
+
+// NotFound sends a HTTP response with status code 404.
+func (ctx *RestoreRevisionContext) NotFound() error {
+	ctx.ResponseData.WriteHeader(404)
+	return nil
+}
+
+// Conflict sends a HTTP response with status code 409.
+func (ctx *RestoreRevisionContext) Conflict(r error) error {
+	ctx.ResponseData.Header().Set("Content-Type", "application/vnd.goa.error")
+	return ctx.ResponseData.Service.Send(ctx.Context, 409, r)
+}
@@ -34,6 +34,7 @@ type ApplicationController interface {
 	Delete(*DeleteApplicationContext) error
 	Get(*GetApplicationContext) error
 	List(*ListApplicationContext) error
+	Outdated(*OutdatedApplicationContext) error
 }
 
 // MountApplicationController "mounts" a Application resource controller on the given service.
@@ -59,8 +60,9 @@ func MountApplicationController(service *goa.Service, ctrl ApplicationController
 		}
 		return ctrl.Create(rctx)
 	}
+	h = handleSecurity("jwt", h)
 	service.Mux.Handle("POST", "/v1/projects/:projectid/applications", ctrl.MuxHandler("create", h, unmarshalCreateApplicationPayload))
-	service.LogInfo("mount", "ctrl", "Application", "action", "Create", "route", "POST /v1/projects/:projectid/applications")
+	service.LogInfo("mount", "ctrl", "Application", "action", "Create", "route", "POST /v1/projects/:projectid/applications", "security", "jwt")
 
 	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
 		// Check if there was an error loading the request
@@ -74,8 +76,9 @@ func MountApplicationController(service *goa.Service, ctrl ApplicationController
 		}
 		return ctrl.Delete(rctx)
 	}
+	h = handleSecurity("jwt", h)
 	service.Mux.Handle("DELETE", "/v1/projects/:projectid/applications/:appid", ctrl.MuxHandler("delete", h, nil))
-	service.LogInfo("mount", "ctrl", "Application", "action", "Delete", "route", "DELETE /v1/projects/:projectid/applications/:appid")
+	service.LogInfo("mount", "ctrl", "Application", "action", "Delete", "route", "DELETE /v1/projects/:projectid/applications/:appid", "security", "jwt")
 
 	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
 		// Check if there was an error loading the request
@@ -89,8 +92,9 @@ func MountApplicationController(service *goa.Service, ctrl ApplicationController
 		}
 		return ctrl.Get(rctx)
 	}
+	h = handleSecurity("jwt", h)
 	service.Mux.Handle("GET", "/v1/projects/:projectid/applications/:appid", ctrl.MuxHandler("get", h, nil))
-	service.LogInfo("mount", "ctrl", "Application", "action", "Get", "route", "GET /v1/projects/:projectid/applications/:appid")
+	service.LogInfo("mount", "ctrl", "Application", "action", "Get", "route", "GET /v1/projects/:projectid/applications/:appid", "security", "jwt")
 
 	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
 		// Check if there was an error loading the request
@@ -110,8 +114,25 @@ func MountApplicationController(service *goa.Service, ctrl ApplicationController
 		}
 		return ctrl.List(rctx)
 	}
+	h = handleSecurity("jwt", h)
 	service.Mux.Handle("GET", "/v1/projects/:projectid/applications", ctrl.MuxHandler("list", h, unmarshalListApplicationPayload))
-	service.LogInfo("mount", "ctrl", "Application", "action", "List", "route", "GET /v1/projects/:projectid/applications")
+	service.LogInfo("mount", "ctrl", "Application", "action", "List", "route", "GET /v1/projects/:projectid/applications", "security", "jwt")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewOutdatedApplicationContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		return ctrl.Outdated(rctx)
+	}
+	h = handleSecurity("jwt", h)
+	service.Mux.Handle("GET", "/v1/projects/:projectid/applications/outdated", ctrl.MuxHandler("outdated", h, nil))
+	service.LogInfo("mount", "ctrl", "Application", "action", "Outdated", "route", "GET /v1/projects/:projectid/applications/outdated", "security", "jwt")
 }
 
 // unmarshalCreateApplicationPayload unmarshals the request body into the context request data Payload field.
@@ -145,12 +166,41 @@ func unmarshalListApplicationPayload(ctx context.Context, service *goa.Service,
 	return nil
 }
 
+// AuditController is the controller interface for the Audit actions.
+type AuditController interface {
+	goa.Muxer
+	List(*ListAuditContext) error
+}
+
+// MountAuditController "mounts" a Audit resource controller on the given service.
+func MountAuditController(service *goa.Service, ctrl AuditController) {
+	initService(service)
+	var h goa.Handler
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewListAuditContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		return ctrl.List(rctx)
+	}
+	h = handleSecurity("jwt", h)
+	service.Mux.Handle("GET", "/v1/audit", ctrl.MuxHandler("list", h, nil))
+	service.LogInfo("mount", "ctrl", "Audit", "action", "List", "route", "GET /v1/audit", "security", "jwt")
+}
+
 // ClusterController is the controller interface for the Cluster actions.
 type ClusterController interface {
 	goa.Muxer
 	Create(*CreateClusterContext) error
 	Delete(*DeleteClusterContext) error
 	Get(*GetClusterContext) error
+	Update(*UpdateClusterContext) error
 }
 
 // MountClusterController "mounts" a Cluster resource controller on the given service.
@@ -176,8 +226,9 @@ func MountClusterController(service *goa.Service, ctrl ClusterController) {
 		}
 		return ctrl.Create(rctx)
 	}
+	h = handleSecurity("jwt", h)
 	service.Mux.Handle("POST", "/v1/projects/:projectid/cluster", ctrl.MuxHandler("create", h, unmarshalCreateClusterPayload))
-	service.LogInfo("mount", "ctrl", "Cluster", "action", "Create", "route", "POST /v1/projects/:projectid/cluster")
+	service.LogInfo("mount", "ctrl", "Cluster", "action", "Create", "route", "POST /v1/projects/:projectid/cluster", "security", "jwt")
 
 	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
 		// Check if there was an error loading the request
@@ -191,8 +242,9 @@ func MountClusterController(service *goa.Service, ctrl ClusterController) {
 		}
 		return ctrl.Delete(rctx)
 	}
+	h = handleSecurity("jwt", h)
 	service.Mux.Handle("DELETE", "/v1/projects/:projectid/cluster/:resource_id", ctrl.MuxHandler("delete", h, nil))
-	service.LogInfo("mount", "ctrl", "Cluster", "action", "Delete", "route", "DELETE /v1/projects/:projectid/cluster/:resource_id")
+	service.LogInfo("mount", "ctrl", "Cluster", "action", "Delete", "route", "DELETE /v1/projects/:projectid/cluster/:resource_id", "security", "jwt")
 
 	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
 		// Check if there was an error loading the request
@@ -206,8 +258,31 @@ func MountClusterController(service *goa.Service, ctrl ClusterController) {
 		}
 		return ctrl.Get(rctx)
 	}
+	h = handleSecurity("jwt", h)
 	service.Mux.Handle("GET", "/v1/projects/:projectid/cluster/:resource_id", ctrl.MuxHandler("get", h, nil))
-	service.LogInfo("mount", "ctrl", "Cluster", "action", "Get", "route", "GET /v1/projects/:projectid/cluster/:resource_id")
+	service.LogInfo("mount", "ctrl", "Cluster", "action", "Get", "route", "GET /v1/projects/:projectid/cluster/:resource_id", "security", "jwt")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewUpdateClusterContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		// Build the payload
+		if rawPayload := goa.ContextRequest(ctx).Payload; rawPayload != nil {
+			rctx.Payload = rawPayload.(*ClusterPutBody)
+		} else {
+			return goa.MissingPayloadError()
+		}
+		return ctrl.Update(rctx)
+	}
+	h = handleSecurity("jwt", h)
+	service.Mux.Handle("PUT", "/v1/projects/:projectid/cluster/:resource_id", ctrl.MuxHandler("update", h, unmarshalUpdateClusterPayload))
+	service.LogInfo("mount", "ctrl", "Cluster", "action", "Update", "route", "PUT /v1/projects/:projectid/cluster/:resource_id", "security", "jwt")
 }
 
 // unmarshalCreateClusterPayload unmarshals the request body into the context request data Payload field.
@@ -226,6 +301,121 @@ func unmarshalCreateClusterPayload(ctx context.Context, service *goa.Service, re
 	return nil
 }
 
+// unmarshalUpdateClusterPayload unmarshals the request body into the context request data Payload field.
+func unmarshalUpdateClusterPayload(ctx context.Context, service *goa.Service, req *http.Request) error {
+	payload := &clusterPutBody{}
+	if err := service.DecodeRequest(req, payload); err != nil {
+		return err
+	}
+	if err := payload.Validate(); err != nil {
+		// Initialize payload with private data structure so it can be logged
+		goa.ContextRequest(ctx).Payload = payload
+		return err
+	}
+	goa.ContextRequest(ctx).Payload = payload.Publicize()
+	return nil
+}
+
+// CredentialController is the controller interface for the Credential actions.
+type CredentialController interface {
+	goa.Muxer
+	Delete(*DeleteCredentialContext) error
+	Get(*GetCredentialContext) error
+	List(*ListCredentialContext) error
+	Update(*UpdateCredentialContext) error
+}
+
+// MountCredentialController "mounts" a Credential resource controller on the given service.
+func MountCredentialController(service *goa.Service, ctrl CredentialController) {
+	initService(service)
+	var h goa.Handler
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewDeleteCredentialContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		return ctrl.Delete(rctx)
+	}
+	h = handleSecurity("jwt", h)
+	service.Mux.Handle("DELETE", "/v1/projects/:projectid/credentials/:name", ctrl.MuxHandler("delete", h, nil))
+	service.LogInfo("mount", "ctrl", "Credential", "action", "Delete", "route", "DELETE /v1/projects/:projectid/credentials/:name", "security", "jwt")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewGetCredentialContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		return ctrl.Get(rctx)
+	}
+	h = handleSecurity("jwt", h)
+	service.Mux.Handle("GET", "/v1/projects/:projectid/credentials/:name", ctrl.MuxHandler("get", h, nil))
+	service.LogInfo("mount", "ctrl", "Credential", "action", "Get", "route", "GET /v1/projects/:projectid/credentials/:name", "security", "jwt")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewListCredentialContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		return ctrl.List(rctx)
+	}
+	h = handleSecurity("jwt", h)
+	service.Mux.Handle("GET", "/v1/projects/:projectid/credentials", ctrl.MuxHandler("list", h, nil))
+	service.LogInfo("mount", "ctrl", "Credential", "action", "List", "route", "GET /v1/projects/:projectid/credentials", "security", "jwt")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewUpdateCredentialContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		// Build the payload
+		if rawPayload := goa.ContextRequest(ctx).Payload; rawPayload != nil {
+			rctx.Payload = rawPayload.(*CredentialPutBody)
+		} else {
+			return goa.MissingPayloadError()
+		}
+		return ctrl.Update(rctx)
+	}
+	h = handleSecurity("jwt", h)
+	service.Mux.Handle("PUT", "/v1/projects/:projectid/credentials/:name", ctrl.MuxHandler("update", h, unmarshalUpdateCredentialPayload))
+	service.LogInfo("mount", "ctrl", "Credential", "action", "Update", "route", "PUT /v1/projects/:projectid/credentials/:name", "security", "jwt")
+}
+
+// unmarshalUpdateCredentialPayload unmarshals the request body into the context request data Payload field.
+func unmarshalUpdateCredentialPayload(ctx context.Context, service *goa.Service, req *http.Request) error {
+	payload := &credentialPutBody{}
+	if err := service.DecodeRequest(req, payload); err != nil {
+		return err
+	}
+	if err := payload.Validate(); err != nil {
+		// Initialize payload with private data structure so it can be logged
+		goa.ContextRequest(ctx).Payload = payload
+		return err
+	}
+	goa.ContextRequest(ctx).Payload = payload.Publicize()
+	return nil
+}
+
 // HealthController is the controller interface for the Health actions.
 type HealthController interface {
 	goa.Muxer
@@ -253,6 +443,151 @@ func MountHealthController(service *goa.Service, ctrl HealthController) {
 	service.LogInfo("mount", "ctrl", "Health", "action", "Health", "route", "GET /v1/healthz")
 }
 
+// KubeconfigController is the controller interface for the Kubeconfig actions.
+type KubeconfigController interface {
+	goa.Muxer
+	Get(*GetKubeconfigContext) error
+	Revoke(*RevokeKubeconfigContext) error
+	Rotate(*RotateKubeconfigContext) error
+}
+
+// MountKubeconfigController "mounts" a Kubeconfig resource controller on the given service.
+func MountKubeconfigController(service *goa.Service, ctrl KubeconfigController) {
+	initService(service)
+	var h goa.Handler
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewGetKubeconfigContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		return ctrl.Get(rctx)
+	}
+	h = handleSecurity("jwt", h)
+	service.Mux.Handle("GET", "/v1/projects/:projectid/kubeconfig", ctrl.MuxHandler("get", h, nil))
+	service.LogInfo("mount", "ctrl", "Kubeconfig", "action", "Get", "route", "GET /v1/projects/:projectid/kubeconfig", "security", "jwt")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewRevokeKubeconfigContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		return ctrl.Revoke(rctx)
+	}
+	h = handleSecurity("jwt", h)
+	service.Mux.Handle("DELETE", "/v1/projects/:projectid/kubeconfig", ctrl.MuxHandler("revoke", h, nil))
+	service.LogInfo("mount", "ctrl", "Kubeconfig", "action", "Revoke", "route", "DELETE /v1/projects/:projectid/kubeconfig", "security", "jwt")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewRotateKubeconfigContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		return ctrl.Rotate(rctx)
+	}
+	h = handleSecurity("jwt", h)
+	service.Mux.Handle("POST", "/v1/projects/:projectid/kubeconfig/rotate", ctrl.MuxHandler("rotate", h, nil))
+	service.LogInfo("mount", "ctrl", "Kubeconfig", "action", "Rotate", "route", "POST /v1/projects/:projectid/kubeconfig/rotate", "security", "jwt")
+}
+
+// MemberController is the controller interface for the Member actions.
+type MemberController interface {
+	goa.Muxer
+	Delete(*DeleteMemberContext) error
+	List(*ListMemberContext) error
+	Update(*UpdateMemberContext) error
+}
+
+// MountMemberController "mounts" a Member resource controller on the given service.
+func MountMemberController(service *goa.Service, ctrl MemberController) {
+	initService(service)
+	var h goa.Handler
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewDeleteMemberContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		return ctrl.Delete(rctx)
+	}
+	h = handleSecurity("jwt", h)
+	service.Mux.Handle("DELETE", "/v1/projects/:projectid/members/:caller", ctrl.MuxHandler("delete", h, nil))
+	service.LogInfo("mount", "ctrl", "Member", "action", "Delete", "route", "DELETE /v1/projects/:projectid/members/:caller", "security", "jwt")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewListMemberContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		return ctrl.List(rctx)
+	}
+	h = handleSecurity("jwt", h)
+	service.Mux.Handle("GET", "/v1/projects/:projectid/members", ctrl.MuxHandler("list", h, nil))
+	service.LogInfo("mount", "ctrl", "Member", "action", "List", "route", "GET /v1/projects/:projectid/members", "security", "jwt")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewUpdateMemberContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		// Build the payload
+		if rawPayload := goa.ContextRequest(ctx).Payload; rawPayload != nil {
+			rctx.Payload = rawPayload.(*UpdateMemberPayload)
+		} else {
+			return goa.MissingPayloadError()
+		}
+		return ctrl.Update(rctx)
+	}
+	h = handleSecurity("jwt", h)
+	service.Mux.Handle("PUT", "/v1/projects/:projectid/members/:caller", ctrl.MuxHandler("update", h, unmarshalUpdateMemberPayload))
+	service.LogInfo("mount", "ctrl", "Member", "action", "Update", "route", "PUT /v1/projects/:projectid/members/:caller", "security", "jwt")
+}
+
+// unmarshalUpdateMemberPayload unmarshals the request body into the context request data Payload field.
+func unmarshalUpdateMemberPayload(ctx context.Context, service *goa.Service, req *http.Request) error {
+	payload := &updateMemberPayload{}
+	if err := service.DecodeRequest(req, payload); err != nil {
+		return err
+	}
+	if err := payload.Validate(); err != nil {
+		// Initialize payload with private data structure so it can be logged
+		goa.ContextRequest(ctx).Payload = payload
+		return err
+	}
+	goa.ContextRequest(ctx).Payload = payload.Publicize()
+	return nil
+}
+
 // NamespaceController is the controller interface for the Namespace actions.
 type NamespaceController interface {
 	goa.Muxer
@@ -260,6 +595,7 @@ type NamespaceController interface {
 	Delete(*DeleteNamespaceContext) error
 	Get(*GetNamespaceContext) error
 	List(*ListNamespaceContext) error
+	Update(*UpdateNamespaceContext) error
 }
 
 // MountNamespaceController "mounts" a Namespace resource controller on the given service.
@@ -285,8 +621,9 @@ func MountNamespaceController(service *goa.Service, ctrl NamespaceController) {
 		}
 		return ctrl.Create(rctx)
 	}
+	h = handleSecurity("jwt", h)
 	service.Mux.Handle("POST", "/v1/projects/:projectid/namespaces", ctrl.MuxHandler("create", h, unmarshalCreateNamespacePayload))
-	service.LogInfo("mount", "ctrl", "Namespace", "action", "Create", "route", "POST /v1/projects/:projectid/namespaces")
+	service.LogInfo("mount", "ctrl", "Namespace", "action", "Create", "route", "POST /v1/projects/:projectid/namespaces", "security", "jwt")
 
 	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
 		// Check if there was an error loading the request
@@ -300,8 +637,9 @@ func MountNamespaceController(service *goa.Service, ctrl NamespaceController) {
 		}
 		return ctrl.Delete(rctx)
 	}
+	h = handleSecurity("jwt", h)
 	service.Mux.Handle("DELETE", "/v1/projects/:projectid/namespaces/:namespaceid", ctrl.MuxHandler("delete", h, nil))
-	service.LogInfo("mount", "ctrl", "Namespace", "action", "Delete", "route", "DELETE /v1/projects/:projectid/namespaces/:namespaceid")
+	service.LogInfo("mount", "ctrl", "Namespace", "action", "Delete", "route", "DELETE /v1/projects/:projectid/namespaces/:namespaceid", "security", "jwt")
 
 	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
 		// Check if there was an error loading the request
@@ -315,8 +653,9 @@ func MountNamespaceController(service *goa.Service, ctrl NamespaceController) {
 		}
 		return ctrl.Get(rctx)
 	}
+	h = handleSecurity("jwt", h)
 	service.Mux.Handle("GET", "/v1/projects/:projectid/namespaces/:namespaceid", ctrl.MuxHandler("get", h, nil))
-	service.LogInfo("mount", "ctrl", "Namespace", "action", "Get", "route", "GET /v1/projects/:projectid/namespaces/:namespaceid")
+	service.LogInfo("mount", "ctrl", "Namespace", "action", "Get", "route", "GET /v1/projects/:projectid/namespaces/:namespaceid", "security", "jwt")
 
 	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
 		// Check if there was an error loading the request
@@ -330,8 +669,31 @@ func MountNamespaceController(service *goa.Service, ctrl NamespaceController) {
 		}
 		return ctrl.List(rctx)
 	}
+	h = handleSecurity("jwt", h)
 	service.Mux.Handle("GET", "/v1/projects/:projectid/namespaces", ctrl.MuxHandler("list", h, nil))
-	service.LogInfo("mount", "ctrl", "Namespace", "action", "List", "route", "GET /v1/projects/:projectid/namespaces")
+	service.LogInfo("mount", "ctrl", "Namespace", "action", "List", "route", "GET /v1/projects/:projectid/namespaces", "security", "jwt")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewUpdateNamespaceContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		// Build the payload
+		if rawPayload := goa.ContextRequest(ctx).Payload; rawPayload != nil {
+			rctx.Payload = rawPayload.(*UpdateNamespacePayload)
+		} else {
+			return goa.MissingPayloadError()
+		}
+		return ctrl.Update(rctx)
+	}
+	h = handleSecurity("jwt", h)
+	service.Mux.Handle("PUT", "/v1/projects/:projectid/namespaces/:namespaceid", ctrl.MuxHandler("update", h, unmarshalUpdateNamespacePayload))
+	service.LogInfo("mount", "ctrl", "Namespace", "action", "Update", "route", "PUT /v1/projects/:projectid/namespaces/:namespaceid", "security", "jwt")
 }
 
 // unmarshalCreateNamespacePayload unmarshals the request body into the context request data Payload field.
@@ -349,6 +711,21 @@ func unmarshalCreateNamespacePayload(ctx context.Context, service *goa.Service,
 	return nil
 }
 
+// unmarshalUpdateNamespacePayload unmarshals the request body into the context request data Payload field.
+func unmarshalUpdateNamespacePayload(ctx context.Context, service *goa.Service, req *http.Request) error {
+	payload := &updateNamespacePayload{}
+	if err := service.DecodeRequest(req, payload); err != nil {
+		return err
+	}
+	if err := payload.Validate(); err != nil {
+		// Initialize payload with private data structure so it can be logged
+		goa.ContextRequest(ctx).Payload = payload
+		return err
+	}
+	goa.ContextRequest(ctx).Payload = payload.Publicize()
+	return nil
+}
+
 // OpenapiController is the controller interface for the Openapi actions.
 type OpenapiController interface {
 	goa.Muxer
@@ -380,6 +757,7 @@ type ProjectController interface {
 	Delete(*DeleteProjectContext) error
 	Get(*GetProjectContext) error
 	List(*ListProjectContext) error
+	Update(*UpdateProjectContext) error
 }
 
 // MountProjectController "mounts" a Project resource controller on the given service.
@@ -405,8 +783,9 @@ func MountProjectController(service *goa.Service, ctrl ProjectController) {
 		}
 		return ctrl.Create(rctx)
 	}
+	h = handleSecurity("jwt", h)
 	service.Mux.Handle("POST", "/v1/projects", ctrl.MuxHandler("create", h, unmarshalCreateProjectPayload))
-	service.LogInfo("mount", "ctrl", "Project", "action", "Create", "route", "POST /v1/projects")
+	service.LogInfo("mount", "ctrl", "Project", "action", "Create", "route", "POST /v1/projects", "security", "jwt")
 
 	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
 		// Check if there was an error loading the request
@@ -420,8 +799,9 @@ func MountProjectController(service *goa.Service, ctrl ProjectController) {
 		}
 		return ctrl.Delete(rctx)
 	}
+	h = handleSecurity("jwt", h)
 	service.Mux.Handle("DELETE", "/v1/projects/:projectid", ctrl.MuxHandler("delete", h, nil))
-	service.LogInfo("mount", "ctrl", "Project", "action", "Delete", "route", "DELETE /v1/projects/:projectid")
+	service.LogInfo("mount", "ctrl", "Project", "action", "Delete", "route", "DELETE /v1/projects/:projectid", "security", "jwt")
 
 	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
 		// Check if there was an error loading the request
@@ -435,8 +815,9 @@ func MountProjectController(service *goa.Service, ctrl ProjectController) {
 		}
 		return ctrl.Get(rctx)
 	}
+	h = handleSecurity("jwt", h)
 	service.Mux.Handle("GET", "/v1/projects/:projectid", ctrl.MuxHandler("get", h, nil))
-	service.LogInfo("mount", "ctrl", "Project", "action", "Get", "route", "GET /v1/projects/:projectid")
+	service.LogInfo("mount", "ctrl", "Project", "action", "Get", "route", "GET /v1/projects/:projectid", "security", "jwt")
 
 	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
 		// Check if there was an error loading the request
@@ -450,8 +831,31 @@ func MountProjectController(service *goa.Service, ctrl ProjectController) {
 		}
 		return ctrl.List(rctx)
 	}
+	h = handleSecurity("jwt", h)
 	service.Mux.Handle("GET", "/v1/projects", ctrl.MuxHandler("list", h, nil))
-	service.LogInfo("mount", "ctrl", "Project", "action", "List", "route", "GET /v1/projects")
+	service.LogInfo("mount", "ctrl", "Project", "action", "List", "route", "GET /v1/projects", "security", "jwt")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewUpdateProjectContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		// Build the payload
+		if rawPayload := goa.ContextRequest(ctx).Payload; rawPayload != nil {
+			rctx.Payload = rawPayload.(*UpdateProjectPayload)
+		} else {
+			return goa.MissingPayloadError()
+		}
+		return ctrl.Update(rctx)
+	}
+	h = handleSecurity("jwt", h)
+	service.Mux.Handle("PUT", "/v1/projects/:projectid", ctrl.MuxHandler("update", h, unmarshalUpdateProjectPayload))
+	service.LogInfo("mount", "ctrl", "Project", "action", "Update", "route", "PUT /v1/projects/:projectid", "security", "jwt")
 }
 
 // unmarshalCreateProjectPayload unmarshals the request body into the context request data Payload field.
@@ -469,6 +873,78 @@ func unmarshalCreateProjectPayload(ctx context.Context, service *goa.Service, re
 	return nil
 }
 
+// unmarshalUpdateProjectPayload unmarshals the request body into the context request data Payload field.
+func unmarshalUpdateProjectPayload(ctx context.Context, service *goa.Service, req *http.Request) error {
+	payload := &updateProjectPayload{}
+	if err := service.DecodeRequest(req, payload); err != nil {
+		return err
+	}
+	goa.ContextRequest(ctx).Payload = payload.Publicize()
+	return nil
+}
+
+// RevisionController is the controller interface for the Revision actions.
+type RevisionController interface {
+	goa.Muxer
+	Diff(*DiffRevisionContext) error
+	List(*ListRevisionContext) error
+	Restore(*RestoreRevisionContext) error
+}
+
+// MountRevisionController "mounts" a Revision resource controller on the given service.
+func MountRevisionController(service *goa.Service, ctrl RevisionController) {
+	initService(service)
+	var h goa.Handler
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewDiffRevisionContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		return ctrl.Diff(rctx)
+	}
+	h = handleSecurity("jwt", h)
+	service.Mux.Handle("GET", "/v1/config/revisions/:revision/diff", ctrl.MuxHandler("diff", h, nil))
+	service.LogInfo("mount", "ctrl", "Revision", "action", "Diff", "route", "GET /v1/config/revisions/:revision/diff", "security", "jwt")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewListRevisionContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		return ctrl.List(rctx)
+	}
+	h = handleSecurity("jwt", h)
+	service.Mux.Handle("GET", "/v1/config/revisions", ctrl.MuxHandler("list", h, nil))
+	service.LogInfo("mount", "ctrl", "Revision", "action", "List", "route", "GET /v1/config/revisions", "security", "jwt")
+
+	h = func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		// Check if there was an error loading the request
+		if err := goa.ContextError(ctx); err != nil {
+			return err
+		}
+		// Build the context
+		rctx, err := NewRestoreRevisionContext(ctx, req, service)
+		if err != nil {
+			return err
+		}
+		return ctrl.Restore(rctx)
+	}
+	h = handleSecurity("jwt", h)
+	service.Mux.Handle("POST", "/v1/config/revisions/:revision/restore", ctrl.MuxHandler("restore", h, nil))
+	service.LogInfo("mount", "ctrl", "Revision", "action", "Restore", "route", "POST /v1/config/revisions/:revision/restore", "security", "jwt")
+}
+
 // SwaggerController is the controller interface for the Swagger actions.
 type SwaggerController interface {
 	goa.Muxer
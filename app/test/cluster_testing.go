@@ -28,7 +28,7 @@ import (
 // It returns the response writer so it's possible to inspect the response headers and the media type struct written to the response.
 // If ctx is nil then context.Background() is used.
 // If service is nil then a default service is created.
-func CreateClusterAccepted(t goatest.TInterface, ctx context.Context, service *goa.Service, ctrl app.ClusterController, projectid string, payload *app.ClusterPostBody) (http.ResponseWriter, *app.Cluster) {
+func CreateClusterAccepted(t goatest.TInterface, ctx context.Context, service *goa.Service, ctrl app.ClusterController, projectid string, plan *bool, payload *app.ClusterPostBody) (http.ResponseWriter, *app.Cluster) {
 	// Setup service
 	var (
 		logBuf bytes.Buffer
@@ -59,8 +59,14 @@ func CreateClusterAccepted(t goatest.TInterface, ctx context.Context, service *g
 
 	// Setup request context
 	rw := httptest.NewRecorder()
+	query := url.Values{}
+	if plan != nil {
+		sliceVal := []string{fmt.Sprintf("%v", *plan)}
+		query["plan"] = sliceVal
+	}
 	u := &url.URL{
-		Path: fmt.Sprintf("/v1/projects/%v/cluster", projectid),
+		Path:     fmt.Sprintf("/v1/projects/%v/cluster", projectid),
+		RawQuery: query.Encode(),
 	}
 	req, _err := http.NewRequest("POST", u.String(), nil)
 	if _err != nil {
@@ -68,6 +74,10 @@ func CreateClusterAccepted(t goatest.TInterface, ctx context.Context, service *g
 	}
 	prms := url.Values{}
 	prms["projectid"] = []string{fmt.Sprintf("%v", projectid)}
+	if plan != nil {
+		sliceVal := []string{fmt.Sprintf("%v", *plan)}
+		prms["plan"] = sliceVal
+	}
 	if ctx == nil {
 		ctx = context.Background()
 	}
@@ -109,7 +119,7 @@ func CreateClusterAccepted(t goatest.TInterface, ctx context.Context, service *g
 // It returns the response writer so it's possible to inspect the response headers and the media type struct written to the response.
 // If ctx is nil then context.Background() is used.
 // If service is nil then a default service is created.
-func CreateClusterBadRequest(t goatest.TInterface, ctx context.Context, service *goa.Service, ctrl app.ClusterController, projectid string, payload *app.ClusterPostBody) (http.ResponseWriter, error) {
+func CreateClusterBadRequest(t goatest.TInterface, ctx context.Context, service *goa.Service, ctrl app.ClusterController, projectid string, plan *bool, payload *app.ClusterPostBody) (http.ResponseWriter, error) {
 	// Setup service
 	var (
 		logBuf bytes.Buffer
@@ -139,8 +149,14 @@ func CreateClusterBadRequest(t goatest.TInterface, ctx context.Context, service
 
 	// Setup request context
 	rw := httptest.NewRecorder()
+	query := url.Values{}
+	if plan != nil {
+		sliceVal := []string{fmt.Sprintf("%v", *plan)}
+		query["plan"] = sliceVal
+	}
 	u := &url.URL{
-		Path: fmt.Sprintf("/v1/projects/%v/cluster", projectid),
+		Path:     fmt.Sprintf("/v1/projects/%v/cluster", projectid),
+		RawQuery: query.Encode(),
 	}
 	req, _err := http.NewRequest("POST", u.String(), nil)
 	if _err != nil {
@@ -148,6 +164,10 @@ func CreateClusterBadRequest(t goatest.TInterface, ctx context.Context, service
 	}
 	prms := url.Values{}
 	prms["projectid"] = []string{fmt.Sprintf("%v", projectid)}
+	if plan != nil {
+		sliceVal := []string{fmt.Sprintf("%v", *plan)}
+		prms["plan"] = sliceVal
+	}
 	if ctx == nil {
 		ctx = context.Background()
 	}
@@ -185,7 +205,7 @@ func CreateClusterBadRequest(t goatest.TInterface, ctx context.Context, service
 // It returns the response writer so it's possible to inspect the response headers.
 // If ctx is nil then context.Background() is used.
 // If service is nil then a default service is created.
-func CreateClusterConflict(t goatest.TInterface, ctx context.Context, service *goa.Service, ctrl app.ClusterController, projectid string, payload *app.ClusterPostBody) http.ResponseWriter {
+func CreateClusterConflict(t goatest.TInterface, ctx context.Context, service *goa.Service, ctrl app.ClusterController, projectid string, plan *bool, payload *app.ClusterPostBody) http.ResponseWriter {
 	// Setup service
 	var (
 		logBuf bytes.Buffer
@@ -216,8 +236,14 @@ func CreateClusterConflict(t goatest.TInterface, ctx context.Context, service *g
 
 	// Setup request context
 	rw := httptest.NewRecorder()
+	query := url.Values{}
+	if plan != nil {
+		sliceVal := []string{fmt.Sprintf("%v", *plan)}
+		query["plan"] = sliceVal
+	}
 	u := &url.URL{
-		Path: fmt.Sprintf("/v1/projects/%v/cluster", projectid),
+		Path:     fmt.Sprintf("/v1/projects/%v/cluster", projectid),
+		RawQuery: query.Encode(),
 	}
 	req, _err := http.NewRequest("POST", u.String(), nil)
 	if _err != nil {
@@ -225,6 +251,10 @@ func CreateClusterConflict(t goatest.TInterface, ctx context.Context, service *g
 	}
 	prms := url.Values{}
 	prms["projectid"] = []string{fmt.Sprintf("%v", projectid)}
+	if plan != nil {
+		sliceVal := []string{fmt.Sprintf("%v", *plan)}
+		prms["plan"] = sliceVal
+	}
 	if ctx == nil {
 		ctx = context.Background()
 	}
@@ -254,7 +284,7 @@ func CreateClusterConflict(t goatest.TInterface, ctx context.Context, service *g
 // It returns the response writer so it's possible to inspect the response headers.
 // If ctx is nil then context.Background() is used.
 // If service is nil then a default service is created.
-func CreateClusterInternalServerError(t goatest.TInterface, ctx context.Context, service *goa.Service, ctrl app.ClusterController, projectid string, payload *app.ClusterPostBody) http.ResponseWriter {
+func CreateClusterInternalServerError(t goatest.TInterface, ctx context.Context, service *goa.Service, ctrl app.ClusterController, projectid string, plan *bool, payload *app.ClusterPostBody) http.ResponseWriter {
 	// Setup service
 	var (
 		logBuf bytes.Buffer
@@ -285,8 +315,14 @@ func CreateClusterInternalServerError(t goatest.TInterface, ctx context.Context,
 
 	// Setup request context
 	rw := httptest.NewRecorder()
+	query := url.Values{}
+	if plan != nil {
+		sliceVal := []string{fmt.Sprintf("%v", *plan)}
+		query["plan"] = sliceVal
+	}
 	u := &url.URL{
-		Path: fmt.Sprintf("/v1/projects/%v/cluster", projectid),
+		Path:     fmt.Sprintf("/v1/projects/%v/cluster", projectid),
+		RawQuery: query.Encode(),
 	}
 	req, _err := http.NewRequest("POST", u.String(), nil)
 	if _err != nil {
@@ -294,6 +330,10 @@ func CreateClusterInternalServerError(t goatest.TInterface, ctx context.Context,
 	}
 	prms := url.Values{}
 	prms["projectid"] = []string{fmt.Sprintf("%v", projectid)}
+	if plan != nil {
+		sliceVal := []string{fmt.Sprintf("%v", *plan)}
+		prms["plan"] = sliceVal
+	}
 	if ctx == nil {
 		ctx = context.Background()
 	}
@@ -323,7 +363,7 @@ func CreateClusterInternalServerError(t goatest.TInterface, ctx context.Context,
 // It returns the response writer so it's possible to inspect the response headers.
 // If ctx is nil then context.Background() is used.
 // If service is nil then a default service is created.
-func CreateClusterNotFound(t goatest.TInterface, ctx context.Context, service *goa.Service, ctrl app.ClusterController, projectid string, payload *app.ClusterPostBody) http.ResponseWriter {
+func CreateClusterNotFound(t goatest.TInterface, ctx context.Context, service *goa.Service, ctrl app.ClusterController, projectid string, plan *bool, payload *app.ClusterPostBody) http.ResponseWriter {
 	// Setup service
 	var (
 		logBuf bytes.Buffer
@@ -354,8 +394,14 @@ func CreateClusterNotFound(t goatest.TInterface, ctx context.Context, service *g
 
 	// Setup request context
 	rw := httptest.NewRecorder()
+	query := url.Values{}
+	if plan != nil {
+		sliceVal := []string{fmt.Sprintf("%v", *plan)}
+		query["plan"] = sliceVal
+	}
 	u := &url.URL{
-		Path: fmt.Sprintf("/v1/projects/%v/cluster", projectid),
+		Path:     fmt.Sprintf("/v1/projects/%v/cluster", projectid),
+		RawQuery: query.Encode(),
 	}
 	req, _err := http.NewRequest("POST", u.String(), nil)
 	if _err != nil {
@@ -363,6 +409,10 @@ func CreateClusterNotFound(t goatest.TInterface, ctx context.Context, service *g
 	}
 	prms := url.Values{}
 	prms["projectid"] = []string{fmt.Sprintf("%v", projectid)}
+	if plan != nil {
+		sliceVal := []string{fmt.Sprintf("%v", *plan)}
+		prms["plan"] = sliceVal
+	}
 	if ctx == nil {
 		ctx = context.Background()
 	}
@@ -388,11 +438,102 @@ func CreateClusterNotFound(t goatest.TInterface, ctx context.Context, service *g
 	return rw
 }
 
+// CreateClusterOK runs the method Create of the given controller with the given parameters and payload.
+// It returns the response writer so it's possible to inspect the response headers and the media type struct written to the response.
+// If ctx is nil then context.Background() is used.
+// If service is nil then a default service is created.
+func CreateClusterOK(t goatest.TInterface, ctx context.Context, service *goa.Service, ctrl app.ClusterController, projectid string, plan *bool, payload *app.ClusterPostBody) (http.ResponseWriter, *app.ClusterPlan) {
+	// Setup service
+	var (
+		logBuf bytes.Buffer
+		resp   interface{}
+
+		respSetter goatest.ResponseSetterFunc = func(r interface{}) { resp = r }
+	)
+	if service == nil {
+		service = goatest.Service(&logBuf, respSetter)
+	} else {
+		logger := log.New(&logBuf, "", log.Ltime)
+		service.WithLogger(goa.NewLogger(logger))
+		newEncoder := func(io.Writer) goa.Encoder { return respSetter }
+		service.Encoder = goa.NewHTTPEncoder() // Make sure the code ends up using this decoder
+		service.Encoder.Register(newEncoder, "*/*")
+	}
+
+	// Validate payload
+	err := payload.Validate()
+	if err != nil {
+		e, ok := err.(goa.ServiceError)
+		if !ok {
+			panic(err) // bug
+		}
+		t.Errorf("unexpected payload validation error: %+v", e)
+		return nil, nil
+	}
+
+	// Setup request context
+	rw := httptest.NewRecorder()
+	query := url.Values{}
+	if plan != nil {
+		sliceVal := []string{fmt.Sprintf("%v", *plan)}
+		query["plan"] = sliceVal
+	}
+	u := &url.URL{
+		Path:     fmt.Sprintf("/v1/projects/%v/cluster", projectid),
+		RawQuery: query.Encode(),
+	}
+	req, _err := http.NewRequest("POST", u.String(), nil)
+	if _err != nil {
+		panic("invalid test " + _err.Error()) // bug
+	}
+	prms := url.Values{}
+	prms["projectid"] = []string{fmt.Sprintf("%v", projectid)}
+	if plan != nil {
+		sliceVal := []string{fmt.Sprintf("%v", *plan)}
+		prms["plan"] = sliceVal
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	goaCtx := goa.NewContext(goa.WithAction(ctx, "ClusterTest"), rw, req, prms)
+	createCtx, __err := app.NewCreateClusterContext(goaCtx, req, service)
+	if __err != nil {
+		panic("invalid test data " + __err.Error()) // bug
+	}
+	createCtx.Payload = payload
+
+	// Perform action
+	__err = ctrl.Create(createCtx)
+
+	// Validate response
+	if __err != nil {
+		t.Fatalf("controller returned %+v, logs:\n%s", __err, logBuf.String())
+	}
+	if rw.Code != 200 {
+		t.Errorf("invalid response status code: got %+v, expected 200", rw.Code)
+	}
+	var mt *app.ClusterPlan
+	if resp != nil {
+		var _ok bool
+		mt, _ok = resp.(*app.ClusterPlan)
+		if !_ok {
+			t.Fatalf("invalid response media: got variable of type %T, value %+v, expected instance of app.ClusterPlan", resp, resp)
+		}
+		__err = mt.Validate()
+		if __err != nil {
+			t.Errorf("invalid response media type: %s", __err)
+		}
+	}
+
+	// Return results
+	return rw, mt
+}
+
 // DeleteClusterBadRequest runs the method Delete of the given controller with the given parameters.
 // It returns the response writer so it's possible to inspect the response headers and the media type struct written to the response.
 // If ctx is nil then context.Background() is used.
 // If service is nil then a default service is created.
-func DeleteClusterBadRequest(t goatest.TInterface, ctx context.Context, service *goa.Service, ctrl app.ClusterController, projectid string, resourceID string) (http.ResponseWriter, error) {
+func DeleteClusterBadRequest(t goatest.TInterface, ctx context.Context, service *goa.Service, ctrl app.ClusterController, projectid string, resourceID string, plan *bool) (http.ResponseWriter, error) {
 	// Setup service
 	var (
 		logBuf bytes.Buffer
@@ -412,8 +553,14 @@ func DeleteClusterBadRequest(t goatest.TInterface, ctx context.Context, service
 
 	// Setup request context
 	rw := httptest.NewRecorder()
+	query := url.Values{}
+	if plan != nil {
+		sliceVal := []string{fmt.Sprintf("%v", *plan)}
+		query["plan"] = sliceVal
+	}
 	u := &url.URL{
-		Path: fmt.Sprintf("/v1/projects/%v/cluster/%v", projectid, resourceID),
+		Path:     fmt.Sprintf("/v1/projects/%v/cluster/%v", projectid, resourceID),
+		RawQuery: query.Encode(),
 	}
 	req, err := http.NewRequest("DELETE", u.String(), nil)
 	if err != nil {
@@ -422,6 +569,10 @@ func DeleteClusterBadRequest(t goatest.TInterface, ctx context.Context, service
 	prms := url.Values{}
 	prms["projectid"] = []string{fmt.Sprintf("%v", projectid)}
 	prms["resource_id"] = []string{fmt.Sprintf("%v", resourceID)}
+	if plan != nil {
+		sliceVal := []string{fmt.Sprintf("%v", *plan)}
+		prms["plan"] = sliceVal
+	}
 	if ctx == nil {
 		ctx = context.Background()
 	}
@@ -458,7 +609,7 @@ func DeleteClusterBadRequest(t goatest.TInterface, ctx context.Context, service
 // It returns the response writer so it's possible to inspect the response headers.
 // If ctx is nil then context.Background() is used.
 // If service is nil then a default service is created.
-func DeleteClusterNoContent(t goatest.TInterface, ctx context.Context, service *goa.Service, ctrl app.ClusterController, projectid string, resourceID string) http.ResponseWriter {
+func DeleteClusterNoContent(t goatest.TInterface, ctx context.Context, service *goa.Service, ctrl app.ClusterController, projectid string, resourceID string, plan *bool) http.ResponseWriter {
 	// Setup service
 	var (
 		logBuf bytes.Buffer
@@ -478,8 +629,14 @@ func DeleteClusterNoContent(t goatest.TInterface, ctx context.Context, service *
 
 	// Setup request context
 	rw := httptest.NewRecorder()
+	query := url.Values{}
+	if plan != nil {
+		sliceVal := []string{fmt.Sprintf("%v", *plan)}
+		query["plan"] = sliceVal
+	}
 	u := &url.URL{
-		Path: fmt.Sprintf("/v1/projects/%v/cluster/%v", projectid, resourceID),
+		Path:     fmt.Sprintf("/v1/projects/%v/cluster/%v", projectid, resourceID),
+		RawQuery: query.Encode(),
 	}
 	req, err := http.NewRequest("DELETE", u.String(), nil)
 	if err != nil {
@@ -488,6 +645,10 @@ func DeleteClusterNoContent(t goatest.TInterface, ctx context.Context, service *
 	prms := url.Values{}
 	prms["projectid"] = []string{fmt.Sprintf("%v", projectid)}
 	prms["resource_id"] = []string{fmt.Sprintf("%v", resourceID)}
+	if plan != nil {
+		sliceVal := []string{fmt.Sprintf("%v", *plan)}
+		prms["plan"] = sliceVal
+	}
 	if ctx == nil {
 		ctx = context.Background()
 	}
@@ -516,7 +677,7 @@ func DeleteClusterNoContent(t goatest.TInterface, ctx context.Context, service *
 // It returns the response writer so it's possible to inspect the response headers.
 // If ctx is nil then context.Background() is used.
 // If service is nil then a default service is created.
-func DeleteClusterNotFound(t goatest.TInterface, ctx context.Context, service *goa.Service, ctrl app.ClusterController, projectid string, resourceID string) http.ResponseWriter {
+func DeleteClusterNotFound(t goatest.TInterface, ctx context.Context, service *goa.Service, ctrl app.ClusterController, projectid string, resourceID string, plan *bool) http.ResponseWriter {
 	// Setup service
 	var (
 		logBuf bytes.Buffer
@@ -536,8 +697,14 @@ func DeleteClusterNotFound(t goatest.TInterface, ctx context.Context, service *g
 
 	// Setup request context
 	rw := httptest.NewRecorder()
+	query := url.Values{}
+	if plan != nil {
+		sliceVal := []string{fmt.Sprintf("%v", *plan)}
+		query["plan"] = sliceVal
+	}
 	u := &url.URL{
-		Path: fmt.Sprintf("/v1/projects/%v/cluster/%v", projectid, resourceID),
+		Path:     fmt.Sprintf("/v1/projects/%v/cluster/%v", projectid, resourceID),
+		RawQuery: query.Encode(),
 	}
 	req, err := http.NewRequest("DELETE", u.String(), nil)
 	if err != nil {
@@ -546,6 +713,10 @@ func DeleteClusterNotFound(t goatest.TInterface, ctx context.Context, service *g
 	prms := url.Values{}
 	prms["projectid"] = []string{fmt.Sprintf("%v", projectid)}
 	prms["resource_id"] = []string{fmt.Sprintf("%v", resourceID)}
+	if plan != nil {
+		sliceVal := []string{fmt.Sprintf("%v", *plan)}
+		prms["plan"] = sliceVal
+	}
 	if ctx == nil {
 		ctx = context.Background()
 	}
@@ -570,6 +741,86 @@ func DeleteClusterNotFound(t goatest.TInterface, ctx context.Context, service *g
 	return rw
 }
 
+// DeleteClusterOK runs the method Delete of the given controller with the given parameters.
+// It returns the response writer so it's possible to inspect the response headers and the media type struct written to the response.
+// If ctx is nil then context.Background() is used.
+// If service is nil then a default service is created.
+func DeleteClusterOK(t goatest.TInterface, ctx context.Context, service *goa.Service, ctrl app.ClusterController, projectid string, resourceID string, plan *bool) (http.ResponseWriter, *app.ClusterPlan) {
+	// Setup service
+	var (
+		logBuf bytes.Buffer
+		resp   interface{}
+
+		respSetter goatest.ResponseSetterFunc = func(r interface{}) { resp = r }
+	)
+	if service == nil {
+		service = goatest.Service(&logBuf, respSetter)
+	} else {
+		logger := log.New(&logBuf, "", log.Ltime)
+		service.WithLogger(goa.NewLogger(logger))
+		newEncoder := func(io.Writer) goa.Encoder { return respSetter }
+		service.Encoder = goa.NewHTTPEncoder() // Make sure the code ends up using this decoder
+		service.Encoder.Register(newEncoder, "*/*")
+	}
+
+	// Setup request context
+	rw := httptest.NewRecorder()
+	query := url.Values{}
+	if plan != nil {
+		sliceVal := []string{fmt.Sprintf("%v", *plan)}
+		query["plan"] = sliceVal
+	}
+	u := &url.URL{
+		Path:     fmt.Sprintf("/v1/projects/%v/cluster/%v", projectid, resourceID),
+		RawQuery: query.Encode(),
+	}
+	req, err := http.NewRequest("DELETE", u.String(), nil)
+	if err != nil {
+		panic("invalid test " + err.Error()) // bug
+	}
+	prms := url.Values{}
+	prms["projectid"] = []string{fmt.Sprintf("%v", projectid)}
+	prms["resource_id"] = []string{fmt.Sprintf("%v", resourceID)}
+	if plan != nil {
+		sliceVal := []string{fmt.Sprintf("%v", *plan)}
+		prms["plan"] = sliceVal
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	goaCtx := goa.NewContext(goa.WithAction(ctx, "ClusterTest"), rw, req, prms)
+	deleteCtx, _err := app.NewDeleteClusterContext(goaCtx, req, service)
+	if _err != nil {
+		panic("invalid test data " + _err.Error()) // bug
+	}
+
+	// Perform action
+	_err = ctrl.Delete(deleteCtx)
+
+	// Validate response
+	if _err != nil {
+		t.Fatalf("controller returned %+v, logs:\n%s", _err, logBuf.String())
+	}
+	if rw.Code != 200 {
+		t.Errorf("invalid response status code: got %+v, expected 200", rw.Code)
+	}
+	var mt *app.ClusterPlan
+	if resp != nil {
+		var ok bool
+		mt, ok = resp.(*app.ClusterPlan)
+		if !ok {
+			t.Fatalf("invalid response media: got variable of type %T, value %+v, expected instance of app.ClusterPlan", resp, resp)
+		}
+		_err = mt.Validate()
+		if _err != nil {
+			t.Errorf("invalid response media type: %s", _err)
+		}
+	}
+
+	// Return results
+	return rw, mt
+}
+
 // GetClusterNotFound runs the method Get of the given controller with the given parameters.
 // It returns the response writer so it's possible to inspect the response headers.
 // If ctx is nil then context.Background() is used.
@@ -697,3 +948,434 @@ func GetClusterOK(t goatest.TInterface, ctx context.Context, service *goa.Servic
 	// Return results
 	return rw, mt
 }
+
+// UpdateClusterAccepted runs the method Update of the given controller with the given parameters and payload.
+// It returns the response writer so it's possible to inspect the response headers and the media type struct written to the response.
+// If ctx is nil then context.Background() is used.
+// If service is nil then a default service is created.
+func UpdateClusterAccepted(t goatest.TInterface, ctx context.Context, service *goa.Service, ctrl app.ClusterController, projectid string, resourceID string, plan *bool, payload *app.ClusterPutBody) (http.ResponseWriter, *app.Cluster) {
+	// Setup service
+	var (
+		logBuf bytes.Buffer
+		resp   interface{}
+
+		respSetter goatest.ResponseSetterFunc = func(r interface{}) { resp = r }
+	)
+	if service == nil {
+		service = goatest.Service(&logBuf, respSetter)
+	} else {
+		logger := log.New(&logBuf, "", log.Ltime)
+		service.WithLogger(goa.NewLogger(logger))
+		newEncoder := func(io.Writer) goa.Encoder { return respSetter }
+		service.Encoder = goa.NewHTTPEncoder() // Make sure the code ends up using this decoder
+		service.Encoder.Register(newEncoder, "*/*")
+	}
+
+	// Validate payload
+	err := payload.Validate()
+	if err != nil {
+		e, ok := err.(goa.ServiceError)
+		if !ok {
+			panic(err) // bug
+		}
+		t.Errorf("unexpected payload validation error: %+v", e)
+		return nil, nil
+	}
+
+	// Setup request context
+	rw := httptest.NewRecorder()
+	query := url.Values{}
+	if plan != nil {
+		sliceVal := []string{fmt.Sprintf("%v", *plan)}
+		query["plan"] = sliceVal
+	}
+	u := &url.URL{
+		Path:     fmt.Sprintf("/v1/projects/%v/cluster/%v", projectid, resourceID),
+		RawQuery: query.Encode(),
+	}
+	req, _err := http.NewRequest("PUT", u.String(), nil)
+	if _err != nil {
+		panic("invalid test " + _err.Error()) // bug
+	}
+	prms := url.Values{}
+	prms["projectid"] = []string{fmt.Sprintf("%v", projectid)}
+	prms["resource_id"] = []string{fmt.Sprintf("%v", resourceID)}
+	if plan != nil {
+		sliceVal := []string{fmt.Sprintf("%v", *plan)}
+		prms["plan"] = sliceVal
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	goaCtx := goa.NewContext(goa.WithAction(ctx, "ClusterTest"), rw, req, prms)
+	updateCtx, __err := app.NewUpdateClusterContext(goaCtx, req, service)
+	if __err != nil {
+		panic("invalid test data " + __err.Error()) // bug
+	}
+	updateCtx.Payload = payload
+
+	// Perform action
+	__err = ctrl.Update(updateCtx)
+
+	// Validate response
+	if __err != nil {
+		t.Fatalf("controller returned %+v, logs:\n%s", __err, logBuf.String())
+	}
+	if rw.Code != 202 {
+		t.Errorf("invalid response status code: got %+v, expected 202", rw.Code)
+	}
+	var mt *app.Cluster
+	if resp != nil {
+		var _ok bool
+		mt, _ok = resp.(*app.Cluster)
+		if !_ok {
+			t.Fatalf("invalid response media: got variable of type %T, value %+v, expected instance of app.Cluster", resp, resp)
+		}
+		__err = mt.Validate()
+		if __err != nil {
+			t.Errorf("invalid response media type: %s", __err)
+		}
+	}
+
+	// Return results
+	return rw, mt
+}
+
+// UpdateClusterBadRequest runs the method Update of the given controller with the given parameters and payload.
+// It returns the response writer so it's possible to inspect the response headers and the media type struct written to the response.
+// If ctx is nil then context.Background() is used.
+// If service is nil then a default service is created.
+func UpdateClusterBadRequest(t goatest.TInterface, ctx context.Context, service *goa.Service, ctrl app.ClusterController, projectid string, resourceID string, plan *bool, payload *app.ClusterPutBody) (http.ResponseWriter, error) {
+	// Setup service
+	var (
+		logBuf bytes.Buffer
+		resp   interface{}
+
+		respSetter goatest.ResponseSetterFunc = func(r interface{}) { resp = r }
+	)
+	if service == nil {
+		service = goatest.Service(&logBuf, respSetter)
+	} else {
+		logger := log.New(&logBuf, "", log.Ltime)
+		service.WithLogger(goa.NewLogger(logger))
+		newEncoder := func(io.Writer) goa.Encoder { return respSetter }
+		service.Encoder = goa.NewHTTPEncoder() // Make sure the code ends up using this decoder
+		service.Encoder.Register(newEncoder, "*/*")
+	}
+
+	// Validate payload
+	err := payload.Validate()
+	if err != nil {
+		e, ok := err.(goa.ServiceError)
+		if !ok {
+			panic(err) // bug
+		}
+		return nil, e
+	}
+
+	// Setup request context
+	rw := httptest.NewRecorder()
+	query := url.Values{}
+	if plan != nil {
+		sliceVal := []string{fmt.Sprintf("%v", *plan)}
+		query["plan"] = sliceVal
+	}
+	u := &url.URL{
+		Path:     fmt.Sprintf("/v1/projects/%v/cluster/%v", projectid, resourceID),
+		RawQuery: query.Encode(),
+	}
+	req, _err := http.NewRequest("PUT", u.String(), nil)
+	if _err != nil {
+		panic("invalid test " + _err.Error()) // bug
+	}
+	prms := url.Values{}
+	prms["projectid"] = []string{fmt.Sprintf("%v", projectid)}
+	prms["resource_id"] = []string{fmt.Sprintf("%v", resourceID)}
+	if plan != nil {
+		sliceVal := []string{fmt.Sprintf("%v", *plan)}
+		prms["plan"] = sliceVal
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	goaCtx := goa.NewContext(goa.WithAction(ctx, "ClusterTest"), rw, req, prms)
+	updateCtx, __err := app.NewUpdateClusterContext(goaCtx, req, service)
+	if __err != nil {
+		panic("invalid test data " + __err.Error()) // bug
+	}
+	updateCtx.Payload = payload
+
+	// Perform action
+	__err = ctrl.Update(updateCtx)
+
+	// Validate response
+	if __err != nil {
+		t.Fatalf("controller returned %+v, logs:\n%s", __err, logBuf.String())
+	}
+	if rw.Code != 400 {
+		t.Errorf("invalid response status code: got %+v, expected 400", rw.Code)
+	}
+	var mt error
+	if resp != nil {
+		var _ok bool
+		mt, _ok = resp.(error)
+		if !_ok {
+			t.Fatalf("invalid response media: got variable of type %T, value %+v, expected instance of error", resp, resp)
+		}
+	}
+
+	// Return results
+	return rw, mt
+}
+
+// UpdateClusterConflict runs the method Update of the given controller with the given parameters and payload.
+// It returns the response writer so it's possible to inspect the response headers.
+// If ctx is nil then context.Background() is used.
+// If service is nil then a default service is created.
+func UpdateClusterConflict(t goatest.TInterface, ctx context.Context, service *goa.Service, ctrl app.ClusterController, projectid string, resourceID string, plan *bool, payload *app.ClusterPutBody) http.ResponseWriter {
+	// Setup service
+	var (
+		logBuf bytes.Buffer
+		resp   interface{}
+
+		respSetter goatest.ResponseSetterFunc = func(r interface{}) { resp = r }
+	)
+	if service == nil {
+		service = goatest.Service(&logBuf, respSetter)
+	} else {
+		logger := log.New(&logBuf, "", log.Ltime)
+		service.WithLogger(goa.NewLogger(logger))
+		newEncoder := func(io.Writer) goa.Encoder { return respSetter }
+		service.Encoder = goa.NewHTTPEncoder() // Make sure the code ends up using this decoder
+		service.Encoder.Register(newEncoder, "*/*")
+	}
+
+	// Validate payload
+	err := payload.Validate()
+	if err != nil {
+		e, ok := err.(goa.ServiceError)
+		if !ok {
+			panic(err) // bug
+		}
+		t.Errorf("unexpected payload validation error: %+v", e)
+		return nil
+	}
+
+	// Setup request context
+	rw := httptest.NewRecorder()
+	query := url.Values{}
+	if plan != nil {
+		sliceVal := []string{fmt.Sprintf("%v", *plan)}
+		query["plan"] = sliceVal
+	}
+	u := &url.URL{
+		Path:     fmt.Sprintf("/v1/projects/%v/cluster/%v", projectid, resourceID),
+		RawQuery: query.Encode(),
+	}
+	req, _err := http.NewRequest("PUT", u.String(), nil)
+	if _err != nil {
+		panic("invalid test " + _err.Error()) // bug
+	}
+	prms := url.Values{}
+	prms["projectid"] = []string{fmt.Sprintf("%v", projectid)}
+	prms["resource_id"] = []string{fmt.Sprintf("%v", resourceID)}
+	if plan != nil {
+		sliceVal := []string{fmt.Sprintf("%v", *plan)}
+		prms["plan"] = sliceVal
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	goaCtx := goa.NewContext(goa.WithAction(ctx, "ClusterTest"), rw, req, prms)
+	updateCtx, __err := app.NewUpdateClusterContext(goaCtx, req, service)
+	if __err != nil {
+		panic("invalid test data " + __err.Error()) // bug
+	}
+	updateCtx.Payload = payload
+
+	// Perform action
+	__err = ctrl.Update(updateCtx)
+
+	// Validate response
+	if __err != nil {
+		t.Fatalf("controller returned %+v, logs:\n%s", __err, logBuf.String())
+	}
+	if rw.Code != 409 {
+		t.Errorf("invalid response status code: got %+v, expected 409", rw.Code)
+	}
+
+	// Return results
+	return rw
+}
+
+// UpdateClusterNotFound runs the method Update of the given controller with the given parameters and payload.
+// It returns the response writer so it's possible to inspect the response headers.
+// If ctx is nil then context.Background() is used.
+// If service is nil then a default service is created.
+func UpdateClusterNotFound(t goatest.TInterface, ctx context.Context, service *goa.Service, ctrl app.ClusterController, projectid string, resourceID string, plan *bool, payload *app.ClusterPutBody) http.ResponseWriter {
+	// Setup service
+	var (
+		logBuf bytes.Buffer
+		resp   interface{}
+
+		respSetter goatest.ResponseSetterFunc = func(r interface{}) { resp = r }
+	)
+	if service == nil {
+		service = goatest.Service(&logBuf, respSetter)
+	} else {
+		logger := log.New(&logBuf, "", log.Ltime)
+		service.WithLogger(goa.NewLogger(logger))
+		newEncoder := func(io.Writer) goa.Encoder { return respSetter }
+		service.Encoder = goa.NewHTTPEncoder() // Make sure the code ends up using this decoder
+		service.Encoder.Register(newEncoder, "*/*")
+	}
+
+	// Validate payload
+	err := payload.Validate()
+	if err != nil {
+		e, ok := err.(goa.ServiceError)
+		if !ok {
+			panic(err) // bug
+		}
+		t.Errorf("unexpected payload validation error: %+v", e)
+		return nil
+	}
+
+	// Setup request context
+	rw := httptest.NewRecorder()
+	query := url.Values{}
+	if plan != nil {
+		sliceVal := []string{fmt.Sprintf("%v", *plan)}
+		query["plan"] = sliceVal
+	}
+	u := &url.URL{
+		Path:     fmt.Sprintf("/v1/projects/%v/cluster/%v", projectid, resourceID),
+		RawQuery: query.Encode(),
+	}
+	req, _err := http.NewRequest("PUT", u.String(), nil)
+	if _err != nil {
+		panic("invalid test " + _err.Error()) // bug
+	}
+	prms := url.Values{}
+	prms["projectid"] = []string{fmt.Sprintf("%v", projectid)}
+	prms["resource_id"] = []string{fmt.Sprintf("%v", resourceID)}
+	if plan != nil {
+		sliceVal := []string{fmt.Sprintf("%v", *plan)}
+		prms["plan"] = sliceVal
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	goaCtx := goa.NewContext(goa.WithAction(ctx, "ClusterTest"), rw, req, prms)
+	updateCtx, __err := app.NewUpdateClusterContext(goaCtx, req, service)
+	if __err != nil {
+		panic("invalid test data " + __err.Error()) // bug
+	}
+	updateCtx.Payload = payload
+
+	// Perform action
+	__err = ctrl.Update(updateCtx)
+
+	// Validate response
+	if __err != nil {
+		t.Fatalf("controller returned %+v, logs:\n%s", __err, logBuf.String())
+	}
+	if rw.Code != 404 {
+		t.Errorf("invalid response status code: got %+v, expected 404", rw.Code)
+	}
+
+	// Return results
+	return rw
+}
+
+// UpdateClusterOK runs the method Update of the given controller with the given parameters and payload.
+// It returns the response writer so it's possible to inspect the response headers and the media type struct written to the response.
+// If ctx is nil then context.Background() is used.
+// If service is nil then a default service is created.
+func UpdateClusterOK(t goatest.TInterface, ctx context.Context, service *goa.Service, ctrl app.ClusterController, projectid string, resourceID string, plan *bool, payload *app.ClusterPutBody) (http.ResponseWriter, *app.ClusterPlan) {
+	// Setup service
+	var (
+		logBuf bytes.Buffer
+		resp   interface{}
+
+		respSetter goatest.ResponseSetterFunc = func(r interface{}) { resp = r }
+	)
+	if service == nil {
+		service = goatest.Service(&logBuf, respSetter)
+	} else {
+		logger := log.New(&logBuf, "", log.Ltime)
+		service.WithLogger(goa.NewLogger(logger))
+		newEncoder := func(io.Writer) goa.Encoder { return respSetter }
+		service.Encoder = goa.NewHTTPEncoder() // Make sure the code ends up using this decoder
+		service.Encoder.Register(newEncoder, "*/*")
+	}
+
+	// Validate payload
+	err := payload.Validate()
+	if err != nil {
+		e, ok := err.(goa.ServiceError)
+		if !ok {
+			panic(err) // bug
+		}
+		t.Errorf("unexpected payload validation error: %+v", e)
+		return nil, nil
+	}
+
+	// Setup request context
+	rw := httptest.NewRecorder()
+	query := url.Values{}
+	if plan != nil {
+		sliceVal := []string{fmt.Sprintf("%v", *plan)}
+		query["plan"] = sliceVal
+	}
+	u := &url.URL{
+		Path:     fmt.Sprintf("/v1/projects/%v/cluster/%v", projectid, resourceID),
+		RawQuery: query.Encode(),
+	}
+	req, _err := http.NewRequest("PUT", u.String(), nil)
+	if _err != nil {
+		panic("invalid test " + _err.Error()) // bug
+	}
+	prms := url.Values{}
+	prms["projectid"] = []string{fmt.Sprintf("%v", projectid)}
+	prms["resource_id"] = []string{fmt.Sprintf("%v", resourceID)}
+	if plan != nil {
+		sliceVal := []string{fmt.Sprintf("%v", *plan)}
+		prms["plan"] = sliceVal
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	goaCtx := goa.NewContext(goa.WithAction(ctx, "ClusterTest"), rw, req, prms)
+	updateCtx, __err := app.NewUpdateClusterContext(goaCtx, req, service)
+	if __err != nil {
+		panic("invalid test data " + __err.Error()) // bug
+	}
+	updateCtx.Payload = payload
+
+	// Perform action
+	__err = ctrl.Update(updateCtx)
+
+	// Validate response
+	if __err != nil {
+		t.Fatalf("controller returned %+v, logs:\n%s", __err, logBuf.String())
+	}
+	if rw.Code != 200 {
+		t.Errorf("invalid response status code: got %+v, expected 200", rw.Code)
+	}
+	var mt *app.ClusterPlan
+	if resp != nil {
+		var _ok bool
+		mt, _ok = resp.(*app.ClusterPlan)
+		if !_ok {
+			t.Fatalf("invalid response media: got variable of type %T, value %+v, expected instance of app.ClusterPlan", resp, resp)
+		}
+		__err = mt.Validate()
+		if __err != nil {
+			t.Errorf("invalid response media type: %s", __err)
+		}
+	}
+
+	// Return results
+	return rw, mt
+}
@@ -1,11 +1,17 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"krak8s/app"
+	"krak8s/commands"
+	"sync"
 	"time"
 
 	"github.com/goadesign/goa"
+	"github.com/golang/glog"
 )
 
 // ApplicationController implements the application resource.
@@ -26,21 +32,39 @@ func NewApplicationController(service *goa.Service, store *DataStore, backend *R
 
 // MarshalApplicationObject to project media type
 func MarshalApplicationObject(obj *ApplicationObject) *app.Application {
+	var constraint, credential, cluster, deployer *string
+	if obj.ChartConstraint != "" {
+		constraint = &obj.ChartConstraint
+	}
+	if obj.Credential != "" {
+		credential = &obj.Credential
+	}
+	if obj.Cluster != "" {
+		cluster = &obj.Cluster
+	}
+	if obj.Deployer != "" {
+		deployer = &obj.Deployer
+	}
 	return &app.Application{
-		ID:             obj.OID,
-		Type:           obj.ObjType,
-		NamespaceID:    obj.NamespaceID,
-		DeploymentName: obj.Deployment,
-		Server:         obj.Server,
-		Registry:       obj.ChartRegistry,
-		Name:           obj.ChartName,
-		Version:        obj.ChartVersion,
-		Channel:        obj.Channel,
-		Username:       obj.Username,
-		Config:         obj.Config,
-		JSONValues:     obj.JSONValues,
-		CreatedAt:      obj.CreatedAt,
-		UpdatedAt:      obj.UpdatedAt,
+		ID:                obj.OID,
+		Type:              obj.ObjType,
+		NamespaceID:       obj.NamespaceID,
+		DeploymentName:    obj.Deployment,
+		Server:            obj.Server,
+		Registry:          obj.ChartRegistry,
+		Name:              obj.ChartName,
+		Version:           obj.ChartVersion,
+		VersionConstraint: constraint,
+		Cluster:           cluster,
+		Deployer:          deployer,
+		Channel:           obj.Channel,
+		Username:          obj.Username,
+		Credential:        credential,
+		Config:            obj.Config,
+		JSONValues:        obj.JSONValues,
+		Values:            obj.Values,
+		CreatedAt:         obj.CreatedAt,
+		UpdatedAt:         obj.UpdatedAt,
 		Status: &struct {
 			DeployedAt time.Time `form:"deployed_at" json:"deployed_at" xml:"deployed_at"`
 			Notes      *string   `form:"notes,omitempty" json:"notes,omitempty" xml:"notes,omitempty"`
@@ -53,6 +77,70 @@ func MarshalApplicationObject(obj *ApplicationObject) *app.Application {
 	}
 }
 
+// applicationValues - parse and validate the chart values in the payload, so
+// that malformed values are rejected with the request rather than failing the
+// install later.  Returns the values_yaml document with the values object
+// merged over it, the json values and set string are merged over these by the
+// chart driver.
+func applicationValues(payload *app.ApplicationPostBody) (map[string]interface{}, error) {
+	var values map[string]interface{}
+	if payload.ValuesYaml != nil {
+		yamlValues, err := commands.UnmarshalValues(*payload.ValuesYaml)
+		if err != nil {
+			return nil, fmt.Errorf("invalid values_yaml: %v", err)
+		}
+		values = yamlValues
+	}
+	if payload.Values != nil {
+		values = commands.MergeValues(values, payload.Values)
+	}
+	if payload.JSONValues != nil && *payload.JSONValues != "" {
+		var jsonValues map[string]interface{}
+		if err := json.Unmarshal([]byte(*payload.JSONValues), &jsonValues); err != nil {
+			return nil, fmt.Errorf("invalid json_values: %v", err)
+		}
+	}
+	if payload.Set != nil {
+		if err := commands.ParseSet(*payload.Set, make(map[string]interface{})); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// outdatedTimeout - how long the outdated action waits for the registries of
+// the project's applications, those that don't answer in time are skipped
+var outdatedTimeout = 30 * time.Second
+
+// outdatedLookups - the number of registry lookups the outdated action runs
+// at once
+const outdatedLookups = 8
+
+// defaultChartServer - the registry server of the charts of applications that
+// name neither a server nor a credential
+const defaultChartServer = "quay.io"
+
+// resolveChartVersion - resolve the payload's chart version constraint to the
+// highest satisfying version in the chart's registry, logged in to with the
+// username and password if they're set.  In dry run mode the registry isn't
+// queried, an exact version is used as is, and the version of any other
+// constraint is left unresolved, empty.
+func resolveChartVersion(ctx context.Context, payload *app.ApplicationPostBody, username, password string) (string, error) {
+	constraint, err := commands.ParseVersionConstraint(payload.Version)
+	if err != nil {
+		return "", err
+	}
+	versions, err := commands.ChartVersions(ctx, *payload.Server, payload.Registry, payload.Name, username, password)
+	if err != nil {
+		return "", fmt.Errorf("unable to resolve chart version %q: %v", payload.Version, err)
+	}
+	if versions == nil {
+		version, _ := constraint.Exact()
+		return version, nil
+	}
+	return constraint.Resolve(versions)
+}
+
 // Create runs the create action.
 func (c *ApplicationController) Create(ctx *app.CreateApplicationContext) error {
 	// ApplicationController_Create: start_implement
@@ -76,25 +164,89 @@ func (c *ApplicationController) Create(ctx *app.CreateApplicationContext) error
 		return ctx.BadRequest(errors.New("Inavlid Namespace Object ID specified in request"))
 	}
 
+	values, err := applicationValues(ctx.Payload)
+	if err != nil {
+		return ctx.BadRequest(goa.ErrBadRequest(err))
+	}
+
+	if ctx.Payload.Cluster != nil {
+		if _, ok := c.ds.ResourceObject(ns.OID, *ctx.Payload.Cluster); !ok {
+			return ctx.BadRequest(goa.ErrBadRequest(fmt.Errorf("namespace %s has no cluster resource %s", ns.Name, *ctx.Payload.Cluster)))
+		}
+	}
+
+	server := defaultChartServer
+	if ctx.Payload.Server != nil {
+		server = *ctx.Payload.Server
+	}
+	var username, password string
+	if ctx.Payload.Username != nil && ctx.Payload.Password != nil {
+		username, password = *ctx.Payload.Username, *ctx.Payload.Password
+	}
+	if ctx.Payload.Credential != nil {
+		if ctx.Payload.Username != nil || ctx.Payload.Password != nil {
+			return ctx.BadRequest(goa.ErrBadRequest(errors.New("an application logs in with either a credential, or a username and password")))
+		}
+		cred, ok := c.ds.ProjectCredential(proj, *ctx.Payload.Credential)
+		if !ok {
+			return ctx.BadRequest(goa.ErrBadRequest(fmt.Errorf("project %s has no credential %s", proj.Name, *ctx.Payload.Credential)))
+		}
+		// the chart is pulled from the registry the credential logs in to
+		if ctx.Payload.Server != nil && *ctx.Payload.Server != cred.Server {
+			return ctx.BadRequest(goa.ErrBadRequest(fmt.Errorf("server %s isn't the server %s of credential %s", *ctx.Payload.Server, cred.Server, cred.Name)))
+		}
+		server = cred.Server
+		username = cred.Username
+		if password, err = c.ds.CredentialPassword(cred); err != nil {
+			glog.Errorf("unable to open the password of credential %s: %v", cred.Name, err)
+			return ctx.InternalServerError()
+		}
+	}
+	ctx.Payload.Server = &server
+
+	if ctx.Payload.Deployer != nil && *ctx.Payload.Deployer == DeployerKraken {
+		if ctx.Payload.Name == "mongodb-replicaset" {
+			return ctx.BadRequest(goa.ErrBadRequest(errors.New("the mongodb-replicaset chart is deployed by helm only")))
+		}
+		if username != "" && password != "" {
+			return ctx.BadRequest(goa.ErrBadRequest(errors.New("charts that need a registry login can't be deployed by kraken")))
+		}
+	}
+
+	version, err := resolveChartVersion(ctx, ctx.Payload, username, password)
+	if err != nil {
+		return ctx.BadRequest(goa.ErrBadRequest(err))
+	}
+
 	app := c.ds.NewApplication(
 		ctx.Payload.NamespaceID,
 		ctx.Payload.DeploymentName,
-		ctx.Payload.Server,
+		server,
 		ctx.Payload.Registry,
 		ctx.Payload.Name,
+		version,
 		ctx.Payload.Version,
 		ctx.Payload.Channel,
 		ctx.Payload.Username,
 		ctx.Payload.Password,
+		ctx.Payload.Credential,
 		ctx.Payload.Set,
-		ctx.Payload.JSONValues)
+		ctx.Payload.JSONValues,
+		ctx.Payload.Cluster,
+		ctx.Payload.Deployer,
+		values)
 	if app == nil {
 		return ctx.InternalServerError()
 	}
+	if err := c.backend.ValidateChart(ctx, c.ds, proj, ns, app); err != nil {
+		c.ds.DeleteApplication(app)
+		return ctx.BadRequest(goa.ErrBadRequest(err))
+	}
 	url := APIVersion + APIProjects + ctx.Projectid + APIApplications + app.OID
 	ns.Applications = append(ns.Applications, &ObjectLink{OID: app.OID, URL: url})
+	AuditObjects(ctx, app.OID)
 
-	c.backend.ChartRequest(AddChart, c.ds, proj, ns, app)
+	c.backend.ChartRequest(ctx, AddChart, c.ds, proj, ns, app)
 
 	return ctx.Accepted(MarshalApplicationObject(app))
 	// ApplicationController_Create: end_implement
@@ -112,7 +264,7 @@ func (c *ApplicationController) Delete(ctx *app.DeleteApplicationContext) error
 		return ctx.NotFound()
 	}
 	ns, ok := c.ds.Namespace(app.NamespaceID)
-	if !ok {
+	if !ok || !proj.HasNamespaceLink(ns.OID) {
 		return ctx.NotFound()
 	}
 
@@ -129,7 +281,7 @@ func (c *ApplicationController) Delete(ctx *app.DeleteApplicationContext) error
 		return ctx.BadRequest(errors.New("Inavlid Application Object ID specified in request"))
 	}
 
-	c.backend.ChartRequest(RemoveChart, c.ds, proj, ns, app)
+	c.backend.ChartRequest(ctx, RemoveChart, c.ds, proj, ns, app)
 
 	c.ds.DeleteApplication(app)
 
@@ -145,10 +297,14 @@ func (c *ApplicationController) Delete(ctx *app.DeleteApplicationContext) error
 // Get runs the get action.
 func (c *ApplicationController) Get(ctx *app.GetApplicationContext) error {
 	// ApplicationController_Get: start_implement
-	app, ok := c.ds.Application(ctx.Appid)
+	proj, ok := c.ds.Project(ctx.Projectid)
 	if !ok {
 		return ctx.NotFound()
 	}
+	app, ok := c.ds.Application(ctx.Appid)
+	if !ok || !proj.HasNamespaceLink(app.NamespaceID) {
+		return ctx.NotFound()
+	}
 	res := MarshalApplicationObject(app)
 	return ctx.OK(res)
 	// ApplicationController_Get: end_implement
@@ -157,13 +313,12 @@ func (c *ApplicationController) Get(ctx *app.GetApplicationContext) error {
 // List runs the list action.
 func (c *ApplicationController) List(ctx *app.ListApplicationContext) error {
 	// ApplicationController_List: start_implement
-	_, ok := c.ds.Project(ctx.Projectid)
+	proj, ok := c.ds.Project(ctx.Projectid)
 	if !ok {
 		return ctx.NotFound()
 	}
-	// TODO: validation step for project oid + namespace oid
 	_, ok = c.ds.Namespace(ctx.Payload.Namespaceid)
-	if !ok {
+	if !ok || !proj.HasNamespaceLink(ctx.Payload.Namespaceid) {
 		return ctx.NotFound()
 	}
 	collection := app.ApplicationCollection{}
@@ -180,3 +335,79 @@ func (c *ApplicationController) List(ctx *app.ListApplicationContext) error {
 	return ctx.OK(collection)
 	// ApplicationController_List: end_implement
 }
+
+// Outdated runs the outdated action.
+func (c *ApplicationController) Outdated(ctx *app.OutdatedApplicationContext) error {
+	// ApplicationController_Outdated: start_implement
+	proj, ok := c.ds.Project(ctx.Projectid)
+	if !ok {
+		return ctx.NotFound()
+	}
+	// The registries are queried concurrently, a few at a time, and all of
+	// them within the one deadline.
+	lookupCtx, cancel := context.WithTimeout(ctx, outdatedTimeout)
+	defer cancel()
+	apps := []*ApplicationObject{}
+	for _, nsLink := range proj.Namespaces {
+		apps = append(apps, c.ds.ApplicationsCollection(nsLink.OID)...)
+	}
+	results := make([]*app.ApplicationVersion, len(apps))
+	lookups := make(chan struct{}, outdatedLookups)
+	var wg sync.WaitGroup
+	for i, obj := range apps {
+		username, password, err := c.ds.ApplicationLogin(proj, obj)
+		if err != nil {
+			glog.Warningf("unable to check application %s for newer versions: %v", obj.OID, err)
+			continue
+		}
+		wg.Add(1)
+		go func(i int, obj *ApplicationObject, username, password string) {
+			defer wg.Done()
+			lookups <- struct{}{}
+			defer func() { <-lookups }()
+			results[i] = outdatedApplication(lookupCtx, obj, username, password)
+		}(i, obj, username, password)
+	}
+	wg.Wait()
+
+	collection := app.ApplicationVersionCollection{}
+	for _, outdated := range results {
+		if outdated != nil {
+			collection = append(collection, outdated)
+		}
+	}
+	return ctx.OK(collection)
+	// ApplicationController_Outdated: end_implement
+}
+
+// outdatedApplication - the application's version status if a newer chart
+// version is available, otherwise (or if the registry can't be read) nil.  The
+// username and password are those the application logs in to its registry
+// with, opened.  The registry isn't read once the context is done.
+func outdatedApplication(ctx context.Context, obj *ApplicationObject, username, password string) *app.ApplicationVersion {
+	versions, err := commands.ChartVersions(ctx, obj.Server, obj.ChartRegistry, obj.ChartName, username, password)
+	if err != nil {
+		glog.Warningf("unable to check application %s for newer versions: %v", obj.OID, err)
+		return nil
+	}
+	latest, err := commands.ResolveVersion(commands.LatestVersion, versions)
+	if err != nil || !commands.VersionLess(obj.ChartVersion, latest) {
+		return nil
+	}
+	outdated := &app.ApplicationVersion{
+		ID:             obj.OID,
+		NamespaceID:    obj.NamespaceID,
+		DeploymentName: obj.Deployment,
+		Name:           obj.ChartName,
+		Version:        obj.ChartVersion,
+		LatestVersion:  latest,
+	}
+	if obj.ChartConstraint != "" {
+		constraint := obj.ChartConstraint
+		outdated.VersionConstraint = &constraint
+		if matching, err := commands.ResolveVersion(constraint, versions); err == nil {
+			outdated.ConstraintVersion = &matching
+		}
+	}
+	return outdated
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"krak8s/app"
+	"krak8s/commands"
+)
+
+func TestApplicationValues(t *testing.T) {
+	doc := "image:\n  tag: \"1.0\"\n  pullPolicy: Always\nreplicas: 1\n"
+	payload := &app.ApplicationPostBody{
+		ValuesYaml: &doc,
+		Values:     map[string]interface{}{"image": map[string]interface{}{"tag": "2.0"}},
+	}
+	values, err := applicationValues(payload)
+	if err != nil {
+		t.Fatalf("applicationValues() have error %v, want nil", err)
+	}
+	image, ok := values["image"].(map[string]interface{})
+	if !ok || image["tag"] != "2.0" || image["pullPolicy"] != "Always" {
+		t.Errorf("applicationValues() have image %v, want tag: 2.0, pullPolicy: Always", values["image"])
+	}
+	if values["replicas"] != float64(1) {
+		t.Errorf("applicationValues() have replicas %v, want 1", values["replicas"])
+	}
+
+	badYaml := "image: [tag"
+	badJSON := `{"image": }`
+	badSet := "image.tag"
+	for _, payload := range []*app.ApplicationPostBody{
+		{ValuesYaml: &badYaml},
+		{JSONValues: &badJSON},
+		{Set: &badSet},
+	} {
+		if _, err := applicationValues(payload); err == nil {
+			t.Errorf("applicationValues(%+v) have nil, want error", payload)
+		}
+	}
+}
+
+func newTestRegistry() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cnr/api/v1/packages/samsung_cnct/mongodb" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`[{"release": "1.0.0"}, {"release": "1.2.0"}, {"release": "1.2.5"}, {"release": "2.0.0"}]`))
+	}))
+}
+
+func TestResolveChartVersion(t *testing.T) {
+	registry := newTestRegistry()
+	defer registry.Close()
+
+	payload := &app.ApplicationPostBody{Server: &registry.URL, Registry: "samsung_cnct", Name: "mongodb", Version: "~1.2"}
+	if version, err := resolveChartVersion(context.Background(), payload, "", ""); err != nil || version != "1.2.5" {
+		t.Errorf("resolveChartVersion(%s) have %q, %v, want 1.2.5", payload.Version, version, err)
+	}
+	for _, version := range []string{"^3", "not a version"} {
+		payload.Version = version
+		if have, err := resolveChartVersion(context.Background(), payload, "", ""); err == nil {
+			t.Errorf("resolveChartVersion(%s) have %q, want error", version, have)
+		}
+	}
+	payload.Version, payload.Name = "latest", "missing"
+	if have, err := resolveChartVersion(context.Background(), payload, "", ""); err == nil {
+		t.Errorf("resolveChartVersion(missing chart) have %q, want error", have)
+	}
+
+	// dry run leaves ranges unresolved
+	commands.SetDryrun(true)
+	defer commands.SetDryrun(false)
+	payload.Name = "mongodb"
+	for version, want := range map[string]string{"1.2.5": "1.2.5", "=1.2.5": "1.2.5", "~1.2": "", "latest": ""} {
+		payload.Version = version
+		if have, err := resolveChartVersion(context.Background(), payload, "", ""); err != nil || have != want {
+			t.Errorf("dry run resolveChartVersion(%s) have %q, %v, want %q", version, have, err, want)
+		}
+	}
+}
+
+func TestOutdatedApplication(t *testing.T) {
+	registry := newTestRegistry()
+	defer registry.Close()
+
+	obj := &ApplicationObject{OID: "e1ea1660", Server: registry.URL, ChartRegistry: "samsung_cnct",
+		ChartName: "mongodb", ChartVersion: "1.2.0", ChartConstraint: "~1.2"}
+	outdated := outdatedApplication(context.Background(), obj, "", "")
+	if outdated == nil {
+		t.Fatalf("outdatedApplication(%s) have nil, want newer versions", obj.ChartVersion)
+	}
+	if outdated.LatestVersion != "2.0.0" {
+		t.Errorf("outdatedApplication(%s) have latest %s, want 2.0.0", obj.ChartVersion, outdated.LatestVersion)
+	}
+	if outdated.ConstraintVersion == nil || *outdated.ConstraintVersion != "1.2.5" {
+		t.Errorf("outdatedApplication(%s) have constraint version %v, want 1.2.5", obj.ChartVersion, outdated.ConstraintVersion)
+	}
+
+	obj.ChartVersion = "2.0.0"
+	if outdated := outdatedApplication(context.Background(), obj, "", ""); outdated != nil {
+		t.Errorf("outdatedApplication(%s) have %v, want nil", obj.ChartVersion, outdated)
+	}
+
+	// past the deadline the registry isn't read
+	obj.ChartVersion = "1.2.0"
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if outdated := outdatedApplication(ctx, obj, "", ""); outdated != nil {
+		t.Errorf("outdatedApplication(done context) have %v, want nil", outdated)
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"krak8s/app"
+	"time"
+
+	"github.com/goadesign/goa"
+)
+
+// AuditController implements the audit resource.
+type AuditController struct {
+	*goa.Controller
+	log *AuditLog
+}
+
+// NewAuditController creates a audit controller.
+func NewAuditController(service *goa.Service, log *AuditLog) *AuditController {
+	return &AuditController{
+		Controller: service.NewController("AuditController"),
+		log:        log,
+	}
+}
+
+// MarshalAuditRecord to audit entry media type
+func MarshalAuditRecord(rec *AuditRecord) *app.AuditEntry {
+	mt := &app.AuditEntry{
+		Time:      rec.Time,
+		RequestID: rec.RequestID,
+		Kind:      rec.Kind,
+		Objects:   rec.Objects,
+		Payload:   rec.Payload,
+		Outcome:   rec.Outcome,
+		Command:   rec.Command,
+	}
+	if rec.Caller != "" {
+		mt.Caller = &rec.Caller
+	}
+	if rec.Action != "" {
+		mt.Action = &rec.Action
+	}
+	if rec.Method != "" {
+		mt.Method = &rec.Method
+	}
+	if rec.Path != "" {
+		mt.Path = &rec.Path
+	}
+	if rec.Status != 0 {
+		mt.Status = &rec.Status
+	}
+	if rec.Error != "" {
+		mt.Error = &rec.Error
+	}
+	return mt
+}
+
+// List runs the list action.
+func (c *AuditController) List(ctx *app.ListAuditContext) error {
+	// AuditController_List: start_implement
+	var since, until time.Time
+	var object string
+	if ctx.Since != nil {
+		since = *ctx.Since
+	}
+	if ctx.Until != nil {
+		until = *ctx.Until
+	}
+	if ctx.Object != nil {
+		object = *ctx.Object
+	}
+	records, err := c.log.Query(since, until, object)
+	if err != nil {
+		return err
+	}
+	collection := app.AuditEntryCollection{}
+	for _, rec := range records {
+		collection = append(collection, MarshalAuditRecord(rec))
+	}
+	return ctx.OK(collection)
+	// AuditController_List: end_implement
+}
@@ -0,0 +1,326 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goadesign/goa"
+	"github.com/goadesign/goa/middleware"
+	"github.com/golang/glog"
+)
+
+// Kinds of the audit log's entries
+const (
+	// AuditRequest - a create, update, or delete API request
+	AuditRequest = "request"
+	// AuditCommand - a backend command run for an API request
+	AuditCommand = "command"
+)
+
+// Outcomes of the audit log's entries
+const (
+	// AuditSuccess - the request was served, or the command succeeded
+	AuditSuccess = "success"
+	// AuditFailure - the request or the command failed
+	AuditFailure = "failure"
+	// AuditDenied - the request wasn't authenticated or authorized
+	AuditDenied = "denied"
+)
+
+// AuditRedacted - the value of the secrets redacted from the audit log
+const AuditRedacted = "REDACTED"
+
+// auditObjectParams - the path and payload parameters holding the oids of the
+// objects a request targets
+var auditObjectParams = []string{"projectid", "namespaceid", "appid", "resource_id", "namespace_id"}
+
+// auditSecretKeys - the payload attributes holding secrets, by lower case
+// substring of their names
+var auditSecretKeys = []string{"password", "secret", "token", "credential"}
+
+// auditOpaqueKeys - the payload attributes of chart values, whose secrets
+// can't be told apart by their names any more than in a text format, redacted
+// whole
+var auditOpaqueKeys = map[string]bool{"set": true, "json_values": true, "values_yaml": true, "values": true}
+
+// auditSecretFlags - the command flags whose values are secrets
+var auditSecretFlags = []string{"-p", "--password", "--token"}
+
+// AuditRecord - an entry of the audit log, of an API request or of a backend
+// command it ran, sharing the request's id
+type AuditRecord struct {
+	Time      time.Time              `json:"time"`
+	RequestID string                 `json:"request_id"`
+	Kind      string                 `json:"kind"`
+	Caller    string                 `json:"caller,omitempty"`
+	Action    string                 `json:"action,omitempty"`
+	Method    string                 `json:"method,omitempty"`
+	Path      string                 `json:"path,omitempty"`
+	Objects   []string               `json:"objects,omitempty"`
+	Payload   map[string]interface{} `json:"payload,omitempty"`
+	Status    int                    `json:"status,omitempty"`
+	Outcome   string                 `json:"outcome"`
+	Error     string                 `json:"error,omitempty"`
+	Command   []string               `json:"command,omitempty"`
+}
+
+// HasObject - true if the request targeted or created the object
+func (rec *AuditRecord) HasObject(oid string) bool {
+	for _, obj := range rec.Objects {
+		if obj == oid {
+			return true
+		}
+	}
+	return false
+}
+
+// addObject - add the object to the request's targets, once
+func (rec *AuditRecord) addObject(oid string) {
+	if oid != "" && !rec.HasObject(oid) {
+		rec.Objects = append(rec.Objects, oid)
+	}
+}
+
+type auditKey int
+
+const auditRecordKey auditKey = iota + 1
+
+// withAuditRecord - the context of the request audited by the record
+func withAuditRecord(ctx context.Context, rec *AuditRecord) context.Context {
+	return context.WithValue(ctx, auditRecordKey, rec)
+}
+
+// contextAuditRecord - the audit record of the context's request, nil for
+// requests that aren't audited
+func contextAuditRecord(ctx context.Context) *AuditRecord {
+	if rec, ok := ctx.Value(auditRecordKey).(*AuditRecord); ok {
+		return rec
+	}
+	return nil
+}
+
+// AuditCaller - record the authenticated caller of the context's request.
+func AuditCaller(ctx context.Context, caller *Caller) {
+	if rec := contextAuditRecord(ctx); rec != nil {
+		rec.Caller = caller.Name
+	}
+}
+
+// AuditObjects - record the objects the context's request created.
+func AuditObjects(ctx context.Context, oids ...string) {
+	if rec := contextAuditRecord(ctx); rec != nil {
+		for _, oid := range oids {
+			rec.addObject(oid)
+		}
+	}
+}
+
+// RedactPayload - the payload as attributes by name, with the values of its
+// secret attributes, at any depth, replaced by AuditRedacted.
+func RedactPayload(payload interface{}) map[string]interface{} {
+	if payload == nil {
+		return nil
+	}
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil
+	}
+	attributes := make(map[string]interface{})
+	if err = json.Unmarshal(raw, &attributes); err != nil {
+		return nil
+	}
+	redactSecrets(attributes)
+	return attributes
+}
+
+func redactSecrets(value interface{}) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, val := range value {
+			if auditSecret(key) {
+				value[key] = AuditRedacted
+			} else {
+				redactSecrets(val)
+			}
+		}
+	case []interface{}:
+		for _, val := range value {
+			redactSecrets(val)
+		}
+	}
+}
+
+func auditSecret(key string) bool {
+	key = strings.ToLower(key)
+	if auditOpaqueKeys[key] {
+		return true
+	}
+	for _, secret := range auditSecretKeys {
+		if strings.Contains(key, secret) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactCommand - the command and its arguments, with the values of its
+// secret flags replaced by AuditRedacted.
+func RedactCommand(command []string) []string {
+	redacted := make([]string, len(command))
+	copy(redacted, command)
+	for i := 1; i < len(redacted); i++ {
+		for _, flag := range auditSecretFlags {
+			if redacted[i-1] == flag {
+				redacted[i] = AuditRedacted
+			} else if strings.HasPrefix(redacted[i], flag+"=") {
+				redacted[i] = flag + "=" + AuditRedacted
+			}
+		}
+	}
+	return redacted
+}
+
+// AuditLog - the append only audit log file, one JSON entry per line.
+type AuditLog struct {
+	filename string
+	file     *os.File
+	mutex    sync.Mutex
+}
+
+// NewAuditLog - open the audit log file for appending, creating it if it
+// doesn't exist.
+func NewAuditLog(filename string) (*AuditLog, error) {
+	file, err := os.OpenFile(filename, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &AuditLog{filename: filename, file: file}, nil
+}
+
+// Append - write the entry to the end of the audit log.
+func (l *AuditLog) Append(rec *AuditRecord) error {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	_, err = l.file.Write(append(line, '\n'))
+	return err
+}
+
+// Query - the audit log's entries at or after since and before until, a zero
+// time not bounding them, oldest first.  With an object, only the entries of
+// the requests that targeted or created it, and of the commands they ran.
+func (l *AuditLog) Query(since, until time.Time, object string) ([]*AuditRecord, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	file, err := os.Open(l.filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	records := []*AuditRecord{}
+	requests := make(map[string]bool)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		rec := &AuditRecord{}
+		if err := json.Unmarshal(scanner.Bytes(), rec); err != nil {
+			glog.Warningf("skipping invalid audit log entry: %v", err)
+			continue
+		}
+		// a request's commands are logged after it, and may match the
+		// object by the request only
+		if object != "" {
+			if rec.Kind == AuditRequest && rec.HasObject(object) {
+				requests[rec.RequestID] = true
+			}
+			if !requests[rec.RequestID] {
+				continue
+			}
+		}
+		if (!since.IsZero() && rec.Time.Before(since)) || (!until.IsZero() && !rec.Time.Before(until)) {
+			continue
+		}
+		records = append(records, rec)
+	}
+	return records, scanner.Err()
+}
+
+// auditOutcome - the outcome of the request responded with the status
+func auditOutcome(status int) string {
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return AuditDenied
+	case status == 0 || status >= http.StatusBadRequest:
+		return AuditFailure
+	}
+	return AuditSuccess
+}
+
+// Middleware - the goa service middleware appending an entry for each create,
+// update, and delete request to the audit log, once it's responded to.  Use
+// after the RequestID middleware, and before the ErrorHandler middleware for
+// the status of the errors it responds with.
+func (l *AuditLog) Middleware() goa.Middleware {
+	return func(h goa.Handler) goa.Handler {
+		return func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+			if req.Method == "GET" || req.Method == "HEAD" || req.Method == "OPTIONS" {
+				return h(ctx, rw, req)
+			}
+			goaReq := goa.ContextRequest(ctx)
+			rec := &AuditRecord{
+				RequestID: middleware.ContextRequestID(ctx),
+				Kind:      AuditRequest,
+				Action:    goa.ContextController(ctx) + "." + goa.ContextAction(ctx),
+				Method:    req.Method,
+				Path:      req.URL.Path,
+				Payload:   RedactPayload(goaReq.Payload),
+			}
+			for _, name := range auditObjectParams {
+				rec.addObject(goaReq.Params.Get(name))
+				if oid, ok := rec.Payload[name].(string); ok {
+					rec.addObject(oid)
+				}
+			}
+
+			err := h(withAuditRecord(ctx, rec), rw, req)
+
+			rec.Time = time.Now()
+			rec.Status = goa.ContextResponse(ctx).Status
+			if err != nil {
+				rec.Error = err.Error()
+			}
+			rec.Outcome = auditOutcome(rec.Status)
+			if aerr := l.Append(rec); aerr != nil {
+				glog.Errorf("unable to append request %s to the audit log: %v", rec.RequestID, aerr)
+			}
+			return err
+		}
+	}
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/goadesign/goa"
+	"github.com/goadesign/goa/middleware"
+)
+
+func newTestAuditLog(t *testing.T) *AuditLog {
+	file, err := ioutil.TempFile(os.TempDir(), "test-audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+	log, err := NewAuditLog(file.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return log
+}
+
+func TestRedactPayload(t *testing.T) {
+	payload := struct {
+		Name       string                 `json:"name"`
+		Password   string                 `json:"password"`
+		ValuesYAML string                 `json:"values_yaml"`
+		Values     map[string]interface{} `json:"values"`
+	}{"myapp", "hunter2", "rootPassword: hunter2", map[string]interface{}{
+		"replicas": 3,
+		"auth":     map[string]interface{}{"adminPassword": "hunter2", "user": "admin"},
+		"mongodb":  map[string]interface{}{"rootKey": "hunter2"},
+	}}
+	redacted := RedactPayload(payload)
+	want := map[string]interface{}{
+		"name":        "myapp",
+		"password":    AuditRedacted,
+		"values_yaml": AuditRedacted,
+		"values":      AuditRedacted,
+	}
+	if !reflect.DeepEqual(redacted, want) {
+		t.Errorf("RedactPayload(), have: %v, want: %v", redacted, want)
+	}
+
+	command := RedactCommand([]string{"helm", "registry", "login", "-u", "bob", "-p", "hunter2", "--token=abc", "quay.io"})
+	want2 := []string{"helm", "registry", "login", "-u", "bob", "-p", AuditRedacted, "--token=" + AuditRedacted, "quay.io"}
+	if !reflect.DeepEqual(command, want2) {
+		t.Errorf("RedactCommand(), have: %v, want: %v", command, want2)
+	}
+}
+
+func TestAuditLogQuery(t *testing.T) {
+	log := newTestAuditLog(t)
+	defer os.Remove(log.filename)
+
+	start := time.Date(2017, 10, 19, 8, 0, 0, 0, time.UTC)
+	for _, rec := range []*AuditRecord{
+		{Time: start, RequestID: "a-1", Kind: AuditRequest, Objects: []string{"p1"}, Outcome: AuditSuccess},
+		{Time: start.Add(time.Minute), RequestID: "a-2", Kind: AuditRequest, Objects: []string{"p1", "r1"}, Outcome: AuditSuccess},
+		{Time: start.Add(2 * time.Minute), RequestID: "a-3", Kind: AuditRequest, Objects: []string{"p2"}, Outcome: AuditDenied},
+		{Time: start.Add(3 * time.Minute), RequestID: "a-2", Kind: AuditCommand, Command: []string{"k2"}, Outcome: AuditFailure},
+	} {
+		if err := log.Append(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, test := range []struct {
+		since, until time.Time
+		object       string
+		want         []string
+	}{
+		{time.Time{}, time.Time{}, "", []string{"a-1", "a-2", "a-3", "a-2"}},
+		{time.Time{}, time.Time{}, "r1", []string{"a-2", "a-2"}},
+		{start.Add(time.Minute), start.Add(3 * time.Minute), "", []string{"a-2", "a-3"}},
+		{start.Add(2 * time.Minute), time.Time{}, "r1", []string{"a-2"}},
+		{time.Time{}, time.Time{}, "p3", []string{}},
+	} {
+		records, err := log.Query(test.since, test.until, test.object)
+		if err != nil {
+			t.Fatal(err)
+		}
+		have := []string{}
+		for _, rec := range records {
+			have = append(have, rec.RequestID)
+		}
+		if !reflect.DeepEqual(have, test.want) {
+			t.Errorf("Query(%s, %s, %q), have: %v, want: %v", test.since, test.until, test.object, have, test.want)
+		}
+	}
+}
+
+func TestAuditLogMiddleware(t *testing.T) {
+	log := newTestAuditLog(t)
+	defer os.Remove(log.filename)
+
+	service := goa.New("test")
+	ctrl := service.NewController("ClusterController")
+	handler := middleware.RequestID()(log.Middleware()(func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+		AuditCaller(ctx, &Caller{Name: "alice"})
+		goa.ContextResponse(ctx).WriteHeader(http.StatusNoContent)
+		return nil
+	}))
+	for _, method := range []string{"GET", "DELETE"} {
+		req, _ := http.NewRequest(method, "/v1/projects/p1/cluster/r1", nil)
+		params := url.Values{"projectid": {"p1"}, "resource_id": {"r1"}}
+		rw := httptest.NewRecorder()
+		ctx := goa.NewContext(goa.WithAction(ctrl.Context, "delete"), rw, req, params)
+		if err := handler(ctx, goa.ContextResponse(ctx), req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	records, err := log.Query(time.Time{}, time.Time{}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("Middleware() entries, have: %d, want: 1", len(records))
+	}
+	rec := records[0]
+	if rec.RequestID == "" || rec.Caller != "alice" || rec.Action != "ClusterController.delete" || rec.Method != "DELETE" ||
+		rec.Status != http.StatusNoContent || rec.Outcome != AuditSuccess || !reflect.DeepEqual(rec.Objects, []string{"p1", "r1"}) {
+		t.Errorf("Middleware() entry, have: %+v", rec)
+	}
+}
+
+func TestRunnerAuditCommand(t *testing.T) {
+	log := newTestAuditLog(t)
+	defer os.Remove(log.filename)
+	r := NewRunner()
+	r.audit = log
+
+	// without a request the command isn't audited
+	r.auditCommand(context.Background(), "helm", []string{"list"}, nil)
+	// the running request's command
+	r.setRunning(&Request{requestID: "run-1", caller: "alice", requestType: AddChart})
+	r.auditCommand(context.Background(), "helm", []string{"install"}, nil)
+	// an API request's command, while another request is running
+	ctx := withAuditRecord(context.Background(), &AuditRecord{RequestID: "api-2", Caller: "bob", Action: "application.create"})
+	r.auditCommand(ctx, "helm", []string{"fetch"}, errors.New("not found"))
+	r.setRunning(nil)
+
+	records, err := log.Query(time.Time{}, time.Time{}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	have := []string{}
+	for _, rec := range records {
+		have = append(have, rec.RequestID+" "+rec.Caller+" "+rec.Action+" "+rec.Command[1]+" "+rec.Outcome)
+	}
+	want := []string{
+		"run-1 alice " + AddChart.String() + " install " + AuditSuccess,
+		"api-2 bob application.create fetch " + AuditFailure,
+	}
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("auditCommand() have %q, want %q", have, want)
+	}
+}
@@ -0,0 +1,353 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	_ "crypto/sha256" // JWT HS256 and RS256 hashes
+	_ "crypto/sha512" // JWT HS384, HS512, RS384 and RS512 hashes
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/goadesign/goa"
+	"github.com/golang/glog"
+	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// SecretKeyPrefix - prefix of a key source naming a Kubernetes Secret,
+// secret:<namespace>/<name>, rather than a file
+const SecretKeyPrefix = "secret:"
+
+// authKeysRefresh - how long the keys of a key source are used before they
+// are read again, so that replaced keys are picked up without a restart
+var authKeysRefresh = time.Minute
+
+// Caller - the authenticated caller of an API request, named by its API key
+// or by its JWT's subject, an admin of krak8s when listed with --admins
+type Caller struct {
+	Name   string
+	Scheme string
+	Admin  bool
+}
+
+type callerKey struct{}
+
+// WithCaller - the context of a request made by the caller.
+func WithCaller(ctx context.Context, caller *Caller) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// ContextCaller - the caller of the request, nil when the API is served
+// without authentication.
+func ContextCaller(ctx context.Context) *Caller {
+	if caller, ok := ctx.Value(callerKey{}).(*Caller); ok {
+		return caller
+	}
+	return nil
+}
+
+// KeySource - named keys: API keys by caller name, or JWT keys
+type KeySource interface {
+	Keys() (map[string][]byte, error)
+}
+
+// fileKeySource - the API keys of a YAML file, by caller name, or the JWT
+// key of a file, named after the file
+type fileKeySource struct {
+	filename string
+	named    bool
+}
+
+func (s *fileKeySource) Keys() (map[string][]byte, error) {
+	data, err := ioutil.ReadFile(s.filename)
+	if err != nil {
+		return nil, err
+	}
+	if !s.named {
+		return map[string][]byte{path.Base(s.filename): data}, nil
+	}
+	named := make(map[string]string)
+	if err = yaml.Unmarshal(data, &named); err != nil {
+		return nil, err
+	}
+	keys := make(map[string][]byte)
+	for name, key := range named {
+		keys[name] = []byte(key)
+	}
+	return keys, nil
+}
+
+// secretKeySource - the keys of a Kubernetes Secret's data items, by item
+// name
+type secretKeySource struct {
+	secrets v1core.SecretInterface
+	name    string
+}
+
+func (s *secretKeySource) Keys() (map[string][]byte, error) {
+	secret, err := s.secrets.Get(s.name)
+	if err != nil {
+		return nil, err
+	}
+	return secret.Data, nil
+}
+
+// NewKeySource - the key source of a file name, or of a Kubernetes Secret
+// given as secret:<namespace>/<name>.  A file of API keys, named, is a YAML
+// map of keys by caller name.
+func NewKeySource(spec string, secrets func(namespace string) v1core.SecretInterface, named bool) (KeySource, error) {
+	if !strings.HasPrefix(spec, SecretKeyPrefix) {
+		return &fileKeySource{filename: spec, named: named}, nil
+	}
+	ref := strings.SplitN(strings.TrimPrefix(spec, SecretKeyPrefix), "/", 2)
+	if len(ref) != 2 || ref[0] == "" || ref[1] == "" {
+		return nil, fmt.Errorf("key source %s is not %s<namespace>/<name>", spec, SecretKeyPrefix)
+	}
+	return &secretKeySource{secrets: secrets(ref[0]), name: ref[1]}, nil
+}
+
+// cachedKeys - the keys of a key source, read again after authKeysRefresh.
+// Keys that can't be read again are logged, and the keys read before kept.
+type cachedKeys struct {
+	source KeySource
+	lock   sync.Mutex
+	keys   map[string][]byte
+	read   time.Time
+}
+
+func (c *cachedKeys) Keys() map[string][]byte {
+	if c == nil {
+		return nil
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.keys != nil && time.Since(c.read) < authKeysRefresh {
+		return c.keys
+	}
+	keys, err := c.source.Keys()
+	if err != nil {
+		glog.Errorf("unable to read authentication keys: %v", err)
+		return c.keys
+	}
+	for name, key := range keys {
+		keys[name] = bytes.TrimSpace(key)
+	}
+	c.keys = keys
+	c.read = time.Now()
+	return c.keys
+}
+
+// Authenticator - authenticates API requests with an API key in the
+// X-Api-Key header, or a JWT bearer token in the Authorization header.
+type Authenticator struct {
+	apiKeys  *cachedKeys
+	jwtKeys  *cachedKeys
+	disabled bool
+}
+
+// NewAuthenticator - the authenticator of the API keys and JWT keys sources.
+// Without either, every request is rejected.  A disabled authenticator lets
+// every request through.
+func NewAuthenticator(apiKeys, jwtKeys KeySource, disabled bool) *Authenticator {
+	auth := &Authenticator{disabled: disabled}
+	if apiKeys != nil {
+		auth.apiKeys = &cachedKeys{source: apiKeys}
+	}
+	if jwtKeys != nil {
+		auth.jwtKeys = &cachedKeys{source: jwtKeys}
+	}
+	return auth
+}
+
+// Authenticate - the caller of the request.
+func (a *Authenticator) Authenticate(req *http.Request) (*Caller, error) {
+	if key := req.Header.Get("X-Api-Key"); key != "" {
+		for name, value := range a.apiKeys.Keys() {
+			if len(value) > 0 && subtle.ConstantTimeCompare([]byte(key), value) == 1 {
+				return &Caller{Name: name, Scheme: "api_key"}, nil
+			}
+		}
+		return nil, errors.New("API key is not valid")
+	}
+	authorization := req.Header.Get("Authorization")
+	if !strings.HasPrefix(authorization, "Bearer ") {
+		return nil, errors.New("request has no API key or bearer token")
+	}
+	claims, err := VerifyJWT(strings.TrimPrefix(authorization, "Bearer "), a.jwtKeys.Keys(), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &Caller{Name: claims.Subject, Scheme: "jwt"}, nil
+}
+
+// Middleware - the goa middleware of the api_key and jwt security schemes,
+// responding 401 Unauthorized to requests without a valid API key or token.
+func (a *Authenticator) Middleware() goa.Middleware {
+	return func(h goa.Handler) goa.Handler {
+		return func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+			if a.disabled {
+				return h(ctx, rw, req)
+			}
+			caller, err := a.Authenticate(req)
+			if err != nil {
+				return goa.ErrUnauthorized(err)
+			}
+			AuditCaller(ctx, caller)
+			return h(WithCaller(ctx, caller), rw, req)
+		}
+	}
+}
+
+// JWTClaims - the JWT claims krak8s checks
+type JWTClaims struct {
+	Subject   string `json:"sub"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+	NotBefore int64  `json:"nbf,omitempty"`
+}
+
+// VerifyJWT - the claims of a JWT signed with one of the keys, an HMAC
+// secret for HS256, HS384 and HS512, or a PEM RSA public key or certificate
+// for RS256, RS384 and RS512.  The token needs a subject, and must not have
+// expired.
+func VerifyJWT(token string, keys map[string][]byte, now time.Time) (*JWTClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("bearer token is not a JWT")
+	}
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return nil, err
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("JWT signature is malformed: %v", err)
+	}
+	verified := false
+	for _, key := range keys {
+		if verifyJWTSignature(header.Alg, key, parts[0]+"."+parts[1], signature) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, errors.New("JWT signature is not valid")
+	}
+
+	claims := &JWTClaims{}
+	if err = decodeJWTPart(parts[1], claims); err != nil {
+		return nil, err
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("JWT has no subject")
+	}
+	if claims.ExpiresAt != 0 && now.Unix() >= claims.ExpiresAt {
+		return nil, errors.New("JWT has expired")
+	}
+	if claims.NotBefore != 0 && now.Unix() < claims.NotBefore {
+		return nil, errors.New("JWT is not valid yet")
+	}
+	return claims, nil
+}
+
+func decodeJWTPart(part string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return fmt.Errorf("JWT is malformed: %v", err)
+	}
+	if err = json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("JWT is malformed: %v", err)
+	}
+	return nil
+}
+
+var jwtHashes = map[string]crypto.Hash{"256": crypto.SHA256, "384": crypto.SHA384, "512": crypto.SHA512}
+
+func verifyJWTSignature(alg string, key []byte, signed string, signature []byte) bool {
+	if len(alg) != 5 {
+		return false
+	}
+	hash, ok := jwtHashes[alg[2:]]
+	if !ok {
+		return false
+	}
+	publicKeys := rsaPublicKeys(key)
+	switch alg[:2] {
+	case "HS":
+		// an RSA public key is public, and never an HMAC secret
+		if len(publicKeys) > 0 {
+			return false
+		}
+		mac := hmac.New(hash.New, key)
+		mac.Write([]byte(signed))
+		return hmac.Equal(mac.Sum(nil), signature)
+	case "RS":
+		digest := hash.New()
+		digest.Write([]byte(signed))
+		for _, publicKey := range publicKeys {
+			if rsa.VerifyPKCS1v15(publicKey, hash, digest.Sum(nil), signature) == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// rsaPublicKeys - the RSA public keys of the PEM blocks of a key.
+func rsaPublicKeys(key []byte) []*rsa.PublicKey {
+	keys := []*rsa.PublicKey{}
+	for {
+		var block *pem.Block
+		block, key = pem.Decode(key)
+		if block == nil {
+			return keys
+		}
+		var publicKey interface{}
+		var err error
+		switch block.Type {
+		case "PUBLIC KEY":
+			publicKey, err = x509.ParsePKIXPublicKey(block.Bytes)
+		case "RSA PUBLIC KEY":
+			publicKey, err = x509.ParsePKCS1PublicKey(block.Bytes)
+		case "CERTIFICATE":
+			var cert *x509.Certificate
+			if cert, err = x509.ParseCertificate(block.Bytes); err == nil {
+				publicKey = cert.PublicKey
+			}
+		}
+		if rsaKey, ok := publicKey.(*rsa.PublicKey); ok && err == nil {
+			keys = append(keys, rsaKey)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type staticKeys map[string][]byte
+
+func (s staticKeys) Keys() (map[string][]byte, error) {
+	keys := make(map[string][]byte)
+	for name, key := range s {
+		keys[name] = key
+	}
+	return keys, nil
+}
+
+func signJWT(alg string, claims string, sign func(signed []byte) []byte) string {
+	signed := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"`+alg+`","typ":"JWT"}`)) + "." +
+		base64.RawURLEncoding.EncodeToString([]byte(claims))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sign([]byte(signed)))
+}
+
+func TestVerifyJWT(t *testing.T) {
+	now := time.Unix(1500000000, 0)
+	secret := []byte("b4f1c2d8e6a7")
+	hs256 := func(signed []byte) []byte {
+		mac := hmac.New(sha256.New, secret)
+		mac.Write(signed)
+		return mac.Sum(nil)
+	}
+	keys := map[string][]byte{"hmac": secret}
+
+	claims, err := VerifyJWT(signJWT("HS256", `{"sub":"alice","exp":1500000060}`, hs256), keys, now)
+	if err != nil || claims.Subject != "alice" {
+		t.Errorf("VerifyJWT() HS256, have: %v %v", claims, err)
+	}
+	for _, token := range []string{
+		signJWT("HS256", `{"sub":"alice","exp":1499999999}`, hs256),
+		signJWT("HS256", `{"sub":"alice","nbf":1500000060}`, hs256),
+		signJWT("HS256", `{"exp":1500000060}`, hs256),
+		signJWT("HS256", `{"sub":"alice"}`, func(signed []byte) []byte { return []byte("forged") }),
+		signJWT("none", `{"sub":"alice"}`, func(signed []byte) []byte { return nil }),
+		"alice",
+	} {
+		if _, err = VerifyJWT(token, keys, now); err == nil {
+			t.Errorf("VerifyJWT() of %s, want: error", token)
+		}
+	}
+
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&private.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	public := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	rs256 := func(signed []byte) []byte {
+		digest := sha256.Sum256(signed)
+		signature, err := rsa.SignPKCS1v15(rand.Reader, private, crypto.SHA256, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signature
+	}
+	keys = map[string][]byte{"hmac": secret, "rsa": public}
+	if claims, err = VerifyJWT(signJWT("RS256", `{"sub":"ci"}`, rs256), keys, now); err != nil || claims.Subject != "ci" {
+		t.Errorf("VerifyJWT() RS256, have: %v %v", claims, err)
+	}
+
+	// an RSA public key isn't an HMAC secret, whoever has it can't sign
+	hsPublic := func(signed []byte) []byte {
+		mac := hmac.New(sha256.New, public)
+		mac.Write(signed)
+		return mac.Sum(nil)
+	}
+	if _, err = VerifyJWT(signJWT("HS256", `{"sub":"ci"}`, hsPublic), keys, now); err == nil {
+		t.Errorf("VerifyJWT() HS256 signed with the RSA public key, want: error")
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	auth := NewAuthenticator(staticKeys{"ci": []byte("0c5e9d13\n")}, nil, false)
+
+	req, _ := http.NewRequest("GET", "/v1/projects", nil)
+	if _, err := auth.Authenticate(req); err == nil {
+		t.Errorf("Authenticate() without credentials, want: error")
+	}
+	req.Header.Set("X-Api-Key", "0c5e9d13")
+	if caller, err := auth.Authenticate(req); err != nil || caller.Name != "ci" || caller.Scheme != "api_key" {
+		t.Errorf("Authenticate() API key, have: %v %v", caller, err)
+	}
+	req.Header.Set("X-Api-Key", "0c5e9d14")
+	if _, err := auth.Authenticate(req); err == nil {
+		t.Errorf("Authenticate() wrong API key, want: error")
+	}
+	req.Header.Del("X-Api-Key")
+	req.Header.Set("Authorization", "Bearer 0c5e9d13")
+	if _, err := auth.Authenticate(req); err == nil {
+		t.Errorf("Authenticate() API key as a bearer token, want: error")
+	}
+}
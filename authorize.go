@@ -0,0 +1,164 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/goadesign/goa"
+)
+
+// Roles of the callers on a project, each allowing the actions of the roles
+// below it
+const (
+	// RoleAdmin - the project's owners, who also delete the project and
+	// manage its members
+	RoleAdmin = "admin"
+	// RoleOperator - manages the project's namespaces, node pools,
+	// applications and kubeconfigs
+	RoleOperator = "operator"
+	// RoleViewer - reads the project and its objects
+	RoleViewer = "viewer"
+)
+
+var roleRanks = map[string]int{RoleViewer: 1, RoleOperator: 2, RoleAdmin: 3}
+
+// ErrForbidden - the error class of requests the caller's role doesn't allow
+var ErrForbidden = goa.NewErrorClass("forbidden", 403)
+
+// RoleAllows - true if the role allows the actions of the required role.
+func RoleAllows(role, required string) bool {
+	return roleRanks[role] > 0 && roleRanks[role] >= roleRanks[required]
+}
+
+// ProjectRole - the caller's role on the project, "" without one.  Admins of
+// krak8s, and the callers of an API served without authentication, are
+// admins of every project.
+func ProjectRole(caller *Caller, proj *ProjectObject) string {
+	if caller == nil || caller.Admin {
+		return RoleAdmin
+	}
+	return proj.MemberRole(caller.Name)
+}
+
+// actionRoles - the role each action needs on the request's project, by
+// controller and action.  Actions needing a role without a project in their
+// path are for the admins of krak8s, and actions that aren't listed are
+// forbidden to everyone else.  An empty role is any caller's.
+var actionRoles = map[string]string{
+	"ProjectController.list":         "",
+	"ProjectController.create":       "",
+	"ProjectController.get":          RoleViewer,
+	"ProjectController.update":       RoleAdmin,
+	"ProjectController.delete":       RoleAdmin,
+	"MemberController.list":          RoleViewer,
+	"MemberController.update":        RoleAdmin,
+	"MemberController.delete":        RoleAdmin,
+	"NamespaceController.list":       RoleViewer,
+	"NamespaceController.get":        RoleViewer,
+	"NamespaceController.create":     RoleOperator,
+	"NamespaceController.update":     RoleOperator,
+	"NamespaceController.delete":     RoleOperator,
+	"ClusterController.get":          RoleViewer,
+	"ClusterController.create":       RoleOperator,
+	"ClusterController.update":       RoleOperator,
+	"ClusterController.delete":       RoleOperator,
+	"ApplicationController.list":     RoleViewer,
+	"ApplicationController.get":      RoleViewer,
+	"ApplicationController.outdated": RoleViewer,
+	"ApplicationController.create":   RoleOperator,
+	"ApplicationController.delete":   RoleOperator,
+	"CredentialController.list":      RoleViewer,
+	"CredentialController.get":       RoleViewer,
+	"CredentialController.update":    RoleOperator,
+	"CredentialController.delete":    RoleOperator,
+	"KubeconfigController.get":       RoleOperator,
+	"KubeconfigController.rotate":    RoleOperator,
+	"KubeconfigController.revoke":    RoleAdmin,
+	"RevisionController.list":        RoleAdmin,
+	"RevisionController.diff":        RoleAdmin,
+	"RevisionController.restore":     RoleAdmin,
+	"AuditController.list":           RoleAdmin,
+}
+
+// Authorizer - authorizes the authenticated callers' requests by their role
+// on the request's project.
+type Authorizer struct {
+	ds     *DataStore
+	admins map[string]bool
+}
+
+// NewAuthorizer - the authorizer of the data store's projects, the named
+// callers being admins of krak8s.
+func NewAuthorizer(ds *DataStore, admins []string) *Authorizer {
+	authz := &Authorizer{ds: ds, admins: make(map[string]bool)}
+	for _, name := range admins {
+		if name != "" {
+			authz.admins[name] = true
+		}
+	}
+	return authz
+}
+
+// Authorize - nil if the caller may run the action of the controller, with
+// the request's path parameters.
+func (a *Authorizer) Authorize(caller *Caller, controller, action string, params map[string][]string) error {
+	if caller.Admin {
+		return nil
+	}
+	required, ok := actionRoles[controller+"."+action]
+	if !ok {
+		return fmt.Errorf("%s %s is for admins", controller, action)
+	}
+	if required == "" {
+		return nil
+	}
+	if len(params["projectid"]) == 0 {
+		return fmt.Errorf("%s %s is for admins", controller, action)
+	}
+	proj, ok := a.ds.Project(params["projectid"][0])
+	if !ok {
+		// the controller responds 404 Not Found
+		return nil
+	}
+	if role := ProjectRole(caller, proj); !RoleAllows(role, required) {
+		return fmt.Errorf("caller %s needs the %s role on project %s", caller.Name, required, proj.OID)
+	}
+	return nil
+}
+
+// Middleware - the goa middleware authorizing the requests of the callers
+// authenticated by the security schemes' middleware before it, responding
+// 403 Forbidden to requests the caller's role doesn't allow.
+func (a *Authorizer) Middleware() goa.Middleware {
+	return func(h goa.Handler) goa.Handler {
+		return func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+			caller := ContextCaller(ctx)
+			if caller == nil {
+				return h(ctx, rw, req)
+			}
+			caller.Admin = a.admins[caller.Name]
+			err := a.Authorize(caller, goa.ContextController(ctx), goa.ContextAction(ctx), goa.ContextRequest(ctx).Params)
+			if err != nil {
+				return ErrForbidden(err)
+			}
+			return h(ctx, rw, req)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import "testing"
+
+func TestProjectMembers(t *testing.T) {
+	ds := NewDataStore("")
+	go ds.Archiver()
+	proj := ds.NewProject("acme", "")
+
+	ds.UpdateProjectMember(proj, "alice", RoleAdmin)
+	ds.UpdateProjectMember(proj, "bob", RoleViewer)
+	if role := proj.MemberRole("alice"); role != RoleAdmin || len(proj.Owners) != 1 {
+		t.Errorf("UpdateProjectMember() owner, have: %q %v", role, proj.Owners)
+	}
+	ds.UpdateProjectMember(proj, "bob", RoleAdmin)
+	ds.UpdateProjectMember(proj, "alice", RoleOperator)
+	if proj.MemberRole("bob") != RoleAdmin || proj.MemberRole("alice") != RoleOperator || len(proj.Owners) != 1 {
+		t.Errorf("UpdateProjectMember() rebound, have: %v %v", proj.Owners, proj.Members)
+	}
+	ds.DeleteProjectMember(proj, "alice")
+	if role := proj.MemberRole("alice"); role != "" {
+		t.Errorf("DeleteProjectMember(), have: %q", role)
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	ds := NewDataStore("")
+	go ds.Archiver()
+	proj := ds.NewProject("acme", "")
+	ds.UpdateProjectMember(proj, "alice", RoleAdmin)
+	ds.UpdateProjectMember(proj, "bob", RoleOperator)
+	ds.UpdateProjectMember(proj, "carol", RoleViewer)
+	authz := NewAuthorizer(ds, []string{"root"})
+	params := map[string][]string{"projectid": {proj.OID}}
+
+	for _, test := range []struct {
+		caller     string
+		controller string
+		action     string
+		allowed    bool
+	}{
+		{"carol", "NamespaceController", "get", true},
+		{"carol", "NamespaceController", "create", false},
+		{"bob", "NamespaceController", "create", true},
+		{"bob", "ProjectController", "delete", false},
+		{"bob", "MemberController", "update", false},
+		{"alice", "MemberController", "update", true},
+		{"carol", "CredentialController", "update", false},
+		{"bob", "CredentialController", "update", true},
+		{"dave", "ProjectController", "get", false},
+		{"dave", "ProjectController", "create", true},
+		{"alice", "UnknownController", "get", false},
+	} {
+		err := authz.Authorize(&Caller{Name: test.caller}, test.controller, test.action, params)
+		if (err == nil) != test.allowed {
+			t.Errorf("Authorize(%s, %s.%s), have: %v, want allowed: %t", test.caller, test.controller, test.action, err, test.allowed)
+		}
+	}
+
+	// actions without a project are for the admins of krak8s
+	if err := authz.Authorize(&Caller{Name: "alice"}, "RevisionController", "restore", nil); err == nil {
+		t.Errorf("Authorize() project owner, want: error")
+	}
+	if err := authz.Authorize(&Caller{Name: "root", Admin: true}, "RevisionController", "restore", nil); err != nil {
+		t.Errorf("Authorize() admin, err: %v", err)
+	}
+	if role := ProjectRole(&Caller{Name: "root", Admin: true}, proj); role != RoleAdmin {
+		t.Errorf("ProjectRole() admin, have: %q", role)
+	}
+	if role := ProjectRole(nil, proj); role != RoleAdmin {
+		t.Errorf("ProjectRole() without authentication, have: %q", role)
+	}
+}
@@ -52,6 +52,16 @@ func (c *Client) NewCreateApplicationRequest(ctx context.Context, path string, p
 	}
 	header := req.Header
 	header.Set("Content-Type", "application/json")
+	if c.JWTSigner != nil {
+		if err := c.JWTSigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
+	if c.APIKeySigner != nil {
+		if err := c.APIKeySigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
 	return req, nil
 }
 
@@ -83,6 +93,16 @@ func (c *Client) NewDeleteApplicationRequest(ctx context.Context, path string) (
 	if err != nil {
 		return nil, err
 	}
+	if c.JWTSigner != nil {
+		if err := c.JWTSigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
+	if c.APIKeySigner != nil {
+		if err := c.APIKeySigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
 	return req, nil
 }
 
@@ -114,6 +134,16 @@ func (c *Client) NewGetApplicationRequest(ctx context.Context, path string) (*ht
 	if err != nil {
 		return nil, err
 	}
+	if c.JWTSigner != nil {
+		if err := c.JWTSigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
+	if c.APIKeySigner != nil {
+		if err := c.APIKeySigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
 	return req, nil
 }
 
@@ -156,5 +186,55 @@ func (c *Client) NewListApplicationRequest(ctx context.Context, path string, pay
 	}
 	header := req.Header
 	header.Set("Content-Type", "application/json")
+	if c.JWTSigner != nil {
+		if err := c.JWTSigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
+	if c.APIKeySigner != nil {
+		if err := c.APIKeySigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
+	return req, nil
+}
+
+// OutdatedApplicationPath computes a request path to the outdated action of application.
+func OutdatedApplicationPath(projectid string) string {
+	param0 := projectid
+
+	return fmt.Sprintf("/v1/projects/%s/applications/outdated", param0)
+}
+
+// Retrieve the applications in the project that have a newer chart version available.
+func (c *Client) OutdatedApplication(ctx context.Context, path string) (*http.Response, error) {
+	req, err := c.NewOutdatedApplicationRequest(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.Do(ctx, req)
+}
+
+// NewOutdatedApplicationRequest create the request corresponding to the outdated action endpoint of the application resource.
+func (c *Client) NewOutdatedApplicationRequest(ctx context.Context, path string) (*http.Request, error) {
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.JWTSigner != nil {
+		if err := c.JWTSigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
+	if c.APIKeySigner != nil {
+		if err := c.APIKeySigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
 	return req, nil
 }
@@ -18,8 +18,10 @@ import (
 // Client is the krak8s service client.
 type Client struct {
 	*goaclient.Client
-	Encoder *goa.HTTPEncoder
-	Decoder *goa.HTTPDecoder
+	APIKeySigner goaclient.Signer
+	JWTSigner    goaclient.Signer
+	Encoder      *goa.HTTPEncoder
+	Decoder      *goa.HTTPDecoder
 }
 
 // New instantiates the client.
@@ -40,3 +42,13 @@ func New(c goaclient.Doer) *Client {
 
 	return client
 }
+
+// SetAPIKeySigner sets the request signer for the api_key security scheme.
+func (c *Client) SetAPIKeySigner(signer goaclient.Signer) {
+	c.APIKeySigner = signer
+}
+
+// SetJWTSigner sets the request signer for the jwt security scheme.
+func (c *Client) SetJWTSigner(signer goaclient.Signer) {
+	c.JWTSigner = signer
+}
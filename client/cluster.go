@@ -16,6 +16,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
 )
 
 // CreateClusterPath computes a request path to the create action of cluster.
@@ -26,8 +27,8 @@ func CreateClusterPath(projectid string) string {
 }
 
 // Request the creation of the cluster resources in the project/namespace
-func (c *Client) CreateCluster(ctx context.Context, path string, payload *ClusterPostBody) (*http.Response, error) {
-	req, err := c.NewCreateClusterRequest(ctx, path, payload)
+func (c *Client) CreateCluster(ctx context.Context, path string, payload *ClusterPostBody, plan *bool) (*http.Response, error) {
+	req, err := c.NewCreateClusterRequest(ctx, path, payload, plan)
 	if err != nil {
 		return nil, err
 	}
@@ -35,7 +36,7 @@ func (c *Client) CreateCluster(ctx context.Context, path string, payload *Cluste
 }
 
 // NewCreateClusterRequest create the request corresponding to the create action endpoint of the cluster resource.
-func (c *Client) NewCreateClusterRequest(ctx context.Context, path string, payload *ClusterPostBody) (*http.Request, error) {
+func (c *Client) NewCreateClusterRequest(ctx context.Context, path string, payload *ClusterPostBody, plan *bool) (*http.Request, error) {
 	var body bytes.Buffer
 	err := c.Encoder.Encode(payload, &body, "*/*")
 	if err != nil {
@@ -46,12 +47,28 @@ func (c *Client) NewCreateClusterRequest(ctx context.Context, path string, paylo
 		scheme = "http"
 	}
 	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
+	values := u.Query()
+	if plan != nil {
+		tmp1 := strconv.FormatBool(*plan)
+		values.Set("plan", tmp1)
+	}
+	u.RawQuery = values.Encode()
 	req, err := http.NewRequest("POST", u.String(), &body)
 	if err != nil {
 		return nil, err
 	}
 	header := req.Header
 	header.Set("Content-Type", "application/json")
+	if c.JWTSigner != nil {
+		if err := c.JWTSigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
+	if c.APIKeySigner != nil {
+		if err := c.APIKeySigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
 	return req, nil
 }
 
@@ -64,8 +81,8 @@ func DeleteClusterPath(projectid string, resourceID string) string {
 }
 
 // Delete the cluster resources from the project/namespace
-func (c *Client) DeleteCluster(ctx context.Context, path string) (*http.Response, error) {
-	req, err := c.NewDeleteClusterRequest(ctx, path)
+func (c *Client) DeleteCluster(ctx context.Context, path string, plan *bool) (*http.Response, error) {
+	req, err := c.NewDeleteClusterRequest(ctx, path, plan)
 	if err != nil {
 		return nil, err
 	}
@@ -73,16 +90,32 @@ func (c *Client) DeleteCluster(ctx context.Context, path string) (*http.Response
 }
 
 // NewDeleteClusterRequest create the request corresponding to the delete action endpoint of the cluster resource.
-func (c *Client) NewDeleteClusterRequest(ctx context.Context, path string) (*http.Request, error) {
+func (c *Client) NewDeleteClusterRequest(ctx context.Context, path string, plan *bool) (*http.Request, error) {
 	scheme := c.Scheme
 	if scheme == "" {
 		scheme = "http"
 	}
 	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
+	values := u.Query()
+	if plan != nil {
+		tmp2 := strconv.FormatBool(*plan)
+		values.Set("plan", tmp2)
+	}
+	u.RawQuery = values.Encode()
 	req, err := http.NewRequest("DELETE", u.String(), nil)
 	if err != nil {
 		return nil, err
 	}
+	if c.JWTSigner != nil {
+		if err := c.JWTSigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
+	if c.APIKeySigner != nil {
+		if err := c.APIKeySigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
 	return req, nil
 }
 
@@ -114,5 +147,69 @@ func (c *Client) NewGetClusterRequest(ctx context.Context, path string) (*http.R
 	if err != nil {
 		return nil, err
 	}
+	if c.JWTSigner != nil {
+		if err := c.JWTSigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
+	if c.APIKeySigner != nil {
+		if err := c.APIKeySigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
+	return req, nil
+}
+
+// UpdateClusterPath computes a request path to the update action of cluster.
+func UpdateClusterPath(projectid string, resourceID string) string {
+	param0 := projectid
+	param1 := resourceID
+
+	return fmt.Sprintf("/v1/projects/%s/cluster/%s", param0, param1)
+}
+
+// Request an update of the cluster resources in the project/namespace
+func (c *Client) UpdateCluster(ctx context.Context, path string, payload *ClusterPutBody, plan *bool) (*http.Response, error) {
+	req, err := c.NewUpdateClusterRequest(ctx, path, payload, plan)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.Do(ctx, req)
+}
+
+// NewUpdateClusterRequest create the request corresponding to the update action endpoint of the cluster resource.
+func (c *Client) NewUpdateClusterRequest(ctx context.Context, path string, payload *ClusterPutBody, plan *bool) (*http.Request, error) {
+	var body bytes.Buffer
+	err := c.Encoder.Encode(payload, &body, "*/*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode body: %s", err)
+	}
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
+	values := u.Query()
+	if plan != nil {
+		tmp3 := strconv.FormatBool(*plan)
+		values.Set("plan", tmp3)
+	}
+	u.RawQuery = values.Encode()
+	req, err := http.NewRequest("PUT", u.String(), &body)
+	if err != nil {
+		return nil, err
+	}
+	header := req.Header
+	header.Set("Content-Type", "application/json")
+	if c.JWTSigner != nil {
+		if err := c.JWTSigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
+	if c.APIKeySigner != nil {
+		if err := c.APIKeySigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
 	return req, nil
 }
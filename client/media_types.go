@@ -23,10 +23,16 @@ import (
 type Application struct {
 	// Application chart's channel
 	Channel string `form:"channel" json:"channel" xml:"channel"`
+	// Name of the cluster resource whose node pool the application is scheduled on
+	Cluster *string `form:"cluster,omitempty" json:"cluster,omitempty" xml:"cluster,omitempty"`
+	// How the application is deployed, by helm or by k2 from the Kraken configuration's helmConfigs
+	Deployer *string `form:"deployer,omitempty" json:"deployer,omitempty" xml:"deployer,omitempty"`
 	// Application chart config --set argument string
 	Config string `form:"config" json:"config" xml:"config"`
 	// Date of creation
 	CreatedAt time.Time `form:"created_at" json:"created_at" xml:"created_at"`
+	// Name of the project's registry credential the application logs in with
+	Credential *string `form:"credential,omitempty" json:"credential,omitempty" xml:"credential,omitempty"`
 	// Cluster application deployment name
 	DeploymentName string `form:"deployment_name" json:"deployment_name" xml:"deployment_name"`
 	// generated resource unique id (8 character hexadecimal value)
@@ -55,8 +61,12 @@ type Application struct {
 	UpdatedAt time.Time `form:"updated_at" json:"updated_at" xml:"updated_at"`
 	// Registry server username
 	Username string `form:"username" json:"username" xml:"username"`
+	// Application chart values, merged from values_yaml and values
+	Values map[string]interface{} `form:"values,omitempty" json:"values,omitempty" xml:"values,omitempty"`
 	// Application chart version (tag) string
 	Version string `form:"version" json:"version" xml:"version"`
+	// Application chart version constraint the version was resolved from
+	VersionConstraint *string `form:"version_constraint,omitempty" json:"version_constraint,omitempty" xml:"version_constraint,omitempty"`
 }
 
 // Validate validates the Application media type instance.
@@ -196,20 +206,194 @@ func (c *Client) DecodeApplicationCollection(resp *http.Response) (ApplicationCo
 	return decoded, err
 }
 
+// Application chart version status, the installed version and the newer versions available (default view)
+//
+// Identifier: application/application.version+json; view=default
+type ApplicationVersion struct {
+	// Highest available chart version that satisfies the version constraint
+	ConstraintVersion *string `form:"constraint_version,omitempty" json:"constraint_version,omitempty" xml:"constraint_version,omitempty"`
+	// Cluster application deployment name
+	DeploymentName string `form:"deployment_name" json:"deployment_name" xml:"deployment_name"`
+	// generated resource unique id (8 character hexadecimal value)
+	ID string `form:"id" json:"id" xml:"id"`
+	// Highest available chart version
+	LatestVersion string `form:"latest_version" json:"latest_version" xml:"latest_version"`
+	// Application chart name
+	Name string `form:"name" json:"name" xml:"name"`
+	// The related namespace's generated unique id, not the namespace's name
+	NamespaceID string `form:"namespace_id" json:"namespace_id" xml:"namespace_id"`
+	// Installed application chart version
+	Version string `form:"version" json:"version" xml:"version"`
+	// Application chart version constraint the version was resolved from
+	VersionConstraint *string `form:"version_constraint,omitempty" json:"version_constraint,omitempty" xml:"version_constraint,omitempty"`
+}
+
+// Validate validates the ApplicationVersion media type instance.
+func (mt *ApplicationVersion) Validate() (err error) {
+	if mt.ID == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "id"))
+	}
+	if mt.NamespaceID == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "namespace_id"))
+	}
+	if mt.DeploymentName == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "deployment_name"))
+	}
+	if mt.Name == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "name"))
+	}
+	if mt.Version == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "version"))
+	}
+	if mt.LatestVersion == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "latest_version"))
+	}
+	return
+}
+
+// DecodeApplicationVersion decodes the ApplicationVersion instance encoded in resp body.
+func (c *Client) DecodeApplicationVersion(resp *http.Response) (*ApplicationVersion, error) {
+	var decoded ApplicationVersion
+	err := c.Decoder.Decode(&decoded, resp.Body, resp.Header.Get("Content-Type"))
+	return &decoded, err
+}
+
+// ApplicationVersionCollection is the media type for an array of ApplicationVersion (default view)
+//
+// Identifier: application/application.version+json; type=collection; view=default
+type ApplicationVersionCollection []*ApplicationVersion
+
+// Validate validates the ApplicationVersionCollection media type instance.
+func (mt ApplicationVersionCollection) Validate() (err error) {
+	for _, e := range mt {
+		if e != nil {
+			if err2 := e.Validate(); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	return
+}
+
+// DecodeApplicationVersionCollection decodes the ApplicationVersionCollection instance encoded in resp body.
+func (c *Client) DecodeApplicationVersionCollection(resp *http.Response) (ApplicationVersionCollection, error) {
+	var decoded ApplicationVersionCollection
+	err := c.Decoder.Decode(&decoded, resp.Body, resp.Header.Get("Content-Type"))
+	return decoded, err
+}
+
+// An entry of the audit log, of a create, update, or delete API request or of a backend command it ran (default view)
+//
+// Identifier: application/audit.entry+json; view=default
+type AuditEntry struct {
+	// Controller and action of the request
+	Action *string `form:"action,omitempty" json:"action,omitempty" xml:"action,omitempty"`
+	// API key name or JWT subject of the caller
+	Caller *string `form:"caller,omitempty" json:"caller,omitempty" xml:"caller,omitempty"`
+	// Backend command and its arguments
+	Command []string `form:"command,omitempty" json:"command,omitempty" xml:"command,omitempty"`
+	// Error of a failed request or command
+	Error *string `form:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+	// Kind of the entry
+	Kind string `form:"kind" json:"kind" xml:"kind"`
+	// HTTP method of the request
+	Method *string `form:"method,omitempty" json:"method,omitempty" xml:"method,omitempty"`
+	// Oids of the objects the request targeted or created
+	Objects []string `form:"objects,omitempty" json:"objects,omitempty" xml:"objects,omitempty"`
+	// Outcome of the request or command
+	Outcome string `form:"outcome" json:"outcome" xml:"outcome"`
+	// URL path of the request
+	Path *string `form:"path,omitempty" json:"path,omitempty" xml:"path,omitempty"`
+	// Request payload, with its secrets redacted
+	Payload map[string]interface{} `form:"payload,omitempty" json:"payload,omitempty" xml:"payload,omitempty"`
+	// goa request id of the API request, shared by the commands it ran
+	RequestID string `form:"request_id" json:"request_id" xml:"request_id"`
+	// HTTP status of the response
+	Status *int `form:"status,omitempty" json:"status,omitempty" xml:"status,omitempty"`
+	// Date of the request's response, or the command's completion
+	Time time.Time `form:"time" json:"time" xml:"time"`
+}
+
+// Validate validates the AuditEntry media type instance.
+func (mt *AuditEntry) Validate() (err error) {
+	if mt.RequestID == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "request_id"))
+	}
+
+	if mt.Kind == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "kind"))
+	}
+	if mt.Outcome == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "outcome"))
+	}
+	if !(mt.Kind == "request" || mt.Kind == "command") {
+		err = goa.MergeErrors(err, goa.InvalidEnumValueError(`response.kind`, mt.Kind, []interface{}{"request", "command"}))
+	}
+	if !(mt.Outcome == "success" || mt.Outcome == "failure" || mt.Outcome == "denied") {
+		err = goa.MergeErrors(err, goa.InvalidEnumValueError(`response.outcome`, mt.Outcome, []interface{}{"success", "failure", "denied"}))
+	}
+	return
+}
+
+// DecodeAuditEntry decodes the AuditEntry instance encoded in resp body.
+func (c *Client) DecodeAuditEntry(resp *http.Response) (*AuditEntry, error) {
+	var decoded AuditEntry
+	err := c.Decoder.Decode(&decoded, resp.Body, resp.Header.Get("Content-Type"))
+	return &decoded, err
+}
+
+// AuditEntryCollection is the media type for an array of AuditEntry (default view)
+//
+// Identifier: application/audit.entry+json; type=collection; view=default
+type AuditEntryCollection []*AuditEntry
+
+// Validate validates the AuditEntryCollection media type instance.
+func (mt AuditEntryCollection) Validate() (err error) {
+	for _, e := range mt {
+		if e != nil {
+			if err2 := e.Validate(); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	return
+}
+
+// DecodeAuditEntryCollection decodes the AuditEntryCollection instance encoded in resp body.
+func (c *Client) DecodeAuditEntryCollection(resp *http.Response) (AuditEntryCollection, error) {
+	var decoded AuditEntryCollection
+	err := c.Decoder.Decode(&decoded, resp.Body, resp.Header.Get("Content-Type"))
+	return decoded, err
+}
+
 // Cluster resource representation type (default view)
 //
 // Identifier: application/cluster+json; view=default
 type Cluster struct {
+	// Kraken definitions.containerConfigs entry of the project's nodes
+	ContainerConfig *string `form:"container_config,omitempty" json:"container_config,omitempty" xml:"container_config,omitempty"`
 	// Date of creation
 	CreatedAt time.Time `form:"created_at" json:"created_at" xml:"created_at"`
 	// generated resource unique id (8 character hexadecimal value)
 	ID string `form:"id" json:"id" xml:"id"`
+	// Extra labels of the project's nodes
+	Labels map[string]string `form:"labels,omitempty" json:"labels,omitempty" xml:"labels,omitempty"`
+	// Name of the node pool within the namespace, empty for the namespace's default node pool
+	Name *string `form:"name,omitempty" json:"name,omitempty" xml:"name,omitempty"`
 	// The related namespace's generated unique id, not the namespace's name
 	NamespaceID string `form:"namespace_id" json:"namespace_id" xml:"namespace_id"`
+	// Kraken definitions.nodeConfigs entry of the project's nodes
+	NodeConfig *string `form:"node_config,omitempty" json:"node_config,omitempty" xml:"node_config,omitempty"`
+	// Name of the Kraken node pool
+	NodePool *string `form:"node_pool,omitempty" json:"node_pool,omitempty" xml:"node_pool,omitempty"`
 	// Requested node pool size
 	NodePoolSize int `form:"nodePoolSize" json:"nodePoolSize" xml:"nodePoolSize"`
+	// Kraken definitions.osConfigs entry of the project's nodes
+	OsConfig *string `form:"os_config,omitempty" json:"os_config,omitempty" xml:"os_config,omitempty"`
 	// Lifecycle state
 	State string `form:"state" json:"state" xml:"state"`
+	// Extra taints of the project's nodes
+	Taints []*NodeTaint `form:"taints,omitempty" json:"taints,omitempty" xml:"taints,omitempty"`
 	// constant: object type
 	Type string `form:"type" json:"type" xml:"type"`
 	// Date of last update
@@ -234,6 +418,13 @@ func (mt *Cluster) Validate() (err error) {
 	if !(mt.State == "create_requested" || mt.State == "starting" || mt.State == "active" || mt.State == "delete_requested" || mt.State == "deleting" || mt.State == "deleted") {
 		err = goa.MergeErrors(err, goa.InvalidEnumValueError(`response.state`, mt.State, []interface{}{"create_requested", "starting", "active", "delete_requested", "deleting", "deleted"}))
 	}
+	for _, e := range mt.Taints {
+		if e != nil {
+			if err2 := e.Validate(); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
 	return
 }
 
@@ -244,6 +435,46 @@ func (c *Client) DecodeCluster(resp *http.Response) (*Cluster, error) {
 	return &decoded, err
 }
 
+// The Kraken configuration change and k2 command a cluster resource request would run (default view)
+//
+// Identifier: application/cluster.plan+json; view=default
+type ClusterPlan struct {
+	// Requested change to the project's node pool
+	Action string `form:"action" json:"action" xml:"action"`
+	// The k2 command line
+	Command []string `form:"command" json:"command" xml:"command"`
+	// Unified diff of the Kraken configuration file, empty if the file is unchanged
+	ConfigDiff *string `form:"config_diff,omitempty" json:"config_diff,omitempty" xml:"config_diff,omitempty"`
+	// Environment variables set for the k2 command, NAME=value
+	Environment []string `form:"environment,omitempty" json:"environment,omitempty" xml:"environment,omitempty"`
+	// Name of the project's node pool
+	NodePool string `form:"node_pool" json:"node_pool" xml:"node_pool"`
+}
+
+// Validate validates the ClusterPlan media type instance.
+func (mt *ClusterPlan) Validate() (err error) {
+	if mt.Action == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "action"))
+	}
+	if mt.NodePool == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "node_pool"))
+	}
+	if mt.Command == nil {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "command"))
+	}
+	if !(mt.Action == "create" || mt.Action == "update" || mt.Action == "delete") {
+		err = goa.MergeErrors(err, goa.InvalidEnumValueError(`response.action`, mt.Action, []interface{}{"create", "update", "delete"}))
+	}
+	return
+}
+
+// DecodeClusterPlan decodes the ClusterPlan instance encoded in resp body.
+func (c *Client) DecodeClusterPlan(resp *http.Response) (*ClusterPlan, error) {
+	var decoded ClusterPlan
+	err := c.Decoder.Decode(&decoded, resp.Body, resp.Header.Get("Content-Type"))
+	return &decoded, err
+}
+
 // An cluster reesources object reference by object id (oid), and url (default view)
 //
 // Identifier: application/cluster.ref+json; view=default
@@ -272,6 +503,298 @@ func (c *Client) DecodeClusterRef(resp *http.Response) (*ClusterRef, error) {
 	return &decoded, err
 }
 
+// ClusterRefCollection is the media type for an array of ClusterRef (default view)
+//
+// Identifier: application/cluster.ref+json; type=collection; view=default
+type ClusterRefCollection []*ClusterRef
+
+// Validate validates the ClusterRefCollection media type instance.
+func (mt ClusterRefCollection) Validate() (err error) {
+	for _, e := range mt {
+		if e != nil {
+			if err2 := e.Validate(); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	return
+}
+
+// DecodeClusterRefCollection decodes the ClusterRefCollection instance encoded in resp body.
+func (c *Client) DecodeClusterRefCollection(resp *http.Response) (ClusterRefCollection, error) {
+	var decoded ClusterRefCollection
+	err := c.Decoder.Decode(&decoded, resp.Body, resp.Header.Get("Content-Type"))
+	return decoded, err
+}
+
+// The differences between two revisions of the Kraken configuration file (default view)
+//
+// Identifier: application/config.diff+json; view=default
+type ConfigDiff struct {
+	// Unified diff, empty if the revisions are the same
+	Diff *string `form:"diff,omitempty" json:"diff,omitempty" xml:"diff,omitempty"`
+	// Revision id diffed from
+	From string `form:"from" json:"from" xml:"from"`
+	// Revision id diffed to, current for the configuration file
+	To string `form:"to" json:"to" xml:"to"`
+}
+
+// Validate validates the ConfigDiff media type instance.
+func (mt *ConfigDiff) Validate() (err error) {
+	if mt.From == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "from"))
+	}
+	if mt.To == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "to"))
+	}
+	return
+}
+
+// DecodeConfigDiff decodes the ConfigDiff instance encoded in resp body.
+func (c *Client) DecodeConfigDiff(resp *http.Response) (*ConfigDiff, error) {
+	var decoded ConfigDiff
+	err := c.Decoder.Decode(&decoded, resp.Body, resp.Header.Get("Content-Type"))
+	return &decoded, err
+}
+
+// A saved revision of the Kraken configuration file, and the operation that replaced it (default view)
+//
+// Identifier: application/config.revision+json; view=default
+type ConfigRevision struct {
+	// Revision id, the unix time the revision was saved
+	ID string `form:"id" json:"id" xml:"id"`
+	// Operation that replaced the revision
+	Operation string `form:"operation" json:"operation" xml:"operation"`
+	// Name of the project whose node pool the operation changed
+	Project *string `form:"project,omitempty" json:"project,omitempty" xml:"project,omitempty"`
+	// Id of the revision restored, for the restore operation
+	Restored *string `form:"restored,omitempty" json:"restored,omitempty" xml:"restored,omitempty"`
+	// Date the revision was saved
+	Time time.Time `form:"time" json:"time" xml:"time"`
+	// State of the cluster update of a restore operation's node pool changes
+	Update *string `form:"update,omitempty" json:"update,omitempty" xml:"update,omitempty"`
+}
+
+// Validate validates the ConfigRevision media type instance.
+func (mt *ConfigRevision) Validate() (err error) {
+	if mt.ID == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "id"))
+	}
+
+	if mt.Operation == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "operation"))
+	}
+	if !(mt.Operation == "add" || mt.Operation == "update" || mt.Operation == "delete" || mt.Operation == "restore" || mt.Operation == "unknown") {
+		err = goa.MergeErrors(err, goa.InvalidEnumValueError(`response.operation`, mt.Operation, []interface{}{"add", "update", "delete", "restore", "unknown"}))
+	}
+	if mt.Update != nil {
+		if !(*mt.Update == "pending" || *mt.Update == "applied" || *mt.Update == "failed") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError(`response.update`, *mt.Update, []interface{}{"pending", "applied", "failed"}))
+		}
+	}
+	return
+}
+
+// DecodeConfigRevision decodes the ConfigRevision instance encoded in resp body.
+func (c *Client) DecodeConfigRevision(resp *http.Response) (*ConfigRevision, error) {
+	var decoded ConfigRevision
+	err := c.Decoder.Decode(&decoded, resp.Body, resp.Header.Get("Content-Type"))
+	return &decoded, err
+}
+
+// ConfigRevisionCollection is the media type for an array of ConfigRevision (default view)
+//
+// Identifier: application/config.revision+json; type=collection; view=default
+type ConfigRevisionCollection []*ConfigRevision
+
+// Validate validates the ConfigRevisionCollection media type instance.
+func (mt ConfigRevisionCollection) Validate() (err error) {
+	for _, e := range mt {
+		if e != nil {
+			if err2 := e.Validate(); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	return
+}
+
+// DecodeConfigRevisionCollection decodes the ConfigRevisionCollection instance encoded in resp body.
+func (c *Client) DecodeConfigRevisionCollection(resp *http.Response) (ConfigRevisionCollection, error) {
+	var decoded ConfigRevisionCollection
+	err := c.Decoder.Decode(&decoded, resp.Body, resp.Header.Get("Content-Type"))
+	return decoded, err
+}
+
+// A named registry credential of a project, that the project's applications log in with (default view)
+//
+// Identifier: application/credential+json; view=default
+type Credential struct {
+	// Ids of the applications using the credential
+	Applications []string `form:"applications" json:"applications" xml:"applications"`
+	// Date of creation
+	CreatedAt time.Time `form:"created_at" json:"created_at" xml:"created_at"`
+	// Name of the credential
+	Name string `form:"name" json:"name" xml:"name"`
+	// The project resource unique oid
+	Project string `form:"project" json:"project" xml:"project"`
+	// Registry host server the credential logs in to
+	Server string `form:"server" json:"server" xml:"server"`
+	// Date of the last rotation
+	UpdatedAt time.Time `form:"updated_at" json:"updated_at" xml:"updated_at"`
+	// Registry server username
+	Username string `form:"username" json:"username" xml:"username"`
+}
+
+// Validate validates the Credential media type instance.
+func (mt *Credential) Validate() (err error) {
+	if mt.Project == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "project"))
+	}
+	if mt.Name == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "name"))
+	}
+	if mt.Server == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "server"))
+	}
+	if mt.Username == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "username"))
+	}
+	if mt.Applications == nil {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "applications"))
+	}
+	return
+}
+
+// DecodeCredential decodes the Credential instance encoded in resp body.
+func (c *Client) DecodeCredential(resp *http.Response) (*Credential, error) {
+	var decoded Credential
+	err := c.Decoder.Decode(&decoded, resp.Body, resp.Header.Get("Content-Type"))
+	return &decoded, err
+}
+
+// CredentialCollection is the media type for an array of Credential (default view)
+//
+// Identifier: application/credential+json; type=collection; view=default
+type CredentialCollection []*Credential
+
+// Validate validates the CredentialCollection media type instance.
+func (mt CredentialCollection) Validate() (err error) {
+	for _, e := range mt {
+		if e != nil {
+			if err2 := e.Validate(); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	return
+}
+
+// DecodeCredentialCollection decodes the CredentialCollection instance encoded in resp body.
+func (c *Client) DecodeCredentialCollection(resp *http.Response) (CredentialCollection, error) {
+	var decoded CredentialCollection
+	err := c.Decoder.Decode(&decoded, resp.Body, resp.Header.Get("Content-Type"))
+	return decoded, err
+}
+
+// A kubeconfig giving the project's tenants access to its Kubernetes namespaces (default view)
+//
+// Identifier: application/kubeconfig+json; view=default
+type Kubeconfig struct {
+	// kubeconfig with the ServiceAccount's token, in YAML
+	Kubeconfig string `form:"kubeconfig" json:"kubeconfig" xml:"kubeconfig"`
+	// Kubernetes namespaces the ServiceAccount can access
+	Namespaces []string `form:"namespaces" json:"namespaces" xml:"namespaces"`
+	// The project resource unique oid
+	Project string `form:"project" json:"project" xml:"project"`
+	// Namespace/name of the project's ServiceAccount
+	ServiceAccount string `form:"service_account" json:"service_account" xml:"service_account"`
+}
+
+// Validate validates the Kubeconfig media type instance.
+func (mt *Kubeconfig) Validate() (err error) {
+	if mt.Project == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "project"))
+	}
+	if mt.ServiceAccount == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "service_account"))
+	}
+	if mt.Namespaces == nil {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "namespaces"))
+	}
+	if mt.Kubeconfig == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "kubeconfig"))
+	}
+	return
+}
+
+// DecodeKubeconfig decodes the Kubeconfig instance encoded in resp body.
+func (c *Client) DecodeKubeconfig(resp *http.Response) (*Kubeconfig, error) {
+	var decoded Kubeconfig
+	err := c.Decoder.Decode(&decoded, resp.Body, resp.Header.Get("Content-Type"))
+	return &decoded, err
+}
+
+// A caller bound to a role on a project, the project's admins are its owners (default view)
+//
+// Identifier: application/member+json; view=default
+type Member struct {
+	// API key name or JWT subject of the caller
+	Caller string `form:"caller" json:"caller" xml:"caller"`
+	// The project resource unique oid
+	Project string `form:"project" json:"project" xml:"project"`
+	// Role of the caller on the project
+	Role string `form:"role" json:"role" xml:"role"`
+}
+
+// Validate validates the Member media type instance.
+func (mt *Member) Validate() (err error) {
+	if mt.Project == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "project"))
+	}
+	if mt.Caller == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "caller"))
+	}
+	if mt.Role == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "role"))
+	}
+	if !(mt.Role == "admin" || mt.Role == "operator" || mt.Role == "viewer") {
+		err = goa.MergeErrors(err, goa.InvalidEnumValueError(`response.role`, mt.Role, []interface{}{"admin", "operator", "viewer"}))
+	}
+	return
+}
+
+// DecodeMember decodes the Member instance encoded in resp body.
+func (c *Client) DecodeMember(resp *http.Response) (*Member, error) {
+	var decoded Member
+	err := c.Decoder.Decode(&decoded, resp.Body, resp.Header.Get("Content-Type"))
+	return &decoded, err
+}
+
+// MemberCollection is the media type for an array of Member (default view)
+//
+// Identifier: application/member+json; type=collection; view=default
+type MemberCollection []*Member
+
+// Validate validates the MemberCollection media type instance.
+func (mt MemberCollection) Validate() (err error) {
+	for _, e := range mt {
+		if e != nil {
+			if err2 := e.Validate(); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	return
+}
+
+// DecodeMemberCollection decodes the MemberCollection instance encoded in resp body.
+func (c *Client) DecodeMemberCollection(resp *http.Response) (MemberCollection, error) {
+	var decoded MemberCollection
+	err := c.Decoder.Decode(&decoded, resp.Body, resp.Header.Get("Content-Type"))
+	return decoded, err
+}
+
 // Users and tennants of the system are represented as the type Project (default view)
 //
 // Identifier: application/namespace+json; view=default
@@ -284,10 +807,16 @@ type Namespace struct {
 	ID string `form:"id" json:"id" xml:"id"`
 	// system wide unique namespace name
 	Name string `form:"name" json:"name" xml:"name"`
-	// cluster resource associated with namespace
-	Resources *ClusterRef `form:"resources" json:"resources" xml:"resources"`
+	// phase of the Kubernetes namespace
+	Phase *string `form:"phase,omitempty" json:"phase,omitempty" xml:"phase,omitempty"`
+	// resource quota of the namespace
+	Quota *NamespaceQuota `form:"quota,omitempty" json:"quota,omitempty" xml:"quota,omitempty"`
+	// cluster resources associated with namespace
+	Resources ClusterRefCollection `form:"resources" json:"resources" xml:"resources"`
 	// constant: object type
 	Type string `form:"type" json:"type" xml:"type"`
+	// current usage of the namespace's resource quota
+	Usage *NamespaceQuota `form:"usage,omitempty" json:"usage,omitempty" xml:"usage,omitempty"`
 }
 
 // Validate validates the Namespace media type instance.
@@ -314,8 +843,21 @@ func (mt *Namespace) Validate() (err error) {
 	if utf8.RuneCountInString(mt.Name) < 2 {
 		err = goa.MergeErrors(err, goa.InvalidLengthError(`response.name`, mt.Name, utf8.RuneCountInString(mt.Name), 2, true))
 	}
-	if mt.Resources != nil {
-		if err2 := mt.Resources.Validate(); err2 != nil {
+	if mt.Phase != nil {
+		if !(*mt.Phase == "Active" || *mt.Phase == "Terminating" || *mt.Phase == "Failed") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError(`response.phase`, *mt.Phase, []interface{}{"Active", "Terminating", "Failed"}))
+		}
+	}
+	if mt.Quota != nil {
+		if err2 := mt.Quota.Validate(); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	}
+	if err2 := mt.Resources.Validate(); err2 != nil {
+		err = goa.MergeErrors(err, err2)
+	}
+	if mt.Usage != nil {
+		if err2 := mt.Usage.Validate(); err2 != nil {
 			err = goa.MergeErrors(err, err2)
 		}
 	}
@@ -413,10 +955,20 @@ type Project struct {
 	CreatedAt time.Time `form:"created_at" json:"created_at" xml:"created_at"`
 	// generated resource unique id (8 character hexadecimal value)
 	ID string `form:"id" json:"id" xml:"id"`
+	// deny ingress traffic to the project's namespaces from outside of the project and its peers
+	Isolated *bool `form:"isolated,omitempty" json:"isolated,omitempty" xml:"isolated,omitempty"`
+	// roles of the project's other callers, operator or viewer, by caller
+	Members map[string]string `form:"members,omitempty" json:"members,omitempty" xml:"members,omitempty"`
 	// name of project
 	Name string `form:"name" json:"name" xml:"name"`
 	// namespace associations for this project
 	Namespaces NamespaceRefCollection `form:"namespaces" json:"namespaces" xml:"namespaces"`
+	// callers with the admin role on the project
+	Owners []string `form:"owners,omitempty" json:"owners,omitempty" xml:"owners,omitempty"`
+	// additional sources of ingress traffic allowed in an isolated project
+	Peers []*NetworkPeer `form:"peers,omitempty" json:"peers,omitempty" xml:"peers,omitempty"`
+	// cluster target the project is placed on, the default target when not given
+	Target *string `form:"target,omitempty" json:"target,omitempty" xml:"target,omitempty"`
 	// constant: object type
 	Type string `form:"type" json:"type" xml:"type"`
 }
@@ -21,6 +21,8 @@ import (
 // CreateNamespacePayload is the namespace create action payload.
 type CreateNamespacePayload struct {
 	Name string `form:"name" json:"name" xml:"name"`
+	// resource quota of the namespace
+	Quota *NamespaceQuota `form:"quota,omitempty" json:"quota,omitempty" xml:"quota,omitempty"`
 }
 
 // CreateNamespacePath computes a request path to the create action of namespace.
@@ -57,6 +59,16 @@ func (c *Client) NewCreateNamespaceRequest(ctx context.Context, path string, pay
 	}
 	header := req.Header
 	header.Set("Content-Type", "application/json")
+	if c.JWTSigner != nil {
+		if err := c.JWTSigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
+	if c.APIKeySigner != nil {
+		if err := c.APIKeySigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
 	return req, nil
 }
 
@@ -88,6 +100,16 @@ func (c *Client) NewDeleteNamespaceRequest(ctx context.Context, path string) (*h
 	if err != nil {
 		return nil, err
 	}
+	if c.JWTSigner != nil {
+		if err := c.JWTSigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
+	if c.APIKeySigner != nil {
+		if err := c.APIKeySigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
 	return req, nil
 }
 
@@ -119,6 +141,16 @@ func (c *Client) NewGetNamespaceRequest(ctx context.Context, path string) (*http
 	if err != nil {
 		return nil, err
 	}
+	if c.JWTSigner != nil {
+		if err := c.JWTSigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
+	if c.APIKeySigner != nil {
+		if err := c.APIKeySigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
 	return req, nil
 }
 
@@ -149,5 +181,69 @@ func (c *Client) NewListNamespaceRequest(ctx context.Context, path string) (*htt
 	if err != nil {
 		return nil, err
 	}
+	if c.JWTSigner != nil {
+		if err := c.JWTSigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
+	if c.APIKeySigner != nil {
+		if err := c.APIKeySigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
+	return req, nil
+}
+
+// UpdateNamespacePayload is the namespace update action payload.
+type UpdateNamespacePayload struct {
+	// resource quota of the namespace
+	Quota *NamespaceQuota `form:"quota" json:"quota" xml:"quota"`
+}
+
+// UpdateNamespacePath computes a request path to the update action of namespace.
+func UpdateNamespacePath(projectid string, namespaceid string) string {
+	param0 := projectid
+	param1 := namespaceid
+
+	return fmt.Sprintf("/v1/projects/%s/namespaces/%s", param0, param1)
+}
+
+// Update the resource quota of the specified namespace
+func (c *Client) UpdateNamespace(ctx context.Context, path string, payload *UpdateNamespacePayload) (*http.Response, error) {
+	req, err := c.NewUpdateNamespaceRequest(ctx, path, payload)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.Do(ctx, req)
+}
+
+// NewUpdateNamespaceRequest create the request corresponding to the update action endpoint of the namespace resource.
+func (c *Client) NewUpdateNamespaceRequest(ctx context.Context, path string, payload *UpdateNamespacePayload) (*http.Request, error) {
+	var body bytes.Buffer
+	err := c.Encoder.Encode(payload, &body, "*/*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode body: %s", err)
+	}
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
+	req, err := http.NewRequest("PUT", u.String(), &body)
+	if err != nil {
+		return nil, err
+	}
+	header := req.Header
+	header.Set("Content-Type", "application/json")
+	if c.JWTSigner != nil {
+		if err := c.JWTSigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
+	if c.APIKeySigner != nil {
+		if err := c.APIKeySigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
 	return req, nil
 }
@@ -20,8 +20,14 @@ import (
 
 // CreateProjectPayload is the project create action payload.
 type CreateProjectPayload struct {
+	// deny ingress traffic to the project's namespaces from outside of the project and its peers
+	Isolated *bool `form:"isolated,omitempty" json:"isolated,omitempty" xml:"isolated,omitempty"`
 	// name of project
 	Name string `form:"name" json:"name" xml:"name"`
+	// additional sources of ingress traffic allowed in an isolated project
+	Peers []*NetworkPeer `form:"peers,omitempty" json:"peers,omitempty" xml:"peers,omitempty"`
+	// cluster target the project is placed on, the default target when not given
+	Target *string `form:"target,omitempty" json:"target,omitempty" xml:"target,omitempty"`
 }
 
 // CreateProjectPath computes a request path to the create action of project.
@@ -57,6 +63,16 @@ func (c *Client) NewCreateProjectRequest(ctx context.Context, path string, paylo
 	}
 	header := req.Header
 	header.Set("Content-Type", "application/json")
+	if c.JWTSigner != nil {
+		if err := c.JWTSigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
+	if c.APIKeySigner != nil {
+		if err := c.APIKeySigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
 	return req, nil
 }
 
@@ -87,6 +103,16 @@ func (c *Client) NewDeleteProjectRequest(ctx context.Context, path string) (*htt
 	if err != nil {
 		return nil, err
 	}
+	if c.JWTSigner != nil {
+		if err := c.JWTSigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
+	if c.APIKeySigner != nil {
+		if err := c.APIKeySigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
 	return req, nil
 }
 
@@ -117,6 +143,16 @@ func (c *Client) NewGetProjectRequest(ctx context.Context, path string) (*http.R
 	if err != nil {
 		return nil, err
 	}
+	if c.JWTSigner != nil {
+		if err := c.JWTSigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
+	if c.APIKeySigner != nil {
+		if err := c.APIKeySigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
 	return req, nil
 }
 
@@ -146,5 +182,70 @@ func (c *Client) NewListProjectRequest(ctx context.Context, path string) (*http.
 	if err != nil {
 		return nil, err
 	}
+	if c.JWTSigner != nil {
+		if err := c.JWTSigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
+	if c.APIKeySigner != nil {
+		if err := c.APIKeySigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
+	return req, nil
+}
+
+// UpdateProjectPayload is the project update action payload.
+type UpdateProjectPayload struct {
+	// deny ingress traffic to the project's namespaces from outside of the project and its peers
+	Isolated *bool `form:"isolated,omitempty" json:"isolated,omitempty" xml:"isolated,omitempty"`
+	// additional sources of ingress traffic allowed in an isolated project
+	Peers []*NetworkPeer `form:"peers,omitempty" json:"peers,omitempty" xml:"peers,omitempty"`
+}
+
+// UpdateProjectPath computes a request path to the update action of project.
+func UpdateProjectPath(projectid string) string {
+	param0 := projectid
+
+	return fmt.Sprintf("/v1/projects/%s", param0)
+}
+
+// Update the network isolation of the project with given id.
+func (c *Client) UpdateProject(ctx context.Context, path string, payload *UpdateProjectPayload) (*http.Response, error) {
+	req, err := c.NewUpdateProjectRequest(ctx, path, payload)
+	if err != nil {
+		return nil, err
+	}
+	return c.Client.Do(ctx, req)
+}
+
+// NewUpdateProjectRequest create the request corresponding to the update action endpoint of the project resource.
+func (c *Client) NewUpdateProjectRequest(ctx context.Context, path string, payload *UpdateProjectPayload) (*http.Request, error) {
+	var body bytes.Buffer
+	err := c.Encoder.Encode(payload, &body, "*/*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode body: %s", err)
+	}
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := url.URL{Host: c.Host, Scheme: scheme, Path: path}
+	req, err := http.NewRequest("PUT", u.String(), &body)
+	if err != nil {
+		return nil, err
+	}
+	header := req.Header
+	header.Set("Content-Type", "application/json")
+	if c.JWTSigner != nil {
+		if err := c.JWTSigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
+	if c.APIKeySigner != nil {
+		if err := c.APIKeySigner.Sign(req); err != nil {
+			return nil, err
+		}
+	}
 	return req, nil
 }
@@ -18,6 +18,12 @@ import (
 type applicationPostBody struct {
 	// Application chart's channel
 	Channel *string `form:"channel,omitempty" json:"channel,omitempty" xml:"channel,omitempty"`
+	// Name of the namespace's cluster resource whose node pool the application is scheduled on, the default node pool if not set
+	Cluster *string `form:"cluster,omitempty" json:"cluster,omitempty" xml:"cluster,omitempty"`
+	// Name of the project's registry credential to log in with, instead of the username and password, the application's server is the credential's
+	Credential *string `form:"credential,omitempty" json:"credential,omitempty" xml:"credential,omitempty"`
+	// How the application is deployed, by helm, or by k2 from the Kraken configuration's helmConfigs, helm if not set
+	Deployer *string `form:"deployer,omitempty" json:"deployer,omitempty" xml:"deployer,omitempty"`
 	// Cluster application deployment name
 	DeploymentName *string `form:"deployment_name,omitempty" json:"deployment_name,omitempty" xml:"deployment_name,omitempty"`
 	// Application chart's json values string
@@ -30,12 +36,16 @@ type applicationPostBody struct {
 	Password *string `form:"password,omitempty" json:"password,omitempty" xml:"password,omitempty"`
 	// Application chart's registry
 	Registry *string `form:"registry,omitempty" json:"registry,omitempty" xml:"registry,omitempty"`
-	// Application chart registry host server
+	// Application chart registry host server, quay.io by default, or the server of the credential the application logs in with
 	Server *string `form:"server,omitempty" json:"server,omitempty" xml:"server,omitempty"`
 	// Application chart config --set argument string
 	Set *string `form:"set,omitempty" json:"set,omitempty" xml:"set,omitempty"`
 	// Registry server username
 	Username *string `form:"username,omitempty" json:"username,omitempty" xml:"username,omitempty"`
+	// Application chart values object
+	Values map[string]interface{} `form:"values,omitempty" json:"values,omitempty" xml:"values,omitempty"`
+	// Application chart values YAML document
+	ValuesYaml *string `form:"values_yaml,omitempty" json:"values_yaml,omitempty" xml:"values_yaml,omitempty"`
 	// Application chart version string
 	Version *string `form:"version,omitempty" json:"version,omitempty" xml:"version,omitempty"`
 }
@@ -50,10 +60,6 @@ func (ut *applicationPostBody) Finalize() {
 	if ut.Registry == nil {
 		ut.Registry = &defaultRegistry
 	}
-	var defaultServer = "quay.io"
-	if ut.Server == nil {
-		ut.Server = &defaultServer
-	}
 	var defaultVersion = "latest"
 	if ut.Version == nil {
 		ut.Version = &defaultVersion
@@ -74,6 +80,21 @@ func (ut *applicationPostBody) Validate() (err error) {
 	if ut.NamespaceID == nil {
 		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "namespace_id"))
 	}
+	if ut.Credential != nil {
+		if ok := goa.ValidatePattern(`^[a-z][a-z0-9-]{0,62}$`, *ut.Credential); !ok {
+			err = goa.MergeErrors(err, goa.InvalidPatternError(`response.credential`, *ut.Credential, `^[a-z][a-z0-9-]{0,62}$`))
+		}
+	}
+	if ut.Cluster != nil {
+		if ok := goa.ValidatePattern(`^[a-z][a-z0-9]{0,15}$`, *ut.Cluster); !ok {
+			err = goa.MergeErrors(err, goa.InvalidPatternError(`response.cluster`, *ut.Cluster, `^[a-z][a-z0-9]{0,15}$`))
+		}
+	}
+	if ut.Deployer != nil {
+		if !(*ut.Deployer == "helm" || *ut.Deployer == "kraken") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError(`response.deployer`, *ut.Deployer, []interface{}{"helm", "kraken"}))
+		}
+	}
 	return
 }
 
@@ -83,6 +104,15 @@ func (ut *applicationPostBody) Publicize() *ApplicationPostBody {
 	if ut.Channel != nil {
 		pub.Channel = ut.Channel
 	}
+	if ut.Cluster != nil {
+		pub.Cluster = ut.Cluster
+	}
+	if ut.Credential != nil {
+		pub.Credential = ut.Credential
+	}
+	if ut.Deployer != nil {
+		pub.Deployer = ut.Deployer
+	}
 	if ut.DeploymentName != nil {
 		pub.DeploymentName = *ut.DeploymentName
 	}
@@ -102,7 +132,7 @@ func (ut *applicationPostBody) Publicize() *ApplicationPostBody {
 		pub.Registry = *ut.Registry
 	}
 	if ut.Server != nil {
-		pub.Server = *ut.Server
+		pub.Server = ut.Server
 	}
 	if ut.Set != nil {
 		pub.Set = ut.Set
@@ -110,6 +140,12 @@ func (ut *applicationPostBody) Publicize() *ApplicationPostBody {
 	if ut.Username != nil {
 		pub.Username = ut.Username
 	}
+	if ut.Values != nil {
+		pub.Values = ut.Values
+	}
+	if ut.ValuesYaml != nil {
+		pub.ValuesYaml = ut.ValuesYaml
+	}
 	if ut.Version != nil {
 		pub.Version = *ut.Version
 	}
@@ -120,6 +156,12 @@ func (ut *applicationPostBody) Publicize() *ApplicationPostBody {
 type ApplicationPostBody struct {
 	// Application chart's channel
 	Channel *string `form:"channel,omitempty" json:"channel,omitempty" xml:"channel,omitempty"`
+	// Name of the namespace's cluster resource whose node pool the application is scheduled on, the default node pool if not set
+	Cluster *string `form:"cluster,omitempty" json:"cluster,omitempty" xml:"cluster,omitempty"`
+	// Name of the project's registry credential to log in with, instead of the username and password, the application's server is the credential's
+	Credential *string `form:"credential,omitempty" json:"credential,omitempty" xml:"credential,omitempty"`
+	// How the application is deployed, by helm, or by k2 from the Kraken configuration's helmConfigs, helm if not set
+	Deployer *string `form:"deployer,omitempty" json:"deployer,omitempty" xml:"deployer,omitempty"`
 	// Cluster application deployment name
 	DeploymentName string `form:"deployment_name" json:"deployment_name" xml:"deployment_name"`
 	// Application chart's json values string
@@ -132,12 +174,16 @@ type ApplicationPostBody struct {
 	Password *string `form:"password,omitempty" json:"password,omitempty" xml:"password,omitempty"`
 	// Application chart's registry
 	Registry string `form:"registry" json:"registry" xml:"registry"`
-	// Application chart registry host server
-	Server string `form:"server" json:"server" xml:"server"`
+	// Application chart registry host server, quay.io by default, or the server of the credential the application logs in with
+	Server *string `form:"server,omitempty" json:"server,omitempty" xml:"server,omitempty"`
 	// Application chart config --set argument string
 	Set *string `form:"set,omitempty" json:"set,omitempty" xml:"set,omitempty"`
 	// Registry server username
 	Username *string `form:"username,omitempty" json:"username,omitempty" xml:"username,omitempty"`
+	// Application chart values object
+	Values map[string]interface{} `form:"values,omitempty" json:"values,omitempty" xml:"values,omitempty"`
+	// Application chart values YAML document
+	ValuesYaml *string `form:"values_yaml,omitempty" json:"values_yaml,omitempty" xml:"values_yaml,omitempty"`
 	// Application chart version string
 	Version string `form:"version" json:"version" xml:"version"`
 }
@@ -156,15 +202,42 @@ func (ut *ApplicationPostBody) Validate() (err error) {
 	if ut.NamespaceID == "" {
 		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "namespace_id"))
 	}
+	if ut.Credential != nil {
+		if ok := goa.ValidatePattern(`^[a-z][a-z0-9-]{0,62}$`, *ut.Credential); !ok {
+			err = goa.MergeErrors(err, goa.InvalidPatternError(`response.credential`, *ut.Credential, `^[a-z][a-z0-9-]{0,62}$`))
+		}
+	}
+	if ut.Cluster != nil {
+		if ok := goa.ValidatePattern(`^[a-z][a-z0-9]{0,15}$`, *ut.Cluster); !ok {
+			err = goa.MergeErrors(err, goa.InvalidPatternError(`response.cluster`, *ut.Cluster, `^[a-z][a-z0-9]{0,15}$`))
+		}
+	}
+	if ut.Deployer != nil {
+		if !(*ut.Deployer == "helm" || *ut.Deployer == "kraken") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError(`response.deployer`, *ut.Deployer, []interface{}{"helm", "kraken"}))
+		}
+	}
 	return
 }
 
 // clusterPostBody user type.
 type clusterPostBody struct {
+	// Name of the Kraken definitions.containerConfigs entry for the project's nodes
+	ContainerConfig *string `form:"container_config,omitempty" json:"container_config,omitempty" xml:"container_config,omitempty"`
+	// Extra labels for the project's nodes
+	Labels map[string]string `form:"labels,omitempty" json:"labels,omitempty" xml:"labels,omitempty"`
+	// Name of the node pool within the namespace, the namespace's default node pool if not set
+	Name *string `form:"name,omitempty" json:"name,omitempty" xml:"name,omitempty"`
 	// The related namespace's generated unique id, not the namespace's name
 	NamespaceID *string `form:"namespace_id,omitempty" json:"namespace_id,omitempty" xml:"namespace_id,omitempty"`
+	// Name of the Kraken definitions.nodeConfigs entry for the project's nodes
+	NodeConfig *string `form:"node_config,omitempty" json:"node_config,omitempty" xml:"node_config,omitempty"`
 	// The number of worker nodes in the projects resource pool
 	NodePoolSize *int `form:"nodePoolSize,omitempty" json:"nodePoolSize,omitempty" xml:"nodePoolSize,omitempty"`
+	// Name of the Kraken definitions.osConfigs entry for the project's nodes
+	OsConfig *string `form:"os_config,omitempty" json:"os_config,omitempty" xml:"os_config,omitempty"`
+	// Extra taints for the project's nodes, in addition to the project's customer taint
+	Taints []*nodeTaint `form:"taints,omitempty" json:"taints,omitempty" xml:"taints,omitempty"`
 }
 
 // Finalize sets the default values for clusterPostBody type instance.
@@ -183,6 +256,11 @@ func (ut *clusterPostBody) Validate() (err error) {
 	if ut.NamespaceID == nil {
 		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "namespace_id"))
 	}
+	if ut.Name != nil {
+		if ok := goa.ValidatePattern(`^[a-z][a-z0-9]{0,15}$`, *ut.Name); !ok {
+			err = goa.MergeErrors(err, goa.InvalidPatternError(`response.name`, *ut.Name, `^[a-z][a-z0-9]{0,15}$`))
+		}
+	}
 	if ut.NodePoolSize != nil {
 		if *ut.NodePoolSize < 3 {
 			err = goa.MergeErrors(err, goa.InvalidRangeError(`response.nodePoolSize`, *ut.NodePoolSize, 3, true))
@@ -193,27 +271,74 @@ func (ut *clusterPostBody) Validate() (err error) {
 			err = goa.MergeErrors(err, goa.InvalidRangeError(`response.nodePoolSize`, *ut.NodePoolSize, 11, false))
 		}
 	}
+	for _, e := range ut.Taints {
+		if e != nil {
+			if err2 := e.Validate(); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
 	return
 }
 
 // Publicize creates ClusterPostBody from clusterPostBody
 func (ut *clusterPostBody) Publicize() *ClusterPostBody {
 	var pub ClusterPostBody
+	if ut.ContainerConfig != nil {
+		pub.ContainerConfig = ut.ContainerConfig
+	}
+	if ut.Labels != nil {
+		pub.Labels = make(map[string]string, len(ut.Labels))
+		for k2, v2 := range ut.Labels {
+			var pubk2 string
+			pubk2 = k2
+			var pubv2 string
+			pubv2 = v2
+			pub.Labels[pubk2] = pubv2
+		}
+	}
+	if ut.Name != nil {
+		pub.Name = ut.Name
+	}
 	if ut.NamespaceID != nil {
 		pub.NamespaceID = *ut.NamespaceID
 	}
+	if ut.NodeConfig != nil {
+		pub.NodeConfig = ut.NodeConfig
+	}
 	if ut.NodePoolSize != nil {
 		pub.NodePoolSize = *ut.NodePoolSize
 	}
+	if ut.OsConfig != nil {
+		pub.OsConfig = ut.OsConfig
+	}
+	if ut.Taints != nil {
+		pub.Taints = make([]*NodeTaint, len(ut.Taints))
+		for i2, elem2 := range ut.Taints {
+			pub.Taints[i2] = elem2.Publicize()
+		}
+	}
 	return &pub
 }
 
 // ClusterPostBody user type.
 type ClusterPostBody struct {
+	// Name of the Kraken definitions.containerConfigs entry for the project's nodes
+	ContainerConfig *string `form:"container_config,omitempty" json:"container_config,omitempty" xml:"container_config,omitempty"`
+	// Extra labels for the project's nodes
+	Labels map[string]string `form:"labels,omitempty" json:"labels,omitempty" xml:"labels,omitempty"`
+	// Name of the node pool within the namespace, the namespace's default node pool if not set
+	Name *string `form:"name,omitempty" json:"name,omitempty" xml:"name,omitempty"`
 	// The related namespace's generated unique id, not the namespace's name
 	NamespaceID string `form:"namespace_id" json:"namespace_id" xml:"namespace_id"`
+	// Name of the Kraken definitions.nodeConfigs entry for the project's nodes
+	NodeConfig *string `form:"node_config,omitempty" json:"node_config,omitempty" xml:"node_config,omitempty"`
 	// The number of worker nodes in the projects resource pool
 	NodePoolSize int `form:"nodePoolSize" json:"nodePoolSize" xml:"nodePoolSize"`
+	// Name of the Kraken definitions.osConfigs entry for the project's nodes
+	OsConfig *string `form:"os_config,omitempty" json:"os_config,omitempty" xml:"os_config,omitempty"`
+	// Extra taints for the project's nodes, in addition to the project's customer taint
+	Taints []*NodeTaint `form:"taints,omitempty" json:"taints,omitempty" xml:"taints,omitempty"`
 }
 
 // Validate validates the ClusterPostBody type instance.
@@ -222,6 +347,68 @@ func (ut *ClusterPostBody) Validate() (err error) {
 	if ut.NamespaceID == "" {
 		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "namespace_id"))
 	}
+	if ut.Name != nil {
+		if ok := goa.ValidatePattern(`^[a-z][a-z0-9]{0,15}$`, *ut.Name); !ok {
+			err = goa.MergeErrors(err, goa.InvalidPatternError(`response.name`, *ut.Name, `^[a-z][a-z0-9]{0,15}$`))
+		}
+	}
+	if ut.NodePoolSize < 3 {
+		err = goa.MergeErrors(err, goa.InvalidRangeError(`response.nodePoolSize`, ut.NodePoolSize, 3, true))
+	}
+	if ut.NodePoolSize > 11 {
+		err = goa.MergeErrors(err, goa.InvalidRangeError(`response.nodePoolSize`, ut.NodePoolSize, 11, false))
+	}
+	for _, e := range ut.Taints {
+		if e != nil {
+			if err2 := e.Validate(); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	return
+}
+
+// clusterPutBody user type.
+type clusterPutBody struct {
+	// The number of worker nodes in the projects resource pool
+	NodePoolSize *int `form:"nodePoolSize,omitempty" json:"nodePoolSize,omitempty" xml:"nodePoolSize,omitempty"`
+}
+
+// Validate validates the clusterPutBody type instance.
+func (ut *clusterPutBody) Validate() (err error) {
+	if ut.NodePoolSize == nil {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "nodePoolSize"))
+	}
+	if ut.NodePoolSize != nil {
+		if *ut.NodePoolSize < 3 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError(`response.nodePoolSize`, *ut.NodePoolSize, 3, true))
+		}
+	}
+	if ut.NodePoolSize != nil {
+		if *ut.NodePoolSize > 11 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError(`response.nodePoolSize`, *ut.NodePoolSize, 11, false))
+		}
+	}
+	return
+}
+
+// Publicize creates ClusterPutBody from clusterPutBody
+func (ut *clusterPutBody) Publicize() *ClusterPutBody {
+	var pub ClusterPutBody
+	if ut.NodePoolSize != nil {
+		pub.NodePoolSize = *ut.NodePoolSize
+	}
+	return &pub
+}
+
+// ClusterPutBody user type.
+type ClusterPutBody struct {
+	// The number of worker nodes in the projects resource pool
+	NodePoolSize int `form:"nodePoolSize" json:"nodePoolSize" xml:"nodePoolSize"`
+}
+
+// Validate validates the ClusterPutBody type instance.
+func (ut *ClusterPutBody) Validate() (err error) {
 	if ut.NodePoolSize < 3 {
 		err = goa.MergeErrors(err, goa.InvalidRangeError(`response.nodePoolSize`, ut.NodePoolSize, 3, true))
 	}
@@ -230,3 +417,256 @@ func (ut *ClusterPostBody) Validate() (err error) {
 	}
 	return
 }
+
+// credentialPutBody user type.
+type credentialPutBody struct {
+	// Registry server password, write only
+	Password *string `form:"password,omitempty" json:"password,omitempty" xml:"password,omitempty"`
+	// Registry host server the credential logs in to
+	Server *string `form:"server,omitempty" json:"server,omitempty" xml:"server,omitempty"`
+	// Registry server username
+	Username *string `form:"username,omitempty" json:"username,omitempty" xml:"username,omitempty"`
+}
+
+// Validate validates the credentialPutBody type instance.
+func (ut *credentialPutBody) Validate() (err error) {
+	if ut.Server == nil {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "server"))
+	}
+	if ut.Username == nil {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "username"))
+	}
+	if ut.Password == nil {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "password"))
+	}
+	return
+}
+
+// Publicize creates CredentialPutBody from credentialPutBody
+func (ut *credentialPutBody) Publicize() *CredentialPutBody {
+	var pub CredentialPutBody
+	if ut.Password != nil {
+		pub.Password = *ut.Password
+	}
+	if ut.Server != nil {
+		pub.Server = *ut.Server
+	}
+	if ut.Username != nil {
+		pub.Username = *ut.Username
+	}
+	return &pub
+}
+
+// CredentialPutBody user type.
+type CredentialPutBody struct {
+	// Registry server password, write only
+	Password string `form:"password" json:"password" xml:"password"`
+	// Registry host server the credential logs in to
+	Server string `form:"server" json:"server" xml:"server"`
+	// Registry server username
+	Username string `form:"username" json:"username" xml:"username"`
+}
+
+// Validate validates the CredentialPutBody type instance.
+func (ut *CredentialPutBody) Validate() (err error) {
+	if ut.Server == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "server"))
+	}
+	if ut.Username == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "username"))
+	}
+	if ut.Password == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "password"))
+	}
+	return
+}
+
+// namespaceQuota user type.
+type namespaceQuota struct {
+	// Total CPU requests and limits of the namespace's pods
+	CPU *string `form:"cpu,omitempty" json:"cpu,omitempty" xml:"cpu,omitempty"`
+	// CPU request and limit of the containers that don't set one
+	DefaultCPU *string `form:"default_cpu,omitempty" json:"default_cpu,omitempty" xml:"default_cpu,omitempty"`
+	// Memory request and limit of the containers that don't set one
+	DefaultMemory *string `form:"default_memory,omitempty" json:"default_memory,omitempty" xml:"default_memory,omitempty"`
+	// Total memory requests and limits of the namespace's pods
+	Memory *string `form:"memory,omitempty" json:"memory,omitempty" xml:"memory,omitempty"`
+	// Number of pods in the namespace
+	Pods *int `form:"pods,omitempty" json:"pods,omitempty" xml:"pods,omitempty"`
+	// Total storage requests of the namespace's persistent volume claims
+	Storage *string `form:"storage,omitempty" json:"storage,omitempty" xml:"storage,omitempty"`
+}
+
+// Validate validates the namespaceQuota type instance.
+func (ut *namespaceQuota) Validate() (err error) {
+	if ut.Pods != nil {
+		if *ut.Pods < 0 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError(`response.pods`, *ut.Pods, 0, true))
+		}
+	}
+	return
+}
+
+// Publicize creates NamespaceQuota from namespaceQuota
+func (ut *namespaceQuota) Publicize() *NamespaceQuota {
+	var pub NamespaceQuota
+	if ut.CPU != nil {
+		pub.CPU = ut.CPU
+	}
+	if ut.DefaultCPU != nil {
+		pub.DefaultCPU = ut.DefaultCPU
+	}
+	if ut.DefaultMemory != nil {
+		pub.DefaultMemory = ut.DefaultMemory
+	}
+	if ut.Memory != nil {
+		pub.Memory = ut.Memory
+	}
+	if ut.Pods != nil {
+		pub.Pods = ut.Pods
+	}
+	if ut.Storage != nil {
+		pub.Storage = ut.Storage
+	}
+	return &pub
+}
+
+// NamespaceQuota user type.
+type NamespaceQuota struct {
+	// Total CPU requests and limits of the namespace's pods
+	CPU *string `form:"cpu,omitempty" json:"cpu,omitempty" xml:"cpu,omitempty"`
+	// CPU request and limit of the containers that don't set one
+	DefaultCPU *string `form:"default_cpu,omitempty" json:"default_cpu,omitempty" xml:"default_cpu,omitempty"`
+	// Memory request and limit of the containers that don't set one
+	DefaultMemory *string `form:"default_memory,omitempty" json:"default_memory,omitempty" xml:"default_memory,omitempty"`
+	// Total memory requests and limits of the namespace's pods
+	Memory *string `form:"memory,omitempty" json:"memory,omitempty" xml:"memory,omitempty"`
+	// Number of pods in the namespace
+	Pods *int `form:"pods,omitempty" json:"pods,omitempty" xml:"pods,omitempty"`
+	// Total storage requests of the namespace's persistent volume claims
+	Storage *string `form:"storage,omitempty" json:"storage,omitempty" xml:"storage,omitempty"`
+}
+
+// Validate validates the NamespaceQuota type instance.
+func (ut *NamespaceQuota) Validate() (err error) {
+	if ut.Pods != nil {
+		if *ut.Pods < 0 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError(`response.pods`, *ut.Pods, 0, true))
+		}
+	}
+	return
+}
+
+// networkPeer user type.
+type networkPeer struct {
+	// Labels of the namespaces whose pods are allowed
+	NamespaceLabels map[string]string `form:"namespace_labels,omitempty" json:"namespace_labels,omitempty" xml:"namespace_labels,omitempty"`
+	// Labels of the pods allowed in the project's own namespaces, not combined with project or namespace_labels
+	PodLabels map[string]string `form:"pod_labels,omitempty" json:"pod_labels,omitempty" xml:"pod_labels,omitempty"`
+	// Generated unique id of a project whose namespaces are allowed
+	Project *string `form:"project,omitempty" json:"project,omitempty" xml:"project,omitempty"`
+}
+
+// Publicize creates NetworkPeer from networkPeer
+func (ut *networkPeer) Publicize() *NetworkPeer {
+	var pub NetworkPeer
+	if ut.NamespaceLabels != nil {
+		pub.NamespaceLabels = make(map[string]string, len(ut.NamespaceLabels))
+		for k2, v2 := range ut.NamespaceLabels {
+			var pubk2 string
+			pubk2 = k2
+			var pubv2 string
+			pubv2 = v2
+			pub.NamespaceLabels[pubk2] = pubv2
+		}
+	}
+	if ut.PodLabels != nil {
+		pub.PodLabels = make(map[string]string, len(ut.PodLabels))
+		for k2, v2 := range ut.PodLabels {
+			var pubk2 string
+			pubk2 = k2
+			var pubv2 string
+			pubv2 = v2
+			pub.PodLabels[pubk2] = pubv2
+		}
+	}
+	if ut.Project != nil {
+		pub.Project = ut.Project
+	}
+	return &pub
+}
+
+// NetworkPeer user type.
+type NetworkPeer struct {
+	// Labels of the namespaces whose pods are allowed
+	NamespaceLabels map[string]string `form:"namespace_labels,omitempty" json:"namespace_labels,omitempty" xml:"namespace_labels,omitempty"`
+	// Labels of the pods allowed in the project's own namespaces, not combined with project or namespace_labels
+	PodLabels map[string]string `form:"pod_labels,omitempty" json:"pod_labels,omitempty" xml:"pod_labels,omitempty"`
+	// Generated unique id of a project whose namespaces are allowed
+	Project *string `form:"project,omitempty" json:"project,omitempty" xml:"project,omitempty"`
+}
+
+// nodeTaint user type.
+type nodeTaint struct {
+	// Taint effect
+	Effect *string `form:"effect,omitempty" json:"effect,omitempty" xml:"effect,omitempty"`
+	// Taint key
+	Key *string `form:"key,omitempty" json:"key,omitempty" xml:"key,omitempty"`
+	// Taint value
+	Value *string `form:"value,omitempty" json:"value,omitempty" xml:"value,omitempty"`
+}
+
+// Validate validates the nodeTaint type instance.
+func (ut *nodeTaint) Validate() (err error) {
+	if ut.Key == nil {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "key"))
+	}
+	if ut.Effect == nil {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "effect"))
+	}
+	if ut.Effect != nil {
+		if !(*ut.Effect == "NoSchedule" || *ut.Effect == "PreferNoSchedule" || *ut.Effect == "NoExecute") {
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError(`response.effect`, *ut.Effect, []interface{}{"NoSchedule", "PreferNoSchedule", "NoExecute"}))
+		}
+	}
+	return
+}
+
+// Publicize creates NodeTaint from nodeTaint
+func (ut *nodeTaint) Publicize() *NodeTaint {
+	var pub NodeTaint
+	if ut.Effect != nil {
+		pub.Effect = *ut.Effect
+	}
+	if ut.Key != nil {
+		pub.Key = *ut.Key
+	}
+	if ut.Value != nil {
+		pub.Value = ut.Value
+	}
+	return &pub
+}
+
+// NodeTaint user type.
+type NodeTaint struct {
+	// Taint effect
+	Effect string `form:"effect" json:"effect" xml:"effect"`
+	// Taint key
+	Key string `form:"key" json:"key" xml:"key"`
+	// Taint value
+	Value *string `form:"value,omitempty" json:"value,omitempty" xml:"value,omitempty"`
+}
+
+// Validate validates the NodeTaint type instance.
+func (ut *NodeTaint) Validate() (err error) {
+	if ut.Key == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "key"))
+	}
+	if ut.Effect == "" {
+		err = goa.MergeErrors(err, goa.MissingAttributeError(`response`, "effect"))
+	}
+	if !(ut.Effect == "NoSchedule" || ut.Effect == "PreferNoSchedule" || ut.Effect == "NoExecute") {
+		err = goa.MergeErrors(err, goa.InvalidEnumValueError(`response.effect`, ut.Effect, []interface{}{"NoSchedule", "PreferNoSchedule", "NoExecute"}))
+	}
+	return
+}
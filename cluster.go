@@ -3,6 +3,7 @@ package main
 import (
 	"errors"
 	"krak8s/app"
+	"krak8s/commands"
 
 	"github.com/goadesign/goa"
 )
@@ -25,7 +26,7 @@ func NewClusterController(service *goa.Service, store *DataStore, backend *Runne
 
 // MarshalResourcesObject to project media type
 func MarshalResourcesObject(obj *ResourceObject) *app.Cluster {
-	return &app.Cluster{
+	cluster := &app.Cluster{
 		ID:           obj.OID,
 		Type:         obj.ObjType,
 		NodePoolSize: obj.NodePoolSize,
@@ -33,6 +34,90 @@ func MarshalResourcesObject(obj *ResourceObject) *app.Cluster {
 		State:        obj.State,
 		CreatedAt:    obj.CreatedAt,
 		UpdatedAt:    obj.UpdatedAt,
+		Labels:       obj.NodePool.Labels,
+	}
+	if obj.Name != "" {
+		cluster.Name = &obj.Name
+	}
+	if obj.NodePoolName != "" {
+		cluster.NodePool = &obj.NodePoolName
+	}
+	if obj.NodePool.NodeConfig != "" {
+		cluster.NodeConfig = &obj.NodePool.NodeConfig
+	}
+	if obj.NodePool.OSConfig != "" {
+		cluster.OsConfig = &obj.NodePool.OSConfig
+	}
+	if obj.NodePool.ContainerConfig != "" {
+		cluster.ContainerConfig = &obj.NodePool.ContainerConfig
+	}
+	for _, taint := range obj.NodePool.Taints {
+		taint := taint
+		nodeTaint := &app.NodeTaint{Key: taint.Key, Effect: taint.Effect}
+		if taint.Value != "" {
+			nodeTaint.Value = &taint.Value
+		}
+		cluster.Taints = append(cluster.Taints, nodeTaint)
+	}
+	return cluster
+}
+
+// projectHasNodePool - whether a namespace of the project has a cluster
+// resource of the name
+func (c *ClusterController) projectHasNodePool(proj *ProjectObject, name string) bool {
+	for _, link := range proj.Namespaces {
+		ns, ok := c.ds.Namespace(link.OID)
+		if !ok {
+			continue
+		}
+		for _, res := range c.ds.NamespaceResources(ns) {
+			if res.Name == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// nodePoolSpec - the node pool specification requested by the payload
+func nodePoolSpec(payload *app.ClusterPostBody) NodePoolSpec {
+	spec := NodePoolSpec{Labels: payload.Labels}
+	if payload.NodeConfig != nil {
+		spec.NodeConfig = *payload.NodeConfig
+	}
+	if payload.OsConfig != nil {
+		spec.OSConfig = *payload.OsConfig
+	}
+	if payload.ContainerConfig != nil {
+		spec.ContainerConfig = *payload.ContainerConfig
+	}
+	for _, taint := range payload.Taints {
+		obj := TaintObject{Key: taint.Key, Effect: taint.Effect}
+		if taint.Value != nil {
+			obj.Value = *taint.Value
+		}
+		spec.Taints = append(spec.Taints, obj)
+	}
+	return spec
+}
+
+// commandTaints - the extra taints of the node pool specification
+func (spec *NodePoolSpec) commandTaints() []commands.Taint {
+	taints := []commands.Taint{}
+	for _, taint := range spec.Taints {
+		taints = append(taints, commands.Taint{Key: taint.Key, Value: taint.Value, Effect: taint.Effect})
+	}
+	return taints
+}
+
+// MarshalProjectPlan to cluster plan media type
+func MarshalProjectPlan(action string, plan *ProjectPlan) *app.ClusterPlan {
+	return &app.ClusterPlan{
+		Action:      action,
+		NodePool:    plan.NodePool,
+		ConfigDiff:  &plan.ConfigDiff,
+		Command:     plan.Command,
+		Environment: plan.Environment,
 	}
 }
 
@@ -46,8 +131,6 @@ func (c *ClusterController) Create(ctx *app.CreateClusterContext) error {
 	ns, ok := c.ds.Namespace(ctx.Payload.NamespaceID)
 	if !ok {
 		return ctx.NotFound()
-	} else if ns.Resources != nil {
-		return ctx.Conflict()
 	}
 
 	found := false
@@ -61,14 +144,50 @@ func (c *ClusterController) Create(ctx *app.CreateClusterContext) error {
 		return ctx.BadRequest(errors.New("Inavlid Namespace Object ID specified in request"))
 	}
 
-	res := c.ds.NewResource(ctx.Payload.NamespaceID, ctx.Payload.NodePoolSize)
+	// the node pool's Kraken name is unique to the project, not the namespace
+	var name string
+	if ctx.Payload.Name != nil {
+		name = *ctx.Payload.Name
+	}
+	if c.projectHasNodePool(proj, name) {
+		return ctx.Conflict()
+	}
+	// nor another project's on the target, whose name and node pool's name
+	// together may make the same Kraken name
+	if c.ds.TargetHasNodePool(ProjectTarget(proj).Name, commands.ProjectNodePoolName(proj.Name, name)) {
+		return ctx.Conflict()
+	}
+
+	spec := nodePoolSpec(ctx.Payload)
+	if err := commands.CheckTaints(spec.commandTaints()); err != nil {
+		return ctx.BadRequest(goa.ErrBadRequest(err))
+	}
+	if err := commands.CheckLabels(spec.Labels); err != nil {
+		return ctx.BadRequest(goa.ErrBadRequest(err))
+	}
+	// the node pool's configurations must be the target's Kraken definitions
+	res := &ResourceObject{Name: name, NodePoolSize: ctx.Payload.NodePoolSize, NodePool: spec}
+	if err := commands.CheckProjectDefinitions(projectConfig(proj, ns, res), ProjectTarget(proj).ConfigPath()); err != nil {
+		return ctx.BadRequest(goa.ErrBadRequest(err))
+	}
+	if ctx.Plan {
+		plan, err := c.backend.PlanProject(AddProject, proj, ns, res)
+		if err != nil {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.OK(MarshalProjectPlan("create", plan))
+	}
+
+	res = c.ds.NewResource(ctx.Payload.NamespaceID, name, ctx.Payload.NodePoolSize, spec)
 	if res == nil {
 		return ctx.InternalServerError()
 	}
+	res.NodePoolName = commands.ProjectNodePoolName(proj.Name, name)
 	url := APIVersion + APIProjects + ctx.Projectid + APICluster + res.OID
-	ns.Resources = &ObjectLink{OID: res.OID, URL: url}
+	ns.Resources = append(ns.Resources, &ObjectLink{OID: res.OID, URL: url})
+	AuditObjects(ctx, res.OID)
 
-	c.backend.ProjectRequest(AddProject, c.ds, proj, ns, res)
+	c.backend.ProjectRequest(ctx, AddProject, c.ds, proj, ns, res)
 
 	return ctx.Accepted(MarshalResourcesObject(res))
 	// ClusterController_Create: end_implement
@@ -86,18 +205,26 @@ func (c *ClusterController) Delete(ctx *app.DeleteClusterContext) error {
 		return ctx.NotFound()
 	}
 	ns, ok := c.ds.Namespace(res.NamespaceID)
-	if !ok {
+	if !ok || !proj.HasNamespaceLink(ns.OID) {
 		return ctx.NotFound()
 	}
-	if ns.Resources.OID != ctx.ResourceID {
+	if !ns.HasResourceLink(ctx.ResourceID) {
 		return ctx.BadRequest(errors.New("Inavlid Cluster Resource Object ID specified in request"))
 	}
 
+	if ctx.Plan {
+		plan, err := c.backend.PlanProject(RemoveProject, proj, ns, res)
+		if err != nil {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.OK(MarshalProjectPlan("delete", plan))
+	}
+
 	res.State = ResourceDeleteRequested
-	c.backend.ProjectRequest(RemoveProject, c.ds, proj, ns, res)
+	c.backend.ProjectRequest(ctx, RemoveProject, c.ds, proj, ns, res)
 
 	c.ds.DeleteResource(res)
-	ns.Resources = nil
+	ns.RemoveResourceLink(res.OID)
 
 	return ctx.NoContent()
 	// ClusterController_Delete: end_implement
@@ -106,14 +233,54 @@ func (c *ClusterController) Delete(ctx *app.DeleteClusterContext) error {
 // Get runs the get action.
 func (c *ClusterController) Get(ctx *app.GetClusterContext) error {
 	// ClusterController_Get: start_implement
-	if _, ok := c.ds.Project(ctx.Projectid); !ok {
+	proj, ok := c.ds.Project(ctx.Projectid)
+	if !ok {
 		return ctx.NotFound()
 	}
 	resource, ok := c.ds.Resource(ctx.ResourceID)
-	if !ok {
+	if !ok || !proj.HasNamespaceLink(resource.NamespaceID) {
 		return ctx.NotFound()
 	}
 	res := MarshalResourcesObject(resource)
 	return ctx.OK(res)
 	// ClusterController_Get: end_implement
 }
+
+// Update runs the update action.
+func (c *ClusterController) Update(ctx *app.UpdateClusterContext) error {
+	// ClusterController_Update: start_implement
+	proj, ok := c.ds.Project(ctx.Projectid)
+	if !ok {
+		return ctx.NotFound()
+	}
+	res, ok := c.ds.Resource(ctx.ResourceID)
+	if !ok {
+		return ctx.NotFound()
+	}
+	ns, ok := c.ds.Namespace(res.NamespaceID)
+	if !ok || !proj.HasNamespaceLink(ns.OID) {
+		return ctx.NotFound()
+	}
+	if !ns.HasResourceLink(ctx.ResourceID) {
+		return ctx.BadRequest(errors.New("Inavlid Cluster Resource Object ID specified in request"))
+	}
+	if res.State != ResourceActive && res.State != ResourceErrorStarting {
+		return ctx.Conflict()
+	}
+
+	if ctx.Plan {
+		planned := *res
+		planned.NodePoolSize = ctx.Payload.NodePoolSize
+		plan, err := c.backend.PlanProject(UpdateProject, proj, ns, &planned)
+		if err != nil {
+			return ctx.BadRequest(goa.ErrBadRequest(err))
+		}
+		return ctx.OK(MarshalProjectPlan("update", plan))
+	}
+
+	c.ds.UpdateResource(res, ctx.Payload.NodePoolSize)
+	c.backend.ProjectRequest(ctx, UpdateProject, c.ds, proj, ns, res)
+
+	return ctx.Accepted(MarshalResourcesObject(res))
+	// ClusterController_Update: end_implement
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	git "gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/config"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+const (
+	// GitUserName - committer name for configuration commits
+	GitUserName = "krak8s"
+	// GitUserEmail - committer email for configuration commits
+	GitUserEmail = "krak8s@localhost"
+	// gitRemoteURL - name of the remote given as a URL, see pushConfigChange()
+	gitRemoteURL = "krak8s"
+)
+
+var (
+	// git versioning of the configuration directory, see SetConfigGit()
+	configGit       bool
+	configGitRemote string
+)
+
+// SetConfigGit - enable committing each configuration file change to a git
+// repository in the configuration directory, and pushing the commits to
+// remote, if it isn't empty.  The remote is the name of one of the
+// repository's remotes, or a URL.
+func SetConfigGit(enable bool, remote string) {
+	configGit = enable
+	configGitRemote = remote
+}
+
+// configCommitMessage - the commit message for the configuration change made
+// by the operation of rev
+func configCommitMessage(rev ConfigRevision, requestID string) string {
+	subject := "krak8s: " + rev.Operation
+	body := []string{"Operation: " + rev.Operation}
+	if rev.Chart != "" {
+		subject += " " + rev.Chart
+	} else if rev.NodePool != "" {
+		subject += " " + rev.NodePool
+	} else if rev.Project != "" {
+		subject += " " + NodePoolName(rev.Project)
+	}
+	if rev.Project != "" {
+		body = append(body, "Project: "+rev.Project)
+	}
+	if rev.Chart != "" {
+		body = append(body, "Chart: "+rev.Chart)
+	}
+	if rev.Restored != "" {
+		subject += " revision " + rev.Restored
+		body = append(body, "Restored: "+rev.Restored)
+	}
+	if requestID != "" {
+		body = append(body, "Request: "+requestID)
+	}
+	return subject + "\n\n" + strings.Join(body, "\n") + "\n"
+}
+
+// openConfigRepository - the git repository of the directory, initialized if
+// there isn't one.  The repository of an enclosing work tree isn't used, its
+// commits and pushes aren't krak8s's to make.
+func openConfigRepository(dir string) (*git.Repository, error) {
+	repo, err := git.PlainOpen(dir)
+	if err == git.ErrRepositoryNotExists {
+		glog.Infof("initializing git repository in %s", dir)
+		return git.PlainInit(dir, false)
+	}
+	return repo, err
+}
+
+// commitConfigChange - commit the configuration file, as changed by the
+// operation of rev, to the git repository in its directory, initializing the
+// repository if there isn't one, and push the commit to the remote.  Does
+// nothing unless enabled, see SetConfigGit(), or in dryrun.
+func commitConfigChange(filename string, rev ConfigRevision, requestID string) error {
+	if !configGit || dryrun {
+		return nil
+	}
+	filename, err := filepath.Abs(filename)
+	if err != nil {
+		return err
+	}
+	repo, err := openConfigRepository(filepath.Dir(filename))
+	if err != nil {
+		glog.Warningf("unable to open the git repository of %s: %v", filename, err)
+		return err
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return err
+	}
+	name, err := filepath.Rel(worktree.Filesystem.Root(), filename)
+	if err != nil {
+		return err
+	}
+	name = filepath.ToSlash(name)
+	if _, err = worktree.Add(name); err != nil {
+		glog.Warningf("git add of %s failed: %v", name, err)
+		return err
+	}
+	// nothing staged, the file is unchanged
+	status, err := worktree.Status()
+	if err != nil {
+		return err
+	}
+	if file, ok := status[name]; !ok || file.Staging == git.Unmodified {
+		return nil
+	}
+	signature := &object.Signature{Name: GitUserName, Email: GitUserEmail, When: time.Now()}
+	_, err = worktree.Commit(configCommitMessage(rev, requestID), &git.CommitOptions{Author: signature})
+	if err != nil {
+		glog.Warningf("git commit of %s failed: %v", name, err)
+		return err
+	}
+	if configGitRemote != "" {
+		if err := pushConfigChange(repo); err != nil {
+			glog.Warningf("git push to %s failed: %v", configGitRemote, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// pushConfigChange - push the current branch to the same branch of the
+// remote, one of the repository's remotes or a URL
+func pushConfigChange(repo *git.Repository) error {
+	head, err := repo.Head()
+	if err != nil {
+		return err
+	}
+	remote, err := repo.Remote(configGitRemote)
+	if err == git.ErrRemoteNotFound {
+		remote = git.NewRemote(repo.Storer, &config.RemoteConfig{
+			Name: gitRemoteURL,
+			URLs: []string{configGitRemote},
+		})
+	} else if err != nil {
+		return err
+	}
+	refSpec := config.RefSpec(head.Name().String() + ":" + head.Name().String())
+	err = remote.Push(&git.PushOptions{RemoteName: remote.Config().Name, RefSpecs: []config.RefSpec{refSpec}})
+	if err == git.NoErrAlreadyUpToDate {
+		return nil
+	}
+	return err
+}
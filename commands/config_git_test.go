@@ -0,0 +1,144 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	git "gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+func TestConfigCommitMessage(t *testing.T) {
+	have := configCommitMessage(ConfigRevision{Operation: ConfigOpUpdate, Project: "acme"}, "12")
+	want := "krak8s: update acmeNodes\n\nOperation: update\nProject: acme\nRequest: 12\n"
+	if have != want {
+		t.Errorf("configCommitMessage(update) have %q, want %q", have, want)
+	}
+	have = configCommitMessage(ConfigRevision{Operation: ConfigOpRestore, Restored: "1508400000"}, "")
+	want = "krak8s: restore revision 1508400000\n\nOperation: restore\nRestored: 1508400000\n"
+	if have != want {
+		t.Errorf("configCommitMessage(restore) have %q, want %q", have, want)
+	}
+}
+
+func TestCommitConfigChange(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "kraken")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filename := path.Join(dir, DefaultConfigFile)
+	if err := ioutil.WriteFile(filename, []byte(testKrakenConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path.Join(dir, "other.yaml"), []byte("other: true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	SetConfigGit(true, "")
+	defer SetConfigGit(false, "")
+
+	cfg := NewProjectConfig("acme", 5, "acme-ns")
+	cfg.RequestID = "host/$HOME-7"
+	if err := UpdateProject(cfg, filename); err != nil {
+		t.Fatalf("UpdateProject(acme) have %v, want nil", err)
+	}
+	// an unchanged file isn't committed again
+	if err := commitConfigChange(filename, ConfigRevision{Operation: ConfigOpUpdate}, ""); err != nil {
+		t.Errorf("commitConfigChange(unchanged) have %v, want nil", err)
+	}
+
+	repo, err := git.PlainOpen(dir)
+	if err != nil {
+		t.Fatalf("git.PlainOpen() have %v, want nil", err)
+	}
+	commits, err := repo.Log(&git.LogOptions{})
+	if err != nil {
+		t.Fatalf("git log have %v, want nil", err)
+	}
+	messages := []string{}
+	commits.ForEach(func(c *object.Commit) error {
+		messages = append(messages, c.Message)
+		return nil
+	})
+	// the request id isn't expanded as an environment variable
+	if len(messages) != 1 || !strings.Contains(messages[0], "Request: host/$HOME-7") {
+		t.Errorf("git log have %q, want one commit for request host/$HOME-7", messages)
+	}
+	head, _ := repo.Head()
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	iter, err := commit.Files()
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := []string{}
+	iter.ForEach(func(f *object.File) error {
+		files = append(files, f.Name)
+		return nil
+	})
+	if len(files) != 1 || files[0] != DefaultConfigFile {
+		t.Errorf("committed files have %q, want only %s", files, DefaultConfigFile)
+	}
+}
+
+func TestCommitConfigChangeParentRepository(t *testing.T) {
+	parent, err := ioutil.TempDir(os.TempDir(), "kraken")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(parent)
+	if _, err := git.PlainInit(parent, false); err != nil {
+		t.Fatal(err)
+	}
+	dir := path.Join(parent, "config")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	filename := path.Join(dir, DefaultConfigFile)
+	if err := ioutil.WriteFile(filename, []byte(testKrakenConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+	SetConfigGit(true, "")
+	defer SetConfigGit(false, "")
+
+	if err := UpdateProject(NewProjectConfig("acme", 5, "acme-ns"), filename); err != nil {
+		t.Fatalf("UpdateProject(acme) have %v, want nil", err)
+	}
+	// the change is committed to a repository of the configuration directory,
+	// not to the enclosing one
+	repo, err := git.PlainOpen(dir)
+	if err != nil {
+		t.Fatalf("git.PlainOpen(config) have %v, want nil", err)
+	}
+	if _, err := repo.Head(); err != nil {
+		t.Errorf("config repository head have %v, want a commit", err)
+	}
+	repo, err = git.PlainOpen(parent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.Head(); err == nil {
+		t.Errorf("enclosing repository head have a commit, want none")
+	}
+}
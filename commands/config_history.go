@@ -0,0 +1,361 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+// Every change to the configuration file first copies the file to a backup,
+// <file>.<unix-time>, the revision.  The history index, <file>.history,
+// records the operation that replaced each revision.
+
+const (
+	// ConfigHistorySuffix - file name suffix of the configuration history index
+	ConfigHistorySuffix = ".history"
+	// CurrentRevision - the revision id of the configuration file itself
+	CurrentRevision = "current"
+	// DefaultConfigHistoryMax - default number of revisions kept
+	DefaultConfigHistoryMax = 50
+)
+
+const (
+	// ConfigOpAdd - revision replaced by adding a project's node pool
+	ConfigOpAdd = "add"
+	// ConfigOpUpdate - revision replaced by updating a project's node pool
+	ConfigOpUpdate = "update"
+	// ConfigOpDelete - revision replaced by removing a project's node pool
+	ConfigOpDelete = "delete"
+	// ConfigOpAddChart - revision replaced by adding an application's chart
+	ConfigOpAddChart = "add-chart"
+	// ConfigOpRemoveChart - revision replaced by removing an application's chart
+	ConfigOpRemoveChart = "remove-chart"
+	// ConfigOpRestore - revision replaced by restoring an earlier revision
+	ConfigOpRestore = "restore"
+	// ConfigOpUnknown - revision with no history index record
+	ConfigOpUnknown = "unknown"
+)
+
+const (
+	// ConfigUpdatePending - the cluster update of a restore is waiting or running
+	ConfigUpdatePending = "pending"
+	// ConfigUpdateApplied - the cluster update of a restore succeeded
+	ConfigUpdateApplied = "applied"
+	// ConfigUpdateFailed - the cluster update of a restore failed
+	ConfigUpdateFailed = "failed"
+)
+
+// ErrNoConfigRevision - the configuration revision doesn't exist
+var ErrNoConfigRevision = errors.New("no such config revision")
+
+var (
+	// revision retention, see SetConfigHistoryRetention()
+	historyMax    = DefaultConfigHistoryMax
+	historyMaxAge time.Duration
+	// serializes updates to the history index
+	historyLock sync.Mutex
+)
+
+// SetConfigHistoryRetention - keep at most max revisions, and none older
+// than maxAge, zero for either is unlimited.
+func SetConfigHistoryRetention(max int, maxAge time.Duration) {
+	historyMax = max
+	historyMaxAge = maxAge
+}
+
+// ConfigRevision - a saved revision of the configuration file, and the
+// operation that replaced it.
+type ConfigRevision struct {
+	ID        string    `json:"id"`
+	Time      time.Time `json:"time"`
+	Operation string    `json:"operation"`
+	Project   string    `json:"project,omitempty"`
+	NodePool  string    `json:"nodePool,omitempty"`
+	// the application chart, for ConfigOpAddChart and ConfigOpRemoveChart
+	Chart string `json:"chart,omitempty"`
+	// the revision restored, for ConfigOpRestore
+	Restored string `json:"restored,omitempty"`
+	// the state of the cluster update run for a ConfigOpRestore, if any
+	Update string `json:"update,omitempty"`
+}
+
+func historyFile(filename string) string {
+	return filename + ConfigHistorySuffix
+}
+
+func revisionFile(filename, id string) string {
+	return filename + "." + id
+}
+
+func readHistory(filename string) ([]ConfigRevision, error) {
+	data, err := ioutil.ReadFile(historyFile(filename))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	revisions := []ConfigRevision{}
+	if err = json.Unmarshal(data, &revisions); err != nil {
+		return nil, fmt.Errorf("%s: %v", historyFile(filename), err)
+	}
+	return revisions, nil
+}
+
+func writeHistory(filename string, revisions []ConfigRevision) error {
+	data, err := json.MarshalIndent(revisions, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(historyFile(filename), data, os.FileMode(0644))
+}
+
+// ConfigRevisions - the saved revisions of the configuration file, newest
+// first.  Backups made before the history index existed are included, with
+// the operation ConfigOpUnknown.
+func ConfigRevisions(filename string) ([]ConfigRevision, error) {
+	historyLock.Lock()
+	defer historyLock.Unlock()
+	return configRevisions(filename)
+}
+
+func configRevisions(filename string) ([]ConfigRevision, error) {
+	history, err := readHistory(filename)
+	if err != nil {
+		return nil, err
+	}
+	indexed := make(map[string]ConfigRevision)
+	for _, rev := range history {
+		indexed[rev.ID] = rev
+	}
+	backups, err := filepath.Glob(filename + ".*")
+	if err != nil {
+		return nil, err
+	}
+	revisions := []ConfigRevision{}
+	for _, backup := range backups {
+		id := strings.TrimPrefix(backup, filename+".")
+		unix, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			continue
+		}
+		rev, ok := indexed[id]
+		if !ok {
+			rev = ConfigRevision{ID: id, Time: time.Unix(unix, 0), Operation: ConfigOpUnknown}
+		}
+		revisions = append(revisions, rev)
+	}
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Time.After(revisions[j].Time)
+	})
+	return revisions, nil
+}
+
+// copyConfigFileBackup - copy the file to a new revision, path.<unix-time>,
+// record the operation of rev replacing it in the history index, and prune
+// the old revisions.  Returns the name of the copy.
+func copyConfigFileBackup(path string, rev ConfigRevision) (string, error) {
+	historyLock.Lock()
+	defer historyLock.Unlock()
+	rev, err := backupConfigFile(path, rev)
+	if err != nil {
+		return "", err
+	}
+	return revisionFile(path, rev.ID), nil
+}
+
+func backupConfigFile(path string, rev ConfigRevision) (ConfigRevision, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return rev, err
+	}
+	rev.Time = time.Now()
+	// one revision per second, later revisions in the same second take the
+	// next free second
+	unix := rev.Time.Unix()
+	for {
+		if _, err := os.Stat(revisionFile(path, strconv.FormatInt(unix, 10))); os.IsNotExist(err) {
+			break
+		}
+		unix++
+	}
+	rev.ID = strconv.FormatInt(unix, 10)
+	if err := copyFile(revisionFile(path, rev.ID), path, os.FileMode(0644)); err != nil {
+		return rev, err
+	}
+
+	history, err := readHistory(path)
+	if err != nil {
+		glog.Warningf("unable to read config history, starting a new history: %v", err)
+	}
+	if err = writeHistory(path, append(history, rev)); err != nil {
+		glog.Warningf("unable to record config revision %s: %v", rev.ID, err)
+	}
+	if err = pruneConfigHistory(path, rev.ID); err != nil {
+		glog.Warningf("unable to prune config history: %v", err)
+	}
+	return rev, nil
+}
+
+// pruneConfigHistory - remove the revisions beyond the retention limits,
+// other than keep.
+func pruneConfigHistory(filename, keep string) error {
+	revisions, err := configRevisions(filename)
+	if err != nil {
+		return err
+	}
+	removed := make(map[string]bool)
+	for i, rev := range revisions {
+		if rev.ID == keep {
+			continue
+		}
+		if (historyMax > 0 && i >= historyMax) ||
+			(historyMaxAge > 0 && time.Since(rev.Time) > historyMaxAge) {
+			if err := os.Remove(revisionFile(filename, rev.ID)); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			removed[rev.ID] = true
+		}
+	}
+	if len(removed) == 0 {
+		return nil
+	}
+	history, err := readHistory(filename)
+	if err != nil {
+		return err
+	}
+	kept := []ConfigRevision{}
+	for _, rev := range history {
+		if !removed[rev.ID] {
+			kept = append(kept, rev)
+		}
+	}
+	return writeHistory(filename, kept)
+}
+
+// ConfigRevisionData - the contents of the revision, CurrentRevision for the
+// configuration file itself.
+func ConfigRevisionData(filename, id string) ([]byte, error) {
+	if id == CurrentRevision {
+		return ioutil.ReadFile(filename)
+	}
+	if _, err := strconv.ParseInt(id, 10, 64); err != nil {
+		return nil, fmt.Errorf("invalid config revision %q", id)
+	}
+	data, err := ioutil.ReadFile(revisionFile(filename, id))
+	if os.IsNotExist(err) {
+		return nil, ErrNoConfigRevision
+	}
+	return data, err
+}
+
+// DiffConfigRevisions - the unified diff from revision from to revision to,
+// either may be CurrentRevision.
+func DiffConfigRevisions(filename, from, to string) (string, error) {
+	a, err := ConfigRevisionData(filename, from)
+	if err != nil {
+		return "", err
+	}
+	b, err := ConfigRevisionData(filename, to)
+	if err != nil {
+		return "", err
+	}
+	name := filepath.Base(filename)
+	return UnifiedDiff(name+"@"+from, name+"@"+to, a, b), nil
+}
+
+// RestoreConfigRevision - replace the configuration file with the revision,
+// after saving the file as a new revision.  The revision must validate, see
+// KrakenConfig.Validate(), and have the node pools of nodePools, the node
+// counts of the projects' node pools by name, see
+// KrakenConfig.ReconcileNodePools().  The change is committed with the id of
+// the request restoring it, see commitConfigChange().  Returns the new
+// revision, the node pools that differ between the replaced file and the
+// revision, and the node pools of nodePools the revision resizes, with the
+// revision's count.
+func RestoreConfigRevision(filename, id, requestID string, nodePools map[string]int) (*ConfigRevision, *NodePoolChanges, map[string]int, error) {
+	historyLock.Lock()
+	defer historyLock.Unlock()
+
+	restored, err := ConfigRevisionData(filename, id)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if id == CurrentRevision {
+		return nil, nil, nil, fmt.Errorf("invalid config revision %q", id)
+	}
+	k, err := ParseKrakenConfig(restored)
+	if err != nil {
+		return nil, nil, nil, &KrakenConfigError{Problems: []string{err.Error()}}
+	}
+	if err = k.Validate(); err != nil {
+		return nil, nil, nil, err
+	}
+	resized, err := k.ReconcileNodePools(nodePools)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	replaced, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	changes, err := DiffNodePools(replaced, restored)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	rev, err := backupConfigFile(filename, ConfigRevision{Operation: ConfigOpRestore, Restored: id})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if err = writeFile(filename, restored, os.FileMode(0644)); err != nil {
+		return nil, nil, nil, err
+	}
+	if err = commitConfigChange(filename, rev, requestID); err != nil {
+		glog.Warningf("unable to commit config file change to git: %v", err)
+	}
+	return &rev, changes, resized, nil
+}
+
+// SetConfigRevisionUpdate - record the state of the cluster update run for
+// the restore revision, one of ConfigUpdatePending, ConfigUpdateApplied and
+// ConfigUpdateFailed.
+func SetConfigRevisionUpdate(filename, id, state string) error {
+	historyLock.Lock()
+	defer historyLock.Unlock()
+	history, err := readHistory(filename)
+	if err != nil {
+		return err
+	}
+	for i := range history {
+		if history[i].ID == id {
+			history[i].Update = state
+			return writeHistory(filename, history)
+		}
+	}
+	return ErrNoConfigRevision
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestConfigHistory(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "kraken")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filename := path.Join(dir, DefaultConfigFile)
+	if err := ioutil.WriteFile(filename, []byte(testKrakenConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// a backup made before the history index existed
+	if err := ioutil.WriteFile(revisionFile(filename, "1000"), []byte(testKrakenConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+	SetConfigHistoryRetention(2, 0)
+	defer SetConfigHistoryRetention(DefaultConfigHistoryMax, 0)
+
+	if revisions, _ := ConfigRevisions(filename); len(revisions) != 1 || revisions[0].Operation != ConfigOpUnknown {
+		t.Errorf("ConfigRevisions() have %v, want the unknown revision 1000", revisions)
+	}
+	if err := UpdateProject(NewProjectConfig("acme", 5, "acme-ns"), filename); err != nil {
+		t.Fatalf("UpdateProject(acme) have %v, want nil", err)
+	}
+	if err := DeleteProject(NewProjectConfig("acmeCorp", 2, "acmeCorp-ns"), filename); err != nil {
+		t.Fatalf("DeleteProject(acmeCorp) have %v, want nil", err)
+	}
+
+	// the third revision, 1000, is pruned
+	revisions, err := ConfigRevisions(filename)
+	if err != nil || len(revisions) != 2 {
+		t.Fatalf("ConfigRevisions() have %v, %v, want 2 revisions", revisions, err)
+	}
+	if revisions[0].Operation != ConfigOpDelete || revisions[0].Project != "acmeCorp" ||
+		revisions[1].Operation != ConfigOpUpdate || revisions[1].Project != "acme" {
+		t.Errorf("ConfigRevisions() have %v, want delete acmeCorp, update acme", revisions)
+	}
+	if _, err := os.Stat(revisionFile(filename, "1000")); !os.IsNotExist(err) {
+		t.Errorf("revision 1000 have %v, want it pruned", err)
+	}
+
+	original := revisions[1].ID
+	diff, err := DiffConfigRevisions(filename, original, CurrentRevision)
+	if err != nil {
+		t.Fatalf("DiffConfigRevisions() have %v, want nil", err)
+	}
+	for _, want := range []string{"-          count: 1\n", "+          count: 5\n", "-        - name: acmeCorpNodes\n"} {
+		if !strings.Contains(diff, want) {
+			t.Errorf("DiffConfigRevisions() have\n%s\nwant it to contain %q", diff, want)
+		}
+	}
+	if _, err := DiffConfigRevisions(filename, "1000", CurrentRevision); err != ErrNoConfigRevision {
+		t.Errorf("DiffConfigRevisions(1000) have %v, want ErrNoConfigRevision", err)
+	}
+
+	// a revision without the node pool of a cluster resource isn't restored
+	_, _, _, err = RestoreConfigRevision(filename, original, "", map[string]int{"acmeNodes": 5, "gammaNodes": 1})
+	if cerr, ok := err.(*NodePoolConflictError); !ok || !reflect.DeepEqual(cerr.Pools, []string{"gammaNodes"}) {
+		t.Errorf("RestoreConfigRevision(gamma) have %v, want a conflict of gammaNodes", err)
+	}
+	if data, _ := ioutil.ReadFile(filename); string(data) == testKrakenConfig {
+		t.Errorf("RestoreConfigRevision(gamma) restored the revision")
+	}
+
+	rev, changes, resized, err := RestoreConfigRevision(filename, original, "", map[string]int{"acmeNodes": 5, "acmeCorpNodes": 2})
+	if err != nil {
+		t.Fatalf("RestoreConfigRevision() have %v, want nil", err)
+	}
+	if rev.Operation != ConfigOpRestore || rev.Restored != original {
+		t.Errorf("RestoreConfigRevision() have %v, want restore of %s", rev, original)
+	}
+	want := &NodePoolChanges{Added: []string{"acmeCorpNodes"}, Updated: []string{"acmeNodes"}}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("RestoreConfigRevision() changes have %+v, want %+v", changes, want)
+	}
+	if !reflect.DeepEqual(resized, map[string]int{"acmeNodes": 1}) {
+		t.Errorf("RestoreConfigRevision() resized have %v, want acmeNodes resized to 1", resized)
+	}
+	if data, _ := ioutil.ReadFile(filename); string(data) != testKrakenConfig {
+		t.Errorf("RestoreConfigRevision() have\n%s\nwant the original configuration", data)
+	}
+
+	if err := SetConfigRevisionUpdate(filename, rev.ID, ConfigUpdateFailed); err != nil {
+		t.Errorf("SetConfigRevisionUpdate() have %v, want nil", err)
+	}
+	if revisions, _ := ConfigRevisions(filename); len(revisions) == 0 || revisions[0].Update != ConfigUpdateFailed {
+		t.Errorf("ConfigRevisions() have %v, want the restore's update failed", revisions)
+	}
+	if err := SetConfigRevisionUpdate(filename, "3000", ConfigUpdateApplied); err != ErrNoConfigRevision {
+		t.Errorf("SetConfigRevisionUpdate(3000) have %v, want ErrNoConfigRevision", err)
+	}
+
+	// a revision that doesn't validate isn't restored
+	ioutil.WriteFile(revisionFile(filename, "2000"), []byte("deployment: *missing\n"), 0644)
+	if _, _, _, err := RestoreConfigRevision(filename, "2000", "", nil); err == nil {
+		t.Errorf("RestoreConfigRevision(invalid) have nil, want error")
+	}
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+const (
+	// ConfigLockSuffix - suffix of the configuration file's lock file
+	ConfigLockSuffix = ".lock"
+	// DefaultConfigLockStale - default age after which a held lock is stale
+	DefaultConfigLockStale = time.Hour
+	// DefaultConfigLockWait - default time a change waits for a held lock
+	DefaultConfigLockWait = 30 * time.Second
+)
+
+var (
+	configLockStale = DefaultConfigLockStale
+	configLockWait  = DefaultConfigLockWait
+)
+
+// how often a held lock is tried again while waiting for it
+var configLockPoll = 250 * time.Millisecond
+
+// SetConfigLockStale - set the age after which a held configuration lock is
+// reported as stale.
+func SetConfigLockStale(stale time.Duration) {
+	if stale > 0 {
+		configLockStale = stale
+	}
+}
+
+// SetConfigLockWait - set how long LockConfig() waits for a configuration
+// lock held by another owner.  The backend runs one request at a time, so
+// a long wait holds up every request queued behind it.
+func SetConfigLockWait(wait time.Duration) {
+	if wait >= 0 {
+		configLockWait = wait
+	}
+}
+
+// ConfigLockOwner - the lock file record of the lock's holder
+type ConfigLockOwner struct {
+	Host      string    `json:"host"`
+	PID       int       `json:"pid"`
+	Operation string    `json:"operation"`
+	Time      time.Time `json:"time"`
+}
+
+func (o ConfigLockOwner) String() string {
+	if o.PID == 0 {
+		return "an unknown owner"
+	}
+	return fmt.Sprintf("%s pid %d (%s) since %s", o.Host, o.PID, o.Operation, o.Time.Format(time.RFC3339))
+}
+
+// ConfigLockError - the configuration file is locked by another owner
+type ConfigLockError struct {
+	Filename string
+	Owner    ConfigLockOwner
+	// Stale - the owner has held the lock longer than the stale age
+	Stale bool
+}
+
+func (e *ConfigLockError) Error() string {
+	msg := fmt.Sprintf("kraken configuration %s is locked by %s", e.Filename, e.Owner)
+	if e.Stale {
+		msg += ", the lock is stale"
+	}
+	return msg
+}
+
+// ConfigLock - an exclusive advisory lock, flock(2) of the lock file
+// <filename>.lock, of a configuration file.  The lock file records the
+// holder, see ConfigLockOwner.
+type ConfigLock struct {
+	filename string
+	file     *os.File
+	owner    ConfigLockOwner
+	stale    *time.Timer
+}
+
+// LockConfig - lock the configuration file for the operation, waiting for a
+// lock held by another owner up to the wait, see SetConfigLockWait(), and not
+// at all for a stale lock.  Hold the lock across the edit of the file and the
+// k2 run applying it.
+func LockConfig(filename, operation string) (*ConfigLock, error) {
+	return lockConfig(filename, operation, configLockWait)
+}
+
+// TryLockConfig - LockConfig() without waiting, a *ConfigLockError if the
+// configuration file is locked.
+func TryLockConfig(filename, operation string) (*ConfigLock, error) {
+	return lockConfig(filename, operation, 0)
+}
+
+func lockConfig(filename, operation string, wait time.Duration) (*ConfigLock, error) {
+	file, err := os.OpenFile(filename+ConfigLockSuffix, os.O_RDWR|os.O_CREATE, os.FileMode(0644))
+	if err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(wait)
+	waiting := false
+	for {
+		err = syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+		if err == nil {
+			break
+		}
+		if err != syscall.EWOULDBLOCK {
+			file.Close()
+			return nil, err
+		}
+		owner := readConfigLockOwner(file)
+		stale := owner.PID != 0 && time.Since(owner.Time) > configLockStale
+		if stale || !time.Now().Before(deadline) {
+			file.Close()
+			lockErr := &ConfigLockError{Filename: filename, Owner: owner, Stale: stale}
+			if stale {
+				glog.Warningf("%v", lockErr)
+			}
+			return nil, lockErr
+		}
+		if !waiting {
+			glog.Infof("waiting for kraken configuration %s, locked by %s", filename, owner)
+			waiting = true
+		}
+		time.Sleep(configLockPoll)
+	}
+
+	host, _ := os.Hostname()
+	lock := &ConfigLock{
+		filename: filename,
+		file:     file,
+		owner:    ConfigLockOwner{Host: host, PID: os.Getpid(), Operation: operation, Time: time.Now()},
+	}
+	data, _ := json.Marshal(lock.owner)
+	if err = file.Truncate(0); err == nil {
+		_, err = file.WriteAt(append(data, '\n'), 0)
+	}
+	if err != nil {
+		glog.Warningf("unable to record the kraken configuration lock owner: %v", err)
+	}
+	lock.stale = time.AfterFunc(configLockStale, func() {
+		glog.Warningf("kraken configuration %s lock held by %s, the lock is stale", filename, lock.owner)
+	})
+	return lock, nil
+}
+
+func readConfigLockOwner(file *os.File) ConfigLockOwner {
+	owner := ConfigLockOwner{}
+	data, err := ioutil.ReadFile(file.Name())
+	if err == nil && len(data) > 0 {
+		json.Unmarshal(data, &owner)
+	}
+	return owner
+}
+
+// Unlock - release the lock, clearing the lock file's owner record.
+func (l *ConfigLock) Unlock() error {
+	l.stale.Stop()
+	l.file.Truncate(0)
+	err := syscall.Flock(int(l.file.Fd()), syscall.LOCK_UN)
+	if closeErr := l.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
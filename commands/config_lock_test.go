@@ -0,0 +1,77 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+	"time"
+)
+
+func TestConfigLock(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "kraken")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filename := path.Join(dir, DefaultConfigFile)
+
+	lock, err := LockConfig(filename, "AddProject acmeNodes")
+	if err != nil {
+		t.Fatalf("LockConfig() have %v, want nil", err)
+	}
+	_, err = TryLockConfig(filename, "restore 1500000000")
+	lockErr, ok := err.(*ConfigLockError)
+	if !ok {
+		t.Fatalf("TryLockConfig() of a locked file have %v, want *ConfigLockError", err)
+	}
+	if lockErr.Owner.PID != os.Getpid() || lockErr.Owner.Operation != "AddProject acmeNodes" || lockErr.Stale {
+		t.Errorf("TryLockConfig() have owner %+v stale %v, want this process's AddProject", lockErr.Owner, lockErr.Stale)
+	}
+	if err := lock.Unlock(); err != nil {
+		t.Errorf("Unlock() have %v, want nil", err)
+	}
+
+	lock, err = TryLockConfig(filename, "restore 1500000000")
+	if err != nil {
+		t.Fatalf("TryLockConfig() of an unlocked file have %v, want nil", err)
+	}
+	defer lock.Unlock()
+
+	// a held lock is waited for up to the wait
+	defer SetConfigLockWait(configLockWait)
+	SetConfigLockWait(50 * time.Millisecond)
+	start := time.Now()
+	_, err = LockConfig(filename, "UpdateProject acmeNodes")
+	if lockErr, ok := err.(*ConfigLockError); !ok || lockErr.Stale {
+		t.Errorf("LockConfig() of a held lock have %v, want *ConfigLockError", err)
+	}
+	if waited := time.Since(start); waited < 50*time.Millisecond || waited > 5*time.Second {
+		t.Errorf("LockConfig() of a held lock waited %v, want 50ms", waited)
+	}
+
+	// a lock held longer than the stale age isn't waited for
+	defer SetConfigLockStale(configLockStale)
+	SetConfigLockStale(10 * time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	_, err = LockConfig(filename, "DeleteProject acmeNodes")
+	if lockErr, ok := err.(*ConfigLockError); !ok || !lockErr.Stale {
+		t.Errorf("LockConfig() of a stale lock have %v, want stale *ConfigLockError", err)
+	}
+}
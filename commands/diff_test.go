@@ -0,0 +1,50 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import "testing"
+
+func TestUnifiedDiff(t *testing.T) {
+	a := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n"
+	b := "1\n2\nthree\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n"
+	want := `--- a
++++ b
+@@ -1,6 +1,6 @@
+ 1
+ 2
+-3
++three
+ 4
+ 5
+ 6
+@@ -10,3 +10,4 @@
+ 10
+ 11
+ 12
++13
+`
+	if have := UnifiedDiff("a", "b", []byte(a), []byte(b)); have != want {
+		t.Errorf("UnifiedDiff() have\n%s\nwant\n%s", have, want)
+	}
+	if have := UnifiedDiff("a", "b", []byte(a), []byte(a)); have != "" {
+		t.Errorf("UnifiedDiff(same) have %q, want empty", have)
+	}
+	want = "--- a\n+++ b\n@@ -0,0 +1,1 @@\n+1\n"
+	if have := UnifiedDiff("a", "b", nil, []byte("1\n")); have != want {
+		t.Errorf("UnifiedDiff(empty) have %q, want %q", have, want)
+	}
+}
@@ -18,6 +18,7 @@ package commands
 
 import (
 	"bytes"
+	"context"
 	"os"
 	"os/exec"
 
@@ -27,6 +28,9 @@ import (
 var (
 	debug  bool
 	dryrun bool
+
+	// execObserver - called with each command run, see SetExecObserver()
+	execObserver func(ctx context.Context, command string, arguments []string, err error)
 )
 
 // SetDebug enable true/false debugging output
@@ -39,6 +43,14 @@ func SetDryrun(enable bool) {
 	dryrun = enable
 }
 
+// SetExecObserver - call the observer with each command Execute() runs, the
+// context it was run with, context.Background() unless ExecuteContext() was
+// given one, its arguments before environment variable expansion, and its
+// error.  Commands aren't run, or observed, in dryrun.
+func SetExecObserver(observer func(ctx context.Context, command string, arguments []string, err error)) {
+	execObserver = observer
+}
+
 // EnvExpansion - check all members of a string slice to see if any are
 // environment variables than can be expanded.  The function will expand, at
 // most, 4 levels of environment variable expansion before stopping.
@@ -67,9 +79,28 @@ func EnvExpansion(args []string) []string {
 // on success: the resultant byte array containing stdout, error = nil
 // on failure: the resultant byte array containing stderr, error is set
 func Execute(command string, arguments []string) ([]byte, error) {
+	return ExecuteWithInput(command, arguments, nil)
+}
+
+// ExecuteWithInput - Execute() the "command" with the input on its stdin,
+// for secrets that mustn't be on its command line.
+func ExecuteWithInput(command string, arguments []string, input []byte) ([]byte, error) {
+	return executeContext(context.Background(), command, arguments, input)
+}
+
+// ExecuteContext - Execute() the "command", killing it if the context is done
+// before it completes.
+func ExecuteContext(ctx context.Context, command string, arguments []string) ([]byte, error) {
+	return executeContext(ctx, command, arguments, nil)
+}
+
+func executeContext(ctx context.Context, command string, arguments []string, input []byte) ([]byte, error) {
 	expandedArguments := EnvExpansion(arguments)
 
-	cmd := exec.Command(command, expandedArguments...)
+	cmd := exec.CommandContext(ctx, command, expandedArguments...)
+	if input != nil {
+		cmd.Stdin = bytes.NewReader(input)
+	}
 	stdoutBuf := &bytes.Buffer{}
 	stderrBuf := &bytes.Buffer{}
 	cmd.Stdout = stdoutBuf
@@ -85,7 +116,11 @@ func Execute(command string, arguments []string) ([]byte, error) {
 		return stdoutBuf.Bytes(), nil
 	}
 
-	if err := cmd.Run(); err != nil {
+	err := cmd.Run()
+	if execObserver != nil {
+		execObserver(ctx, command, arguments, err)
+	}
+	if err != nil {
 		glog.Warningf("cmd:  %s, args: %s returned error: %v", command, expandedArguments, err)
 		glog.Warningf("cmd:  %s, stderr: %s", command, string(stderrBuf.Bytes()))
 		glog.Warningf("cmd:  %s, stdout: %v", command, string(stdoutBuf.Bytes()))
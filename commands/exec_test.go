@@ -0,0 +1,38 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestExecuteContext(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := ExecuteContext(ctx, "sleep", []string{"5"}); err == nil {
+		t.Error("ExecuteContext() have nil, want error of the killed command")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("ExecuteContext() returned after %s, want the deadline", elapsed)
+	}
+	if output, err := ExecuteContext(context.Background(), "echo", []string{"done"}); err != nil || string(output) != "done\n" {
+		t.Errorf("ExecuteContext() have %q, %v, want done", output, err)
+	}
+}
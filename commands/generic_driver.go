@@ -17,8 +17,12 @@ limitations under the License.
 package commands
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"os"
+	"strings"
 
 	"github.com/ghodss/yaml"
 	"github.com/golang/glog"
@@ -32,10 +36,23 @@ type GenericDriver struct {
 	Server         string
 	Namespace      string
 	SetConfig      string
+	Values         map[string]interface{}
 	JSONValues     string
 	YAMLValues     []byte
 	Username       string
 	Password       string
+
+	// CustomerName and SchedulingPaths are set when the project owns a node
+	// pool, the chart's pods are then scheduled on to that node pool, or the
+	// NodePool if one is named.
+	CustomerName    string
+	NodePool        string
+	SchedulingPaths *SchedulingPaths
+
+	// KubeConfig and KubeContext select the cluster the chart is deployed
+	// on, helm's defaults are used when they are empty.
+	KubeConfig  string
+	KubeContext string
 }
 
 // setup temp file for YAML --value parameter
@@ -60,25 +77,77 @@ func tempValues(r GenericDriver) (string, error) {
 	return file.Name(), nil
 }
 
-// if credentials are present, they try login and return result
+// if credentials are present, they try login and return result.  The
+// password isn't on helm's command line: without -p the appr registry plugin
+// prompts for it, and with no terminal to prompt on reads it from stdin.
 func registryLogin(r GenericDriver) ([]byte, error) {
+	return registryLoginContext(context.Background(), r)
+}
+
+// registryLoginContext - registryLogin(), killed if the context is done first.
+func registryLoginContext(ctx context.Context, r GenericDriver) ([]byte, error) {
 	if r.Username != "" && r.Password != "" && r.Server != "" {
 		// Login required for private application repos
 		arguments := []string{"registry",
 			"login",
 			"-u " + r.Username,
-			"-p " + r.Password,
 			r.Server,
 		}
-		return r.execute(arguments)
+		// the registry login is not cluster specific
+		return executeContext(ctx, Helm, arguments, []byte(r.Password+"\n"))
 	}
 	return nil, nil
 }
 
-// convert internal json to yaml for api objects
-func jsonToYaml(r *GenericDriver) error {
-	json := []byte(r.JSONValues)
-	yaml, err := yaml.JSONToYAML(json)
+// Login - log in to the chart's registry, if credentials are present, without
+// deploying the chart.
+func (r GenericDriver) Login() ([]byte, error) {
+	return registryLogin(r)
+}
+
+// merge the chart's values.  The sources are merged in order, each one taking
+// precedence over those before it (the chart's own defaults are applied by
+// helm, underneath all of these):
+//  1. the structured values (from the values_yaml document and values object)
+//  2. the json values
+//  3. the --set argument string (SetConfig)
+//  4. the tenant scheduling values, when the project owns a node pool
+func mergedValues(r *GenericDriver) (map[string]interface{}, error) {
+	values := copyValues(r.Values)
+	if values == nil {
+		values = make(map[string]interface{})
+	}
+	if r.JSONValues != "" {
+		jsonValues := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(r.JSONValues), &jsonValues); err != nil {
+			glog.Infof("err: %v\n", err)
+			return nil, err
+		}
+		values = MergeValues(values, jsonValues)
+	}
+	if r.SetConfig != "" {
+		if err := ParseSet(r.SetConfig, values); err != nil {
+			glog.Infof("err: %v\n", err)
+			return nil, err
+		}
+	}
+	if r.CustomerName != "" && r.SchedulingPaths != nil {
+		nodePool := r.NodePool
+		if nodePool == "" {
+			nodePool = NodePoolName(r.CustomerName)
+		}
+		values = MergeValues(values, NodePoolScheduling(r.CustomerName, nodePool, *r.SchedulingPaths))
+	}
+	return values, nil
+}
+
+// build the chart's YAML values file from the merged values.
+func buildValues(r *GenericDriver) error {
+	values, err := mergedValues(r)
+	if err != nil {
+		return err
+	}
+	yaml, err := yaml.Marshal(values)
 	if err != nil {
 		glog.Infof("err: %v\n", err)
 		return err
@@ -93,7 +162,7 @@ func (r GenericDriver) Install() ([]byte, error) {
 		return output, err
 	}
 
-	if err := jsonToYaml(&r); err != nil {
+	if err := buildValues(&r); err != nil {
 		return nil, err
 	}
 
@@ -121,15 +190,15 @@ func (r GenericDriver) Upgrade() ([]byte, error) {
 		return output, err
 	}
 
-	filename, err := tempValues(r)
-	if err != nil {
+	if err := buildValues(&r); err != nil {
 		return nil, err
 	}
-	defer os.Remove(filename)
 
-	if err = jsonToYaml(&r); err != nil {
+	filename, err := tempValues(r)
+	if err != nil {
 		return nil, err
 	}
+	defer os.Remove(filename)
 
 	// Do the upgrade
 	arguments := []string{"registry",
@@ -151,6 +220,27 @@ func (r GenericDriver) Remove() ([]byte, error) {
 	return r.execute(arguments)
 }
 
+// ServiceChart - the chart as a Kraken helmConfig chart, for k2 to deploy,
+// with the merged values.  Private registries, those needing a login, can't
+// be deployed by k2.
+func (r GenericDriver) ServiceChart() (ServiceChart, error) {
+	if r.Username != "" && r.Password != "" {
+		return ServiceChart{}, fmt.Errorf("chart %s needs a registry login, kraken can't deploy it", r.ChartLocation)
+	}
+	values, err := mergedValues(&r)
+	if err != nil {
+		return ServiceChart{}, err
+	}
+	return ServiceChart{
+		Name:      r.DeploymentName,
+		Registry:  r.Server,
+		Chart:     strings.TrimPrefix(r.ChartLocation, r.Server+"/"),
+		Version:   r.Version,
+		Namespace: r.Namespace,
+		Values:    values,
+	}, nil
+}
+
 func (r GenericDriver) execute(arguments []string) ([]byte, error) {
-	return Execute("helm", arguments)
+	return Execute("helm", append(arguments, helmKubeArgs(r.KubeConfig, r.KubeContext)...))
 }
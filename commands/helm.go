@@ -33,6 +33,8 @@ const (
 	HelmUpdate = "update"
 	// HelmUpgrade - Helm subcommand upgrade
 	HelmUpgrade = "upgrade"
+	// HelmArgKubeConfig - path of the kubeconfig file to use
+	HelmArgKubeConfig = "--kubeconfig"
 	// HelmArgKubeContext - name of the kubeconfig context to use
 	HelmArgKubeContext = "--kube-context"
 	// HelmArgName - chart/release name
@@ -50,3 +52,16 @@ const (
 	// HelmArgWait - wait until all elements are created
 	HelmArgWait = "--wait"
 )
+
+// helmKubeArgs - the arguments selecting the cluster helm works on, each is
+// left to helm's default when empty.
+func helmKubeArgs(kubeConfig, kubeContext string) []string {
+	arguments := []string{}
+	if kubeConfig != "" {
+		arguments = append(arguments, HelmArgKubeConfig+" "+kubeConfig)
+	}
+	if kubeContext != "" {
+		arguments = append(arguments, HelmArgKubeContext+" "+kubeContext)
+	}
+	return arguments
+}
@@ -16,7 +16,10 @@ limitations under the License.
 
 package commands
 
-import "os"
+import (
+	"os"
+	"strings"
+)
 
 const (
 	// K2 - kraken command name
@@ -175,14 +178,46 @@ func K2CmdUp(docker bool, config string) []string {
 	}
 }
 
-// K2CmdUpdate - build a command string to call ".../bin/update.sh"
+// K2CmdUpdate - build a command string to call ".../bin/update.sh", and set
+// the KRAKEN_EXTRA_VARS environment variable for it.
 func K2CmdUpdate(docker bool, action, base, config, name string) []string {
+	return K2CmdUpdateNodePools(docker, action, base, config, []string{NodePoolName(name)})
+}
+
+// K2CmdUpdateNodePools - K2CmdUpdate() for the named node pools, rather than a
+// project's node pool.
+func K2CmdUpdateNodePools(docker bool, action, base, config string, nodePools []string) []string {
+	os.Setenv(K2ENVExtraVars, k2UpdateExtraVars(action, base, config, nodePools))
+	return k2CmdUpdate(docker, action, base, config, nodePools)
+}
 
-	nodePoolName := name + "Nodes"
-	extraVars := K2ExtraVarsConfigPath + "=" + config + " " +
+// K2PlanUpdate - the command string and environment K2CmdUpdate() would set
+// up, without changing the environment.
+func K2PlanUpdate(docker bool, action, base, config, name string) ([]string, []string) {
+	return K2PlanUpdateNodePools(docker, action, base, config, []string{NodePoolName(name)})
+}
+
+// K2PlanUpdateNodePools - K2PlanUpdate() for the named node pools, rather
+// than a project's node pool.
+func K2PlanUpdateNodePools(docker bool, action, base, config string, nodePools []string) ([]string, []string) {
+	env := []string{K2ENVExtraVars + "=" + k2UpdateExtraVars(action, base, config, nodePools)}
+	return k2CmdUpdate(docker, action, base, config, nodePools), env
+}
+
+// K2UpdateExtraVars - the KRAKEN_EXTRA_VARS value for ".../bin/update.sh"
+func K2UpdateExtraVars(action, base, config, name string) string {
+	return k2UpdateExtraVars(action, base, config, []string{NodePoolName(name)})
+}
+
+func k2UpdateExtraVars(action, base, config string, nodePools []string) string {
+	return K2ExtraVarsConfigPath + "=" + config + " " +
 		K2ExtraVarsConfigBase + "=" + base + " " +
-		K2ExtraVarsAction + " " + action + "=" + nodePoolName
-	os.Setenv(K2ENVExtraVars, extraVars)
+		K2ExtraVarsAction + " " + action + "=" + strings.Join(nodePools, ",")
+}
+
+func k2CmdUpdate(docker bool, action, base, config string, nodePools []string) []string {
+
+	nodePoolNames := strings.Join(nodePools, ",")
 
 	var k2UpdateArg string
 	if action == K2ExtraVarsAddNodePools {
@@ -195,7 +230,7 @@ func K2CmdUpdate(docker bool, action, base, config, name string) []string {
 
 	if docker {
 		cmd := DockerRunK2()
-		cmd = append(cmd, K2Update, K2Config, config, K2Output, base, k2UpdateArg, nodePoolName)
+		cmd = append(cmd, K2Update, K2Config, config, K2Output, base, k2UpdateArg, nodePoolNames)
 		return cmd
 	}
 
@@ -206,7 +241,7 @@ func K2CmdUpdate(docker bool, action, base, config, name string) []string {
 		K2Output,
 		base,
 		k2UpdateArg,
-		nodePoolName,
+		nodePoolNames,
 	}
 }
 
@@ -16,6 +16,8 @@ limitations under the License.
 
 package commands
 
+import "strings"
+
 const (
 	// K2CLI - kraken cli command name
 	K2CLI = "k2cli"
@@ -38,13 +40,29 @@ const (
 // ClusterUpdateAdd - build a command string to call "cluster update --add-nodepools"
 func ClusterUpdateAdd(name string) []string {
 	return []string{
-		K2CLI, K2CLICluster, K2CLIClusterUpdate, K2CLIAddNodePools, name + "Nodes",
+		K2CLI, K2CLICluster, K2CLIClusterUpdate, K2CLIAddNodePools, NodePoolName(name),
+	}
+}
+
+// ClusterUpdateNodePools - build a command string to call "cluster update --update-nodepools"
+func ClusterUpdateNodePools(name string) []string {
+	return []string{
+		K2CLI, K2CLICluster, K2CLIClusterUpdate, K2CLIUpdateNodePools, NodePoolName(name),
 	}
 }
 
 // ClusterUpdateRemove - build a command string to call "cluster update --rm-nodepools"
 func ClusterUpdateRemove(name string) []string {
 	return []string{
-		K2CLI, K2CLICluster, K2CLIClusterUpdate, K2CLIRemoveNodePools, name + "Nodes",
+		K2CLI, K2CLICluster, K2CLIClusterUpdate, K2CLIRemoveNodePools, NodePoolName(name),
+	}
+}
+
+// ClusterUpdate - build a command string to call "cluster update" with the
+// node pool argument, K2CLIAddNodePools, K2CLIUpdateNodePools or
+// K2CLIRemoveNodePools, for the named node pools.
+func ClusterUpdate(arg string, nodePools []string) []string {
+	return []string{
+		K2CLI, K2CLICluster, K2CLIClusterUpdate, arg, strings.Join(nodePools, ","),
 	}
 }
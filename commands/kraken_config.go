@@ -0,0 +1,758 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultContainerConfig - node pool containerConfig anchor
+	DefaultContainerConfig = "defaultDocker"
+	// DefaultOSConfig - node pool osConfig anchor
+	DefaultOSConfig = "defaultCoreOs"
+	// DefaultNodeConfig - node pool nodeConfig anchor
+	DefaultNodeConfig = "defaultAwsClusterNode"
+	// TenantTaintKey - key of the taint that reserves a node pool for a project
+	TenantTaintKey = "customer"
+	// TenantTaintEffect - effect of the taint that reserves a node pool for a
+	// project
+	TenantTaintEffect = "NoSchedule"
+	// TenantLabelKey - key of the node label naming the project of a node
+	// pool, the node selector of the project's namespaces
+	TenantLabelKey = "krak8s.io/project"
+	// NodePoolLabel - key of the node label naming the node pool, the node
+	// selector of the tenant scheduling values
+	NodePoolLabel = "krak8s.io/node-pool"
+)
+
+// nodePoolRefs - the node pool keys that refer to definitions, and the
+// definitions sequence each must refer to an item of
+var nodePoolRefs = []struct{ key, definitions string }{
+	{"kubeConfig", "kubeConfigs"},
+	{"containerConfig", "containerConfigs"},
+	{"osConfig", "osConfigs"},
+	{"nodeConfig", "nodeConfigs"},
+	{"keyPair", "keyPairs"},
+}
+
+// KrakenConfigError - a Kraken configuration that k2 can't apply, with one
+// problem per line item.
+type KrakenConfigError struct {
+	Problems []string
+}
+
+func (e *KrakenConfigError) Error() string {
+	return "invalid kraken configuration: " + strings.Join(e.Problems, "; ")
+}
+
+// KrakenConfig - a Kraken configuration file held as a YAML node tree, edits
+// to the tree keep the file's comments, anchors and aliases.
+type KrakenConfig struct {
+	doc *yaml.Node
+	// the source began with a document start marker, "---"
+	docStart bool
+}
+
+// ParseKrakenConfig - parse a Kraken configuration document.
+func ParseKrakenConfig(data []byte) (*KrakenConfig, error) {
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal(data, doc); err != nil {
+		return nil, err
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.New("kraken configuration is not a YAML map")
+	}
+	return &KrakenConfig{
+		doc:      doc,
+		docStart: bytes.HasPrefix(bytes.TrimSpace(data), []byte("---")),
+	}, nil
+}
+
+// LoadKrakenConfig - read and parse the Kraken configuration file.
+func LoadKrakenConfig(filename string) (*KrakenConfig, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	k, err := ParseKrakenConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", filename, err)
+	}
+	return k, nil
+}
+
+// Bytes - the configuration document as YAML.  Blank lines in the source
+// aren't kept, everything else (comments included) is.
+func (k *KrakenConfig) Bytes() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if k.docStart {
+		buf.WriteString("---\n")
+	}
+	enc := yaml.NewEncoder(buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(k.doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Save - write the configuration file, the file is replaced as a whole so
+// that a failed write can't leave it truncated.
+func (k *KrakenConfig) Save(filename string) error {
+	data, err := k.Bytes()
+	if err != nil {
+		return err
+	}
+	return writeFile(filename, data, os.FileMode(0644))
+}
+
+// NodePoolName - the name of a project's node pool
+func NodePoolName(project string) string {
+	return project + nodePoolNameSuffix
+}
+
+// ProjectNodePoolName - the name of the project's named node pool, the
+// project's node pool, see NodePoolName(), for the empty pool name.
+func ProjectNodePoolName(project, pool string) string {
+	if pool == "" {
+		return NodePoolName(project)
+	}
+	return NodePoolName(project + "-" + pool)
+}
+
+// mapValue - the value of key in the mapping node, nil if there isn't one.
+func mapValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// nodeName - the value of the name key of a sequence item, "" if it has none.
+func nodeName(node *yaml.Node) string {
+	if name := mapValue(node, "name"); name != nil && name.Kind == yaml.ScalarNode {
+		return name.Value
+	}
+	return ""
+}
+
+// nodePools - the sequence node of deployment.clusters[0].nodePools
+func (k *KrakenConfig) nodePools() (*yaml.Node, error) {
+	clusters := mapValue(mapValue(k.doc.Content[0], "deployment"), "clusters")
+	if clusters == nil || clusters.Kind != yaml.SequenceNode || len(clusters.Content) == 0 {
+		return nil, errors.New("kraken configuration has no deployment.clusters")
+	}
+	pools := mapValue(clusters.Content[0], "nodePools")
+	if pools == nil || pools.Kind != yaml.SequenceNode {
+		return nil, errors.New("kraken configuration has no deployment.clusters[0].nodePools")
+	}
+	return pools, nil
+}
+
+// NodePools - the names of the cluster's node pools, in file order.
+func (k *KrakenConfig) NodePools() ([]string, error) {
+	pools, err := k.nodePools()
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, pool := range pools.Content {
+		names = append(names, nodeName(pool))
+	}
+	return names, nil
+}
+
+// ConfigNodePool - a node pool of the configuration, its node count, and the
+// project its tenant taint reserves it for, empty for the cluster's own pools.
+type ConfigNodePool struct {
+	Name    string
+	Count   int
+	Project string
+}
+
+// ConfigNodePools - the cluster's node pools, in file order.
+func (k *KrakenConfig) ConfigNodePools() ([]ConfigNodePool, error) {
+	pools, err := k.nodePools()
+	if err != nil {
+		return nil, err
+	}
+	result := []ConfigNodePool{}
+	for _, pool := range pools.Content {
+		if pool.Kind == yaml.AliasNode {
+			pool = pool.Alias
+		}
+		entry := ConfigNodePool{Name: nodeName(pool), Project: nodePoolProject(pool)}
+		if count := mapValue(pool, "count"); count != nil {
+			entry.Count, _ = strconv.Atoi(count.Value)
+		}
+		result = append(result, entry)
+	}
+	return result, nil
+}
+
+// nodePoolProject - the project the node pool is reserved for by its tenant
+// taint, empty if it isn't reserved
+func nodePoolProject(pool *yaml.Node) string {
+	if pool.Kind == yaml.AliasNode {
+		pool = pool.Alias
+	}
+	taints := mapValue(mapValue(pool, "schedulingConfig"), "taints")
+	if taints == nil || taints.Kind != yaml.SequenceNode {
+		return ""
+	}
+	for _, taint := range taints.Content {
+		if key := mapValue(taint, "key"); key != nil && key.Value == TenantTaintKey {
+			if value := mapValue(taint, "value"); value != nil {
+				return value.Value
+			}
+		}
+	}
+	return ""
+}
+
+// checkNodePoolProject - check that the node pool isn't reserved for another
+// project, whose node pool name is the same as the project's
+func checkNodePoolProject(pool *yaml.Node, project string) error {
+	if owner := nodePoolProject(pool); owner != "" && owner != project {
+		return fmt.Errorf("kraken configuration node pool %s is reserved for project %s", nodeName(pool), owner)
+	}
+	return nil
+}
+
+// NodePoolConflictError - a Kraken configuration that adds or removes node
+// pools of the projects' cluster resources, which only creating or deleting
+// the cluster resources does, with the names of those node pools.
+type NodePoolConflictError struct {
+	Pools []string
+}
+
+func (e *NodePoolConflictError) Error() string {
+	return "node pools " + strings.Join(e.Pools, ", ") + " differ from the cluster resources, create or delete the cluster resources instead"
+}
+
+// ReconcileNodePools - compare the configuration with nodePools, the node
+// counts of the projects' node pools by name: each of them must be in the
+// configuration, and each node pool the configuration reserves for a project
+// must be one of them, otherwise a *NodePoolConflictError names the node
+// pools that aren't.  Returns the node pools whose configuration count
+// differs from nodePools, with the configuration's count.
+func (k *KrakenConfig) ReconcileNodePools(nodePools map[string]int) (map[string]int, error) {
+	pools, err := k.ConfigNodePools()
+	if err != nil {
+		return nil, &KrakenConfigError{Problems: []string{err.Error()}}
+	}
+	resized := make(map[string]int)
+	conflicts := []string{}
+	found := make(map[string]bool)
+	for _, pool := range pools {
+		count, ok := nodePools[pool.Name]
+		if !ok {
+			if pool.Project != "" {
+				conflicts = append(conflicts, pool.Name)
+			}
+			continue
+		}
+		found[pool.Name] = true
+		if pool.Count != count {
+			resized[pool.Name] = pool.Count
+		}
+	}
+	for name := range nodePools {
+		if !found[name] {
+			conflicts = append(conflicts, name)
+		}
+	}
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		return nil, &NodePoolConflictError{Pools: conflicts}
+	}
+	return resized, nil
+}
+
+// CheckTaints - check that none of the extra taints of a node pool has the
+// key of the project's taint, which krak8s sets itself
+func CheckTaints(taints []Taint) error {
+	for _, taint := range taints {
+		if taint.Key == TenantTaintKey {
+			return fmt.Errorf("node pool taint key %s is reserved for the project", TenantTaintKey)
+		}
+	}
+	return nil
+}
+
+// CheckLabels - check that none of the extra labels of a node pool has the
+// key of the project's or the node pool's label, which krak8s sets itself
+func CheckLabels(labels map[string]string) error {
+	for _, key := range []string{TenantLabelKey, NodePoolLabel} {
+		if _, ok := labels[key]; ok {
+			return fmt.Errorf("node pool label %s is reserved for krak8s", key)
+		}
+	}
+	return nil
+}
+
+// findNamed - the index of the item of seq named name, -1 if there isn't one.
+func findNamed(seq *yaml.Node, name string) int {
+	for i, item := range seq.Content {
+		if nodeName(item) == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// anchor - the node that defines the named anchor
+func (k *KrakenConfig) anchor(name string) *yaml.Node {
+	var found *yaml.Node
+	var walk func(node *yaml.Node)
+	walk = func(node *yaml.Node) {
+		if found != nil || node == nil {
+			return
+		}
+		if node.Anchor == name && node.Kind != yaml.AliasNode {
+			found = node
+			return
+		}
+		for _, child := range node.Content {
+			walk(child)
+		}
+	}
+	walk(k.doc)
+	return found
+}
+
+// alias - an alias node referring to the named anchor, which must be defined.
+func (k *KrakenConfig) alias(name string) (*yaml.Node, error) {
+	target := k.anchor(name)
+	if target == nil {
+		return nil, fmt.Errorf("kraken configuration has no anchor &%s", name)
+	}
+	return &yaml.Node{Kind: yaml.AliasNode, Value: name, Alias: target}, nil
+}
+
+func scalar(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+// nodePool - the node pool stanza for the project
+func (k *KrakenConfig) nodePool(config ProjectConfig) (*yaml.Node, error) {
+	anchors := map[string]string{
+		"kubeConfig":      config.KubeConfigName,
+		"containerConfig": config.ContainerConfig,
+		"osConfig":        config.OSConfig,
+		"nodeConfig":      config.NodeConfig,
+		"keyPair":         config.KeyPair,
+	}
+	definitions := mapValue(k.doc.Content[0], "definitions")
+	pool := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	pool.Content = append(pool.Content,
+		scalar("name"), scalar(config.NodePoolName()),
+		scalar("count"), &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(config.NodePoolCount)})
+	for _, ref := range nodePoolRefs {
+		alias, err := k.alias(anchors[ref.key])
+		if err != nil {
+			return nil, err
+		}
+		if !isDefinition(mapValue(definitions, ref.definitions), alias) {
+			return nil, fmt.Errorf("kraken configuration %s %s is not one of definitions.%s",
+				ref.key, anchors[ref.key], ref.definitions)
+		}
+		pool.Content = append(pool.Content, scalar(ref.key), alias)
+	}
+
+	if err := CheckLabels(config.Labels); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(config.Labels))
+	for name := range config.Labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	labels := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	values := map[string]string{TenantLabelKey: config.Name, NodePoolLabel: config.NodePoolName()}
+	for _, name := range append([]string{TenantLabelKey, NodePoolLabel}, names...) {
+		value, ok := values[name]
+		if !ok {
+			value = config.Labels[name]
+		}
+		labels.Content = append(labels.Content, &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{
+			scalar("name"), scalar(name),
+			scalar("value"), scalar(value),
+		}})
+	}
+	pool.Content = append(pool.Content, scalar("labels"), labels)
+
+	if err := CheckTaints(config.Taints); err != nil {
+		return nil, err
+	}
+	taints := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	for _, taint := range append([]Taint{{Key: TenantTaintKey, Value: config.Name, Effect: TenantTaintEffect}}, config.Taints...) {
+		taints.Content = append(taints.Content, &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{
+			scalar("key"), scalar(taint.Key),
+			scalar("value"), scalar(taint.Value),
+			scalar("effect"), scalar(taint.Effect),
+		}})
+	}
+	pool.Content = append(pool.Content, scalar("schedulingConfig"), &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{
+		scalar("taints"), taints,
+	}})
+	return pool, nil
+}
+
+// AddNodePool - append the project's node pool to the cluster's node pools,
+// it is an error if a node pool of the same name already exists.
+func (k *KrakenConfig) AddNodePool(config ProjectConfig) error {
+	pools, err := k.nodePools()
+	if err != nil {
+		return err
+	}
+	name := config.NodePoolName()
+	if findNamed(pools, name) >= 0 {
+		return fmt.Errorf("kraken configuration already has node pool %s", name)
+	}
+	pool, err := k.nodePool(config)
+	if err != nil {
+		return err
+	}
+	pools.Content = append(pools.Content, pool)
+	return nil
+}
+
+// UpdateNodePool - replace the project's node pool, in place, keeping any
+// comments on it.  It is an error if the node pool doesn't exist.
+func (k *KrakenConfig) UpdateNodePool(config ProjectConfig) error {
+	pools, err := k.nodePools()
+	if err != nil {
+		return err
+	}
+	name := config.NodePoolName()
+	i := findNamed(pools, name)
+	if i < 0 {
+		return fmt.Errorf("kraken configuration has no node pool %s", name)
+	}
+	if err := checkNodePoolProject(pools.Content[i], config.Name); err != nil {
+		return err
+	}
+	pool, err := k.nodePool(config)
+	if err != nil {
+		return err
+	}
+	old := pools.Content[i]
+	pool.HeadComment, pool.LineComment, pool.FootComment = old.HeadComment, old.LineComment, old.FootComment
+	pools.Content[i] = pool
+	return nil
+}
+
+// RemoveNodePool - remove the project's named node pool, false if there
+// isn't one.  It is an error if the node pool is reserved for another
+// project.
+func (k *KrakenConfig) RemoveNodePool(project, name string) (bool, error) {
+	pools, err := k.nodePools()
+	if err != nil {
+		return false, err
+	}
+	if i := findNamed(pools, name); i >= 0 {
+		if err := checkNodePoolProject(pools.Content[i], project); err != nil {
+			return false, err
+		}
+	}
+	return removeNamed(pools, name), nil
+}
+
+// clusterHelmConfig - the mapping node of deployment.clusters[0].helmConfig,
+// the definitions.helmConfigs entry it refers to
+func (k *KrakenConfig) clusterHelmConfig() (*yaml.Node, error) {
+	clusters := mapValue(mapValue(k.doc.Content[0], "deployment"), "clusters")
+	if clusters == nil || clusters.Kind != yaml.SequenceNode || len(clusters.Content) == 0 {
+		return nil, errors.New("kraken configuration has no deployment.clusters")
+	}
+	helmConfig := mapValue(clusters.Content[0], "helmConfig")
+	if helmConfig != nil && helmConfig.Kind == yaml.AliasNode {
+		helmConfig = helmConfig.Alias
+	}
+	if helmConfig == nil || helmConfig.Kind != yaml.MappingNode {
+		return nil, errors.New("kraken configuration has no deployment.clusters[0].helmConfig")
+	}
+	return helmConfig, nil
+}
+
+// clusterCharts - the charts sequence node of deployment.clusters[0].helmConfig,
+// added if the helm configuration has none.
+func (k *KrakenConfig) clusterCharts() (*yaml.Node, error) {
+	helmConfig, err := k.clusterHelmConfig()
+	if err != nil {
+		return nil, err
+	}
+	charts := mapValue(helmConfig, "charts")
+	if charts == nil {
+		charts = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		helmConfig.Content = append(helmConfig.Content, scalar("charts"), charts)
+	}
+	if charts.Kind != yaml.SequenceNode {
+		return nil, errors.New("kraken configuration helmConfig charts is not a sequence")
+	}
+	return charts, nil
+}
+
+// chartNamespace - the namespace of a helmConfig chart entry, "" if it has none
+func chartNamespace(chart *yaml.Node) string {
+	if namespace := mapValue(chart, "namespace"); namespace != nil && namespace.Kind == yaml.ScalarNode {
+		return namespace.Value
+	}
+	return ""
+}
+
+// SetChart - append the chart entry to the cluster's helmConfig charts, or
+// replace the chart of the same name, in place, keeping any comments on it.
+// The chart's name is its helm release name, unique to the cluster, so it is
+// an error if the chart of the same name is another namespace's.
+func (k *KrakenConfig) SetChart(chart *yaml.Node) error {
+	charts, err := k.clusterCharts()
+	if err != nil {
+		return err
+	}
+	if i := findNamed(charts, nodeName(chart)); i >= 0 {
+		old := charts.Content[i]
+		if namespace := chartNamespace(old); namespace != chartNamespace(chart) {
+			return fmt.Errorf("kraken configuration chart %s is deployed in namespace %s", nodeName(chart), namespace)
+		}
+		chart.HeadComment, chart.LineComment, chart.FootComment = old.HeadComment, old.LineComment, old.FootComment
+		charts.Content[i] = chart
+		return nil
+	}
+	charts.Content = append(charts.Content, chart)
+	return nil
+}
+
+// Charts - the names of the cluster's helmConfig charts, in file order.
+func (k *KrakenConfig) Charts() ([]string, error) {
+	charts, err := k.clusterCharts()
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, chart := range charts.Content {
+		names = append(names, nodeName(chart))
+	}
+	return names, nil
+}
+
+// RemoveChart - remove the named chart of the namespace, of any namespace if
+// namespace is "", from the cluster's helmConfig charts, false if there isn't
+// one.
+func (k *KrakenConfig) RemoveChart(name, namespace string) bool {
+	helmConfig, err := k.clusterHelmConfig()
+	if err != nil {
+		return false
+	}
+	charts := mapValue(helmConfig, "charts")
+	if charts == nil || charts.Kind != yaml.SequenceNode {
+		return false
+	}
+	i := findNamed(charts, name)
+	if i < 0 || (namespace != "" && chartNamespace(charts.Content[i]) != namespace) {
+		return false
+	}
+	return removeNamed(charts, name)
+}
+
+// removeNamed - remove the item of seq named name.  A comment that precedes
+// the following item, which the parser attaches to the removed item, is kept.
+func removeNamed(seq *yaml.Node, name string) bool {
+	i := findNamed(seq, name)
+	if i < 0 {
+		return false
+	}
+	if foot := seq.Content[i].FootComment; foot != "" {
+		if i+1 < len(seq.Content) {
+			next := seq.Content[i+1]
+			next.HeadComment = joinComments(foot, next.HeadComment)
+		} else {
+			seq.FootComment = joinComments(foot, seq.FootComment)
+		}
+	}
+	seq.Content = append(seq.Content[:i], seq.Content[i+1:]...)
+	return true
+}
+
+func joinComments(a, b string) string {
+	if a == "" {
+		return b
+	}
+	if b == "" {
+		return a
+	}
+	return a + "\n" + b
+}
+
+// Validate - check the configuration before it is handed to k2: every alias
+// resolves, the node pools have unique names, and the kubeConfig and keyPair
+// of each node pool refer to definitions.kubeConfigs and
+// definitions.keyPairs entries.  Returns a *KrakenConfigError.
+func (k *KrakenConfig) Validate() error {
+	problems := []string{}
+	var walk func(node *yaml.Node)
+	walk = func(node *yaml.Node) {
+		if node.Kind == yaml.AliasNode && node.Alias == nil {
+			problems = append(problems, fmt.Sprintf("line %d: alias *%s has no anchor", node.Line, node.Value))
+		}
+		for _, child := range node.Content {
+			walk(child)
+		}
+	}
+	walk(k.doc)
+
+	pools, err := k.nodePools()
+	if err != nil {
+		return &KrakenConfigError{Problems: append(problems, err.Error())}
+	}
+	definitions := mapValue(k.doc.Content[0], "definitions")
+	names := make(map[string]bool)
+	for i, pool := range pools.Content {
+		name := nodeName(pool)
+		if name == "" {
+			problems = append(problems, fmt.Sprintf("node pool %d has no name", i))
+		} else if names[name] {
+			problems = append(problems, fmt.Sprintf("node pool %s is not unique", name))
+		}
+		names[name] = true
+		for _, ref := range nodePoolRefs {
+			value := mapValue(pool, ref.key)
+			if value == nil {
+				continue
+			}
+			if !isDefinition(mapValue(definitions, ref.definitions), value) {
+				problems = append(problems, fmt.Sprintf("node pool %s %s is not one of definitions.%s",
+					name, ref.key, ref.definitions))
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return &KrakenConfigError{Problems: problems}
+	}
+	return nil
+}
+
+// isDefinition - true if value is an alias of an item of the definitions
+// sequence.
+func isDefinition(definitions, value *yaml.Node) bool {
+	if definitions == nil || definitions.Kind != yaml.SequenceNode || value.Kind != yaml.AliasNode {
+		return false
+	}
+	for _, item := range definitions.Content {
+		if item == value.Alias {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateKrakenConfig - load and validate the Kraken configuration file.
+func ValidateKrakenConfig(filename string) error {
+	k, err := LoadKrakenConfig(filename)
+	if err != nil {
+		return &KrakenConfigError{Problems: []string{err.Error()}}
+	}
+	return k.Validate()
+}
+
+// NodePoolChanges - the node pools added, updated and removed between two
+// configurations.
+type NodePoolChanges struct {
+	Added   []string
+	Updated []string
+	Removed []string
+}
+
+// Empty - true if there are no node pool changes
+func (c *NodePoolChanges) Empty() bool {
+	return len(c.Added) == 0 && len(c.Updated) == 0 && len(c.Removed) == 0
+}
+
+// DiffNodePools - the node pool changes from configuration document from to
+// configuration document to.
+func DiffNodePools(from, to []byte) (*NodePoolChanges, error) {
+	fromPools, err := nodePoolDocs(from)
+	if err != nil {
+		return nil, err
+	}
+	toPools, err := nodePoolDocs(to)
+	if err != nil {
+		return nil, err
+	}
+	changes := &NodePoolChanges{}
+	for _, name := range toPools.names {
+		doc, ok := fromPools.docs[name]
+		if !ok {
+			changes.Added = append(changes.Added, name)
+		} else if doc != toPools.docs[name] {
+			changes.Updated = append(changes.Updated, name)
+		}
+	}
+	for _, name := range fromPools.names {
+		if _, ok := toPools.docs[name]; !ok {
+			changes.Removed = append(changes.Removed, name)
+		}
+	}
+	return changes, nil
+}
+
+type poolDocs struct {
+	names []string
+	docs  map[string]string
+}
+
+// nodePoolDocs - the YAML of each node pool, by name, for comparison
+func nodePoolDocs(data []byte) (*poolDocs, error) {
+	k, err := ParseKrakenConfig(data)
+	if err != nil {
+		return nil, err
+	}
+	pools, err := k.nodePools()
+	if err != nil {
+		return nil, err
+	}
+	result := &poolDocs{docs: make(map[string]string)}
+	for _, pool := range pools.Content {
+		doc, err := yaml.Marshal(pool)
+		if err != nil {
+			return nil, err
+		}
+		name := nodeName(pool)
+		result.names = append(result.names, name)
+		result.docs[name] = string(doc)
+	}
+	return result, nil
+}
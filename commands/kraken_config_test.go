@@ -0,0 +1,524 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const testKrakenConfig = `---
+version: v1
+definitions:
+  helmConfigs:
+    - &defaultHelm
+      name: defaultHelm
+      charts:
+        - name: heapster
+          version: 0.1.0-0
+        - name: acme-mongodb
+          version: 1.2.0-0
+  containerConfigs:
+    - &defaultDocker
+      name: defaultDocker
+  osConfigs:
+    - &defaultCoreOs
+      name: defaultCoreOs
+  nodeConfigs:
+    - &defaultAwsClusterNode
+      name: defaultAwsClusterNode
+  kubeConfigs:
+    - &defaultKube
+      name: defaultKube
+  keyPairs:
+    - &defaultKeyPair
+      name: defaultKeyPair
+deployment:
+  clusters:
+    - name: test
+      nodePools:
+        # the masters
+        - name: master
+          count: 3
+          kubeConfig: *defaultKube
+        - name: acmeNodes
+          count: 1
+          kubeConfig: *defaultKube
+        - name: acmeCorpNodes
+          count: 2
+          kubeConfig: *defaultKube
+      helmConfig: *defaultHelm
+`
+
+func TestKrakenConfigReconcileNodePools(t *testing.T) {
+	k, err := ParseKrakenConfig([]byte(testKrakenConfig))
+	if err != nil {
+		t.Fatalf("ParseKrakenConfig() have %v, want nil", err)
+	}
+	if err := k.AddNodePool(NewProjectConfig("beta", 4, "beta-ns")); err != nil {
+		t.Fatalf("AddNodePool(beta) have %v, want nil", err)
+	}
+	pools, err := k.ConfigNodePools()
+	if err != nil || len(pools) != 4 || pools[3] != (ConfigNodePool{Name: "betaNodes", Count: 4, Project: "beta"}) {
+		t.Errorf("ConfigNodePools() have %v, %v, want betaNodes of beta last", pools, err)
+	}
+
+	resized, err := k.ReconcileNodePools(map[string]int{"acmeNodes": 3, "acmeCorpNodes": 2, "betaNodes": 4})
+	if err != nil || !reflect.DeepEqual(resized, map[string]int{"acmeNodes": 1}) {
+		t.Errorf("ReconcileNodePools() have %v, %v, want acmeNodes resized to 1", resized, err)
+	}
+	_, err = k.ReconcileNodePools(map[string]int{"acmeNodes": 3, "gammaNodes": 1})
+	conflicts := []string{}
+	if cerr, ok := err.(*NodePoolConflictError); ok {
+		conflicts = cerr.Pools
+	}
+	if want := []string{"betaNodes", "gammaNodes"}; !reflect.DeepEqual(conflicts, want) {
+		t.Errorf("ReconcileNodePools() have %v, want conflicts %v", err, want)
+	}
+}
+
+func TestKrakenConfigNodePoolProject(t *testing.T) {
+	k, err := ParseKrakenConfig([]byte(testKrakenConfig))
+	if err != nil {
+		t.Fatalf("ParseKrakenConfig() have %v, want nil", err)
+	}
+	cfg := NewProjectConfig("acme", 3, "acme-ns")
+	cfg.Pool = "db"
+	if err := k.AddNodePool(cfg); err != nil {
+		t.Fatalf("AddNodePool(acme db) have %v, want nil", err)
+	}
+
+	// project acme-db's default node pool has the name of acme's db node pool
+	other := NewProjectConfig("acme-db", 5, "acme-db-ns")
+	if other.NodePoolName() != cfg.NodePoolName() {
+		t.Fatalf("NodePoolName() have %s, want %s", other.NodePoolName(), cfg.NodePoolName())
+	}
+	if err := k.AddNodePool(other); err == nil {
+		t.Errorf("AddNodePool(acme-db) have nil, want error")
+	}
+	if err := k.UpdateNodePool(other); err == nil {
+		t.Errorf("UpdateNodePool(acme-db) have nil, want error")
+	}
+	if removed, err := k.RemoveNodePool(other.Name, other.NodePoolName()); removed || err == nil {
+		t.Errorf("RemoveNodePool(acme-db) have %v, %v, want false, error", removed, err)
+	}
+	pools, _ := k.ConfigNodePools()
+	if pool := pools[len(pools)-1]; pool.Name != cfg.NodePoolName() || pool.Count != 3 || pool.Project != "acme" {
+		t.Errorf("ConfigNodePools() have %+v, want acme's node pool", pool)
+	}
+	if removed, err := k.RemoveNodePool(cfg.Name, cfg.NodePoolName()); !removed || err != nil {
+		t.Errorf("RemoveNodePool(acme db) have %v, %v, want true, nil", removed, err)
+	}
+}
+
+func TestKrakenConfigNodePools(t *testing.T) {
+	k, err := ParseKrakenConfig([]byte(testKrakenConfig))
+	if err != nil {
+		t.Fatalf("ParseKrakenConfig() have %v, want nil", err)
+	}
+
+	// acme is a substring of acmeCorp, only the exact name is removed
+	if removed, err := k.RemoveNodePool("acme", NodePoolName("acme")); !removed || err != nil {
+		t.Errorf("RemoveNodePool(acmeNodes) have %v, %v, want true, nil", removed, err)
+	}
+	if !k.RemoveChart("acme-mongodb", "") {
+		t.Errorf("RemoveChart(acme-mongodb) have false, want true")
+	}
+	if removed, _ := k.RemoveNodePool("acme", NodePoolName("acme")); removed {
+		t.Errorf("RemoveNodePool(acmeNodes) again have true, want false")
+	}
+
+	cfg := NewProjectConfig("beta", 4, "beta-ns")
+	if err := k.AddNodePool(cfg); err != nil {
+		t.Fatalf("AddNodePool(beta) have %v, want nil", err)
+	}
+	if err := k.AddNodePool(cfg); err == nil {
+		t.Errorf("AddNodePool(beta) again have nil, want error")
+	}
+	cfg.NodePoolCount = 6
+	if err := k.UpdateNodePool(cfg); err != nil {
+		t.Errorf("UpdateNodePool(beta) have %v, want nil", err)
+	}
+	if err := k.UpdateNodePool(NewProjectConfig("gamma", 1, "gamma-ns")); err == nil {
+		t.Errorf("UpdateNodePool(gamma) have nil, want error")
+	}
+	cfg = NewProjectConfig("delta", 1, "delta-ns")
+	cfg.KeyPair = "missingKeyPair"
+	if err := k.AddNodePool(cfg); err == nil {
+		t.Errorf("AddNodePool(missing anchor) have nil, want error")
+	}
+
+	names, _ := k.NodePools()
+	if want := []string{"master", "acmeCorpNodes", "betaNodes"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("NodePools() have %v, want %v", names, want)
+	}
+
+	data, err := k.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes() have %v, want nil", err)
+	}
+	out := string(data)
+	for _, want := range []string{
+		"---\n",
+		"# the masters",
+		"- &defaultDocker",
+		"helmConfig: *defaultHelm",
+		"containerConfig: *defaultDocker",
+		"osConfig: *defaultCoreOs",
+		"keyPair: *defaultKeyPair",
+		"count: 6",
+		"value: beta",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Bytes() have\n%s\nwant it to contain %q", out, want)
+		}
+	}
+	if strings.Contains(out, "acme-mongodb") || strings.Contains(out, "name: acmeNodes") {
+		t.Errorf("Bytes() have\n%s\nwant acme removed", out)
+	}
+
+	// the output resolves the new node pool's aliases
+	var config struct {
+		Deployment struct {
+			Clusters []struct {
+				NodePools []map[string]interface{} `yaml:"nodePools"`
+			}
+		}
+	}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		t.Fatalf("yaml.Unmarshal(Bytes()) have %v, want nil", err)
+	}
+	pool := config.Deployment.Clusters[0].NodePools[2]
+	if want := map[string]interface{}{"name": "defaultCoreOs"}; !reflect.DeepEqual(pool["osConfig"], want) {
+		t.Errorf("betaNodes osConfig have %v, want %v", pool["osConfig"], want)
+	}
+}
+
+func TestKrakenConfigNodePoolSpec(t *testing.T) {
+	k, err := ParseKrakenConfig([]byte(testKrakenConfig))
+	if err != nil {
+		t.Fatalf("ParseKrakenConfig() have %v, want nil", err)
+	}
+
+	cfg := NewProjectConfig("gpu", 3, "gpu-ns")
+	cfg.NodeConfig = "defaultKube"
+	if err := k.AddNodePool(cfg); err == nil {
+		t.Errorf("AddNodePool(nodeConfig defaultKube) have nil, want error")
+	}
+	cfg.NodeConfig = DefaultNodeConfig
+	cfg.Taints = []Taint{{Key: TenantTaintKey, Value: "acme", Effect: "NoSchedule"}}
+	if err := k.AddNodePool(cfg); err == nil {
+		t.Errorf("AddNodePool(customer taint) have nil, want error")
+	}
+	// the project's own taint is set by krak8s, not the payload
+	cfg.Taints = []Taint{{Key: TenantTaintKey, Value: "gpu", Effect: "NoExecute"}}
+	if err := k.AddNodePool(cfg); err == nil {
+		t.Errorf("AddNodePool(own customer taint) have nil, want error")
+	}
+	cfg.Taints = []Taint{{Key: "gpu", Value: "true", Effect: "NoExecute"}}
+	cfg.Labels = map[string]string{TenantLabelKey: "acme"}
+	if err := k.AddNodePool(cfg); err == nil {
+		t.Errorf("AddNodePool(project label) have nil, want error")
+	}
+	cfg.Labels = map[string]string{NodePoolLabel: "acmeNodes"}
+	if err := k.AddNodePool(cfg); err == nil {
+		t.Errorf("AddNodePool(node pool label) have nil, want error")
+	}
+	cfg.Labels = map[string]string{"zone": "b", "accelerator": "gpu"}
+	if err := k.AddNodePool(cfg); err != nil {
+		t.Fatalf("AddNodePool(gpu) have %v, want nil", err)
+	}
+	if err := k.Validate(); err != nil {
+		t.Errorf("Validate() have %v, want nil", err)
+	}
+
+	data, err := k.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes() have %v, want nil", err)
+	}
+	var config struct {
+		Deployment struct {
+			Clusters []struct {
+				NodePools []struct {
+					Name   string
+					Labels []map[string]string
+					Config struct {
+						Taints []map[string]string
+					} `yaml:"schedulingConfig"`
+				} `yaml:"nodePools"`
+			}
+		}
+	}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		t.Fatalf("yaml.Unmarshal(Bytes()) have %v, want nil", err)
+	}
+	pool := config.Deployment.Clusters[0].NodePools[3]
+	labels := []map[string]string{
+		{"name": TenantLabelKey, "value": "gpu"},
+		{"name": NodePoolLabel, "value": "gpuNodes"},
+		{"name": "accelerator", "value": "gpu"},
+		{"name": "zone", "value": "b"},
+	}
+	if !reflect.DeepEqual(pool.Labels, labels) {
+		t.Errorf("gpuNodes labels have %v, want %v", pool.Labels, labels)
+	}
+	taints := []map[string]string{
+		{"key": TenantTaintKey, "value": "gpu", "effect": "NoSchedule"},
+		{"key": "gpu", "value": "true", "effect": "NoExecute"},
+	}
+	if !reflect.DeepEqual(pool.Config.Taints, taints) {
+		t.Errorf("gpuNodes taints have %v, want %v", pool.Config.Taints, taints)
+	}
+}
+
+func TestAddDeleteProject(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "kraken")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filename := path.Join(dir, DefaultConfigFile)
+	if err := ioutil.WriteFile(filename, []byte(testKrakenConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := NewProjectConfig("acme", 2, "acme-ns")
+	if err := AddProjectTemplate(cfg, filename); err == nil {
+		t.Errorf("AddProjectTemplate(acme) have nil, want duplicate error")
+	}
+	if err := DeleteProject(cfg, filename); err != nil {
+		t.Fatalf("DeleteProject(acme) have %v, want nil", err)
+	}
+	if err := AddProjectTemplate(cfg, filename); err != nil {
+		t.Fatalf("AddProjectTemplate(acme) have %v, want nil", err)
+	}
+	k, err := LoadKrakenConfig(filename)
+	if err != nil {
+		t.Fatalf("LoadKrakenConfig() have %v, want nil", err)
+	}
+	names, _ := k.NodePools()
+	if want := []string{"master", "acmeCorpNodes", "acmeNodes"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("NodePools() have %v, want %v", names, want)
+	}
+
+	// a named node pool of the project, removing it keeps the project's services
+	if err := ioutil.WriteFile(filename, []byte(testKrakenConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg.Pool = "db"
+	if err := AddProjectTemplate(cfg, filename); err != nil {
+		t.Fatalf("AddProjectTemplate(acme db) have %v, want nil", err)
+	}
+	if err := DeleteProject(cfg, filename); err != nil {
+		t.Fatalf("DeleteProject(acme db) have %v, want nil", err)
+	}
+	data, _ := ioutil.ReadFile(filename)
+	if !strings.Contains(string(data), "acme-mongodb") {
+		t.Errorf("DeleteProject(acme db) have\n%s\nwant acme-mongodb kept", data)
+	}
+	if err := AddProjectTemplate(cfg, filename); err != nil {
+		t.Fatalf("AddProjectTemplate(acme db) have %v, want nil", err)
+	}
+	k, _ = LoadKrakenConfig(filename)
+	names, _ = k.NodePools()
+	if want := []string{"master", "acmeNodes", "acmeCorpNodes", "acme-dbNodes"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("NodePools() have %v, want %v", names, want)
+	}
+}
+
+func TestAddRemoveServiceChart(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "kraken")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filename := path.Join(dir, DefaultConfigFile)
+	if err := ioutil.WriteFile(filename, []byte(testKrakenConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	chart := ServiceChart{
+		Name:      "acme-web",
+		Registry:  "quay.io",
+		Chart:     "samsung_cnct/nginx",
+		Version:   "0.1.0",
+		Namespace: "acme-ns",
+		Values:    map[string]interface{}{"replicas": 2},
+		Project:   "acme",
+	}
+	if err := AddServiceChart(chart, filename); err != nil {
+		t.Fatalf("AddServiceChart(acme-web) have %v, want nil", err)
+	}
+	chart.Version = "0.2.0"
+	if err := AddServiceChart(chart, filename); err != nil {
+		t.Fatalf("AddServiceChart(acme-web) again have %v, want nil", err)
+	}
+	k, err := LoadKrakenConfig(filename)
+	if err != nil {
+		t.Fatalf("LoadKrakenConfig() have %v, want nil", err)
+	}
+	names, _ := k.Charts()
+	if want := []string{"heapster", "acme-mongodb", "acme-web"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Charts() have %v, want %v", names, want)
+	}
+	data, _ := ioutil.ReadFile(filename)
+	for _, want := range []string{"chart: samsung_cnct/nginx", "version: '0.2.0'", "namespace: acme-ns", "replicas: 2"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("AddServiceChart(acme-web) have\n%s\nwant %q", data, want)
+		}
+	}
+	revs, _ := ConfigRevisions(filename)
+	if len(revs) != 2 || revs[0].Operation != ConfigOpAddChart || revs[0].Chart != "acme-web" {
+		t.Errorf("ConfigRevisions() have %+v, want two add-chart acme-web revisions", revs)
+	}
+
+	// another project's application of the same release name
+	other := chart
+	other.Namespace, other.Project = "beta-ns", "beta"
+	if err := AddServiceChart(other, filename); err == nil {
+		t.Errorf("AddServiceChart(beta acme-web) have nil, want error")
+	}
+	if err := RemoveServiceChart(other, filename); err != nil {
+		t.Fatalf("RemoveServiceChart(beta acme-web) have %v, want nil", err)
+	}
+	k, _ = LoadKrakenConfig(filename)
+	if names, _ = k.Charts(); len(names) != 3 {
+		t.Errorf("Charts() have %v, want acme-web kept", names)
+	}
+
+	if err := RemoveServiceChart(chart, filename); err != nil {
+		t.Fatalf("RemoveServiceChart(acme-web) have %v, want nil", err)
+	}
+	k, _ = LoadKrakenConfig(filename)
+	names, _ = k.Charts()
+	if want := []string{"heapster", "acme-mongodb"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Charts() have %v, want %v", names, want)
+	}
+
+	// payload strings are values, not YAML
+	chart.Name = "evil"
+	chart.Version = "1.0\n  namespace: kube-system"
+	if err := AddServiceChart(chart, filename); err != nil {
+		t.Fatalf("AddServiceChart(evil) have %v, want nil", err)
+	}
+	var config struct {
+		Definitions struct {
+			HelmConfigs []struct {
+				Charts []map[string]interface{}
+			} `yaml:"helmConfigs"`
+		}
+	}
+	data, _ = ioutil.ReadFile(filename)
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		t.Fatalf("yaml.Unmarshal() have %v, want nil", err)
+	}
+	charts := config.Definitions.HelmConfigs[0].Charts
+	if evil := charts[len(charts)-1]; evil["version"] != chart.Version || evil["namespace"] != "acme-ns" {
+		t.Errorf("AddServiceChart(evil) have %v, want version %q in acme-ns", evil, chart.Version)
+	}
+}
+
+func TestKrakenConfigValidate(t *testing.T) {
+	k, err := ParseKrakenConfig([]byte(testKrakenConfig))
+	if err != nil {
+		t.Fatalf("ParseKrakenConfig() have %v, want nil", err)
+	}
+	if err := k.Validate(); err != nil {
+		t.Errorf("Validate() have %v, want nil", err)
+	}
+
+	// a duplicate node pool, and a keyPair that isn't a keyPairs definition
+	invalid := testKrakenConfig + `        - name: master
+          count: 1
+          keyPair: *defaultKube
+`
+	k, err = ParseKrakenConfig([]byte(strings.Replace(invalid, "      helmConfig: *defaultHelm\n", "", 1)))
+	if err != nil {
+		t.Fatalf("ParseKrakenConfig(invalid) have %v, want nil", err)
+	}
+	err = k.Validate()
+	configErr, ok := err.(*KrakenConfigError)
+	if !ok || len(configErr.Problems) != 2 {
+		t.Errorf("Validate(invalid) have %v, want *KrakenConfigError with 2 problems", err)
+	}
+
+	dir, err := ioutil.TempDir(os.TempDir(), "kraken")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filename := path.Join(dir, DefaultConfigFile)
+	ioutil.WriteFile(filename, []byte("deployment: *missing\n"), 0644)
+	err = ValidateKrakenConfig(filename)
+	if _, ok := err.(*KrakenConfigError); !ok {
+		t.Errorf("ValidateKrakenConfig(unknown alias) have %v, want *KrakenConfigError", err)
+	}
+}
+
+func TestPlanProject(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "kraken")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filename := path.Join(dir, DefaultConfigFile)
+	if err := ioutil.WriteFile(filename, []byte(testKrakenConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	diff, err := PlanUpdateProject(NewProjectConfig("acme", 5, "acme-ns"), filename)
+	if err != nil {
+		t.Fatalf("PlanUpdateProject(acme) have %v, want nil", err)
+	}
+	for _, want := range []string{"-          count: 1\n", "+          count: 5\n", "+          keyPair: *defaultKeyPair\n"} {
+		if !strings.Contains(diff, want) {
+			t.Errorf("PlanUpdateProject(acme) have\n%s\nwant it to contain %q", diff, want)
+		}
+	}
+	if _, err := PlanAddProject(NewProjectConfig("acme", 3, "acme-ns"), filename); err == nil {
+		t.Errorf("PlanAddProject(acme) have nil, want duplicate error")
+	}
+	if diff, err := PlanDeleteProject(NewProjectConfig("acme", 3, "acme-ns"), filename); err != nil || !strings.Contains(diff, "-        - name: acmeNodes\n") {
+		t.Errorf("PlanDeleteProject(acme) have %q, %v, want acmeNodes removed", diff, err)
+	}
+
+	cfg := NewProjectConfig("beta", 2, "beta-ns")
+	if err := CheckProjectDefinitions(cfg, filename); err != nil {
+		t.Errorf("CheckProjectDefinitions(beta) have %v, want nil", err)
+	}
+	cfg.NodeConfig = "defaultKube"
+	if err := CheckProjectDefinitions(cfg, filename); err == nil {
+		t.Errorf("CheckProjectDefinitions(nodeConfig defaultKube) have nil, want error")
+	}
+	cfg.NodeConfig = "missingNode"
+	if err := CheckProjectDefinitions(cfg, filename); err == nil {
+		t.Errorf("CheckProjectDefinitions(nodeConfig missingNode) have nil, want error")
+	}
+
+	// planning doesn't change, or back up, the file
+	data, _ := ioutil.ReadFile(filename)
+	files, _ := ioutil.ReadDir(dir)
+	if string(data) != testKrakenConfig || len(files) != 1 {
+		t.Errorf("PlanUpdateProject() changed the configuration directory")
+	}
+}
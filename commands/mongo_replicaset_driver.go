@@ -31,13 +31,13 @@ const (
   affinity:
     node:
       labels:
-        - key: nodepool
+        - key: ` + NodePoolLabel + `
           operator: In
           values: [ "{{ .CustomerName }}Nodes" ]
   tolerations:
-    - key: customer
+    - key: ` + TenantTaintKey + `
       value: {{ .CustomerName }}
-      effect: NoSchedule
+      effect: ` + TenantTaintEffect + `
 resources:
   limits:
     cpu: 200m
@@ -56,6 +56,11 @@ type MongoReplicasetDriver struct {
 	CustomerName string
 
 	Template string
+
+	// KubeConfig and KubeContext select the cluster the chart is deployed
+	// on, helm's defaults are used when they are empty.
+	KubeConfig  string
+	KubeContext string
 }
 
 // Install - upgrade the mongo replicaset chart.
@@ -125,5 +130,5 @@ func (m MongoReplicasetDriver) Remove() ([]byte, error) {
 }
 
 func (m MongoReplicasetDriver) execute(arguments []string) ([]byte, error) {
-	return Execute("helm", arguments)
+	return Execute("helm", append(arguments, helmKubeArgs(m.KubeConfig, m.KubeContext)...))
 }
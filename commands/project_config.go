@@ -17,18 +17,14 @@ limitations under the License.
 package commands
 
 import (
-	"bytes"
-	"errors"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
-	"strconv"
-	"strings"
-	"text/template"
-	"time"
 
 	"github.com/golang/glog"
+	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -43,14 +39,8 @@ const (
 )
 
 const (
-	serviceTmplName    = "services.tmpl"
-	serviceTmplLines   = 5
 	serviceNameSuffix  = "-mongodb"
-	servicesMarker     = "# |--> SERVICES_MARKER <--|"
-	nodePoolTmplName   = "node_pool.tmpl"
-	nodePoolTmplLines  = 12
 	nodePoolNameSuffix = "Nodes"
-	nodePoolMarker     = "# |--> NODE_POOL_MARKER <--|"
 )
 
 // ProjectConfig describes the cluster resource configuration for a project
@@ -60,19 +50,47 @@ type ProjectConfig struct {
 	KubeConfigName string
 	KeyPair        string
 	Namespace      string
+	// Pool - the name of the node pool within the project, empty for the
+	// project's default node pool
+	Pool            string
+	NodeConfig      string
+	OSConfig        string
+	ContainerConfig string
+	// Labels - extra labels of the node pool's nodes
+	Labels map[string]string
+	// Taints - extra taints of the node pool's nodes, after the tenant taint
+	Taints []Taint
+	// RequestID - the request making the change, recorded in the
+	// configuration's git commit, see SetConfigGit()
+	RequestID string
 }
 
 // NewProjectConfig creates an configuration record with default values.
 func NewProjectConfig(name string, count int, ns string) ProjectConfig {
 	return ProjectConfig{
-		Name:           name,
-		NodePoolCount:  count,
-		Namespace:      ns,
-		KeyPair:        DefaultKeyPair,
-		KubeConfigName: DefaultKubeConfig,
+		Name:            name,
+		NodePoolCount:   count,
+		Namespace:       ns,
+		KeyPair:         DefaultKeyPair,
+		KubeConfigName:  DefaultKubeConfig,
+		NodeConfig:      DefaultNodeConfig,
+		OSConfig:        DefaultOSConfig,
+		ContainerConfig: DefaultContainerConfig,
 	}
 }
 
+// NodePoolName - the Kraken node pool name of the configuration
+func (config ProjectConfig) NodePoolName() string {
+	return ProjectNodePoolName(config.Name, config.Pool)
+}
+
+// Taint - a node taint
+type Taint struct {
+	Key    string `json:"key"`
+	Value  string `json:"value,omitempty"`
+	Effect string `json:"effect"`
+}
+
 func copyFile(dst, src string, perm os.FileMode) error {
 	in, err := os.Open(src)
 	if err != nil {
@@ -100,144 +118,248 @@ func copyFile(dst, src string, perm os.FileMode) error {
 	return os.Rename(tmp.Name(), dst)
 }
 
-func copyConfigFileBackup(path string) error {
-	src := path
-	dest := path + "." + strconv.FormatInt(time.Now().Unix(), 10)
-	if _, err := os.Stat(src); os.IsNotExist(err) {
+// writeFile - write the file as a whole, by replacing it, so that a failed
+// write can't leave it truncated.
+func writeFile(filename string, data []byte, perm os.FileMode) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(filename), "")
+	if err != nil {
 		return err
 	}
-	if err := copyFile(dest, src, os.FileMode(0644)); err != nil {
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
 		return err
 	}
-	return nil
-}
-
-func templateNodes(config ProjectConfig) (string, error) {
-	tmpl, err := template.New(nodePoolTmplName).ParseFiles(nodePoolTmplName)
-	if err != nil {
-		glog.Warningf("failed to parse node pool template file: %v", err)
-		return "", err
+	if err = tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
 	}
-	buf := new(bytes.Buffer)
-	if err := tmpl.Execute(buf, config); err != nil {
-		glog.Warningf("failed to execute node pool template %v", err)
-		return "", err
+	if err = os.Chmod(tmp.Name(), perm); err != nil {
+		os.Remove(tmp.Name())
+		return err
 	}
-	return buf.String(), nil
+	return os.Rename(tmp.Name(), filename)
 }
 
-func templateServices(config ProjectConfig) (string, error) {
-	tmpl, err := template.New(serviceTmplName).ParseFiles(serviceTmplName)
-	if err != nil {
-		glog.Warningf("failed to parse services template file: %v", err)
-		return "", err
-	}
-	buf := new(bytes.Buffer)
-	if err := tmpl.Execute(buf, config); err != nil {
-		glog.Warningf("failed to execute services template %v", err)
-		return "", err
+// ServiceChart - an application chart that k2 deploys, an entry of the
+// cluster's helmConfig charts, see serviceChartEntry().
+type ServiceChart struct {
+	// Name - the release name
+	Name      string
+	Registry  string
+	Chart     string
+	Version   string
+	Namespace string
+	Values    map[string]interface{}
+	// Project and RequestID are recorded in the configuration revision
+	Project   string
+	RequestID string
+}
+
+// serviceChartEntry - the chart's helmConfig charts entry, its fields string
+// scalars whatever they contain
+func serviceChartEntry(chart ServiceChart) (*yaml.Node, error) {
+	version := scalar(chart.Version)
+	version.Style = yaml.SingleQuotedStyle
+	entry := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{
+		scalar("name"), scalar(chart.Name),
+		scalar("registry"), scalar(chart.Registry),
+		scalar("chart"), scalar(chart.Chart),
+		scalar("version"), version,
+		scalar("namespace"), scalar(chart.Namespace),
+	}}
+	if len(chart.Values) > 0 {
+		values := &yaml.Node{}
+		if err := values.Encode(chart.Values); err != nil {
+			return nil, err
+		}
+		entry.Content = append(entry.Content, scalar("values"), values)
 	}
-	return buf.String(), nil
+	return entry, nil
 }
 
 // AddProjectTemplate - copies the configuration file, which *MUST* be the
-// most current up to date configuration file, and then inserts a node pool
-// and service stanza in to the configuration file for the project.
+// most current up to date configuration file, and then adds a node pool for
+// the project to the configuration file.
 func AddProjectTemplate(config ProjectConfig, filename string) error {
+	return editProjectConfig(filename, ConfigOpAdd, config, addProjectEdit(config))
+}
 
-	nodeConfig, err := templateNodes(config)
-	if err != nil {
-		glog.Warning("failed to template nodePool config stanza")
-		return err
-	}
+// UpdateProject - copies the configuration file, which *MUST* be the most
+// current up to date configuration file, and then replaces the project's
+// node pool in the configuration file.
+func UpdateProject(config ProjectConfig, filename string) error {
+	return editProjectConfig(filename, ConfigOpUpdate, config, updateProjectEdit(config))
+}
 
-	/* svcConfig, err := templateServices(config)
-	if err != nil {
-		glog.Warning("failed to template services config stanza")
-		return err
-	}*/
+// DeleteProject - copies the configuration file, which *MUST* be the most
+// current up to date configuration file, and then removes the project's node
+// pool and service chart from the configuration file.
+func DeleteProject(config ProjectConfig, filename string) error {
+	return editProjectConfig(filename, ConfigOpDelete, config, deleteProjectEdit(config))
+}
 
-	err = copyConfigFileBackup(filename)
+// PlanAddProject - the diff of the configuration file that
+// AddProjectTemplate() would make, the file isn't changed.
+func PlanAddProject(config ProjectConfig, filename string) (string, error) {
+	return planProjectConfig(filename, addProjectEdit(config))
+}
+
+// PlanUpdateProject - the diff of the configuration file that
+// UpdateProject() would make, the file isn't changed.
+func PlanUpdateProject(config ProjectConfig, filename string) (string, error) {
+	return planProjectConfig(filename, updateProjectEdit(config))
+}
+
+// PlanDeleteProject - the diff of the configuration file that
+// DeleteProject() would make, the file isn't changed.
+func PlanDeleteProject(config ProjectConfig, filename string) (string, error) {
+	return planProjectConfig(filename, deleteProjectEdit(config))
+}
+
+// CheckProjectDefinitions - check that the node pool AddProjectTemplate()
+// would add for the project refers to entries of the configuration file's
+// definitions, the file isn't changed.
+func CheckProjectDefinitions(config ProjectConfig, filename string) error {
+	data, err := ioutil.ReadFile(filename)
 	if err != nil {
-		glog.Warningf("failed to make backup copy of config file, error: %v", err)
 		return err
 	}
-
-	configFileData, err := ioutil.ReadFile(filename)
+	k, err := ParseKrakenConfig(data)
 	if err != nil {
-		glog.Warning("unable to open config file")
-		return err
+		return fmt.Errorf("%s: %v", filename, err)
 	}
+	_, err = k.nodePool(config)
+	return err
+}
 
-	configFileLines := strings.Split(string(configFileData), "\n")
+// AddServiceChart - copies the configuration file, which *MUST* be the most
+// current up to date configuration file, and then adds the chart to the
+// cluster's helmConfig charts, replacing a chart of the same name, for k2 to
+// deploy.
+func AddServiceChart(chart ServiceChart, filename string) error {
+	rev := ConfigRevision{Operation: ConfigOpAddChart, Project: chart.Project, Chart: chart.Name}
+	return editConfig(filename, rev, chart.RequestID, func(k *KrakenConfig) error {
+		entry, err := serviceChartEntry(chart)
+		if err != nil {
+			return err
+		}
+		return k.SetChart(entry)
+	})
+}
 
-	var outputFileLines []string
-	for _, line := range configFileLines {
-		if strings.Contains(line, config.Name) || strings.Contains(line, config.Namespace) {
-			glog.Infof("Configuration file already contains the Project Name: %s, or Namespace: %s",
-				config.Name, config.Name)
-			return errors.New("Can't add potentially duplicate value to configuration file")
+// RemoveServiceChart - copies the configuration file, which *MUST* be the
+// most current up to date configuration file, and then removes the chart of
+// the chart's namespace from the cluster's helmConfig charts.
+func RemoveServiceChart(chart ServiceChart, filename string) error {
+	rev := ConfigRevision{Operation: ConfigOpRemoveChart, Project: chart.Project, Chart: chart.Name}
+	return editConfig(filename, rev, chart.RequestID, func(k *KrakenConfig) error {
+		if !k.RemoveChart(chart.Name, chart.Namespace) {
+			glog.Infof("configuration file has no chart %s", chart.Name)
 		}
-		outputFileLines = append(outputFileLines, line)
-		if strings.Contains(line, nodePoolMarker) {
-			outputFileLines = append(outputFileLines, nodeConfig)
-		} /* else if strings.Contains(line, servicesMarker) {
-			outputFileLines = append(outputFileLines, svcConfig)
-		} */
-	}
+		return nil
+	})
+}
 
-	outputFileData := strings.Join(outputFileLines, "\n")
-	err = ioutil.WriteFile(filename, []byte(outputFileData), 0644)
-	if err != nil {
-		glog.Warning("failed writing out new version of config file")
-		return err
+func addProjectEdit(config ProjectConfig) func(*KrakenConfig) error {
+	return func(k *KrakenConfig) error {
+		return k.AddNodePool(config)
 	}
+}
 
-	return nil
+func updateProjectEdit(config ProjectConfig) func(*KrakenConfig) error {
+	return func(k *KrakenConfig) error {
+		return k.UpdateNodePool(config)
+	}
 }
 
-// DeleteProject - copies the configuration file, which *MUST* be the most
-// current up to date configuration file, and then searches for and removes
-// a node pool and service stanza from the configuration file for the project.
-func DeleteProject(config ProjectConfig, filename string) error {
+func deleteProjectEdit(config ProjectConfig) func(*KrakenConfig) error {
+	return func(k *KrakenConfig) error {
+		removed, err := k.RemoveNodePool(config.Name, config.NodePoolName())
+		if err != nil {
+			return err
+		}
+		if !removed {
+			glog.Infof("configuration file has no node pool %s", config.NodePoolName())
+		}
+		// the project's services go with its default node pool
+		if config.Pool == "" {
+			k.RemoveChart(config.Name+serviceNameSuffix, "")
+		}
+		return nil
+	}
+}
 
-	err := copyConfigFileBackup(filename)
+// planProjectConfig - apply edit to the configuration file in memory, and
+// return the unified diff from the file to the result.  The result must
+// validate, as for editProjectConfig().
+func planProjectConfig(filename string, edit func(*KrakenConfig) error) (string, error) {
+	data, err := ioutil.ReadFile(filename)
 	if err != nil {
-		glog.Warning("failed to make backup copy of config file")
-		return err
+		return "", err
 	}
-
-	configFileData, err := ioutil.ReadFile(filename)
+	k, err := ParseKrakenConfig(data)
 	if err != nil {
-		glog.Warning("unable to open config file")
-		return err
+		return "", fmt.Errorf("%s: %v", filename, err)
+	}
+	if err = edit(k); err != nil {
+		return "", err
+	}
+	edited, err := k.Bytes()
+	if err != nil {
+		return "", err
+	}
+	// validate the edited file as it would be written
+	result, err := ParseKrakenConfig(edited)
+	if err != nil {
+		return "", &KrakenConfigError{Problems: []string{err.Error()}}
+	}
+	if err = result.Validate(); err != nil {
+		return "", err
 	}
+	return UnifiedDiff(filename, filename, data, edited), nil
+}
 
-	configFileLines := strings.Split(string(configFileData), "\n")
+// editProjectConfig - load the configuration file, apply edit and write the
+// result, after first making a backup copy of the file, a revision replaced by
+// the project operation, see copyConfigFileBackup().  The file is left
+// unchanged if the edit fails, and is restored from the backup if the result
+// doesn't validate, see KrakenConfig.Validate().  The validated result is
+// committed to git, if enabled, see SetConfigGit().
+func editProjectConfig(filename, operation string, config ProjectConfig, edit func(*KrakenConfig) error) error {
+	rev := ConfigRevision{Operation: operation, Project: config.Name, NodePool: config.NodePoolName()}
+	return editConfig(filename, rev, config.RequestID, edit)
+}
 
-	skip := 0
-	var outputFileLines []string
-	for _, line := range configFileLines {
-		if strings.Contains(line, config.Name) {
-			if strings.Contains(line, config.Name+nodePoolNameSuffix) {
-				skip = nodePoolTmplLines
-			} else if strings.Contains(line, config.Name+serviceNameSuffix) {
-				skip = serviceTmplLines
-			}
-		}
-		if skip > 0 {
-			skip--
-			continue
-		}
-		outputFileLines = append(outputFileLines, line)
+// editConfig - editProjectConfig() for the revision rev of any operation.
+func editConfig(filename string, rev ConfigRevision, requestID string, edit func(*KrakenConfig) error) error {
+	k, err := LoadKrakenConfig(filename)
+	if err != nil {
+		glog.Warningf("unable to load config file: %v", err)
+		return err
 	}
-
-	outputFileData := strings.Join(outputFileLines, "\n")
-	err = ioutil.WriteFile(filename, []byte(outputFileData), 0644)
+	if err = edit(k); err != nil {
+		glog.Warningf("unable to update config file: %v", err)
+		return err
+	}
+	backup, err := copyConfigFileBackup(filename, rev)
 	if err != nil {
+		glog.Warningf("failed to make backup copy of config file, error: %v", err)
+		return err
+	}
+	if err = k.Save(filename); err != nil {
 		glog.Warning("failed writing out new version of config file")
 		return err
 	}
-
+	if err = ValidateKrakenConfig(filename); err != nil {
+		glog.Warningf("new version of config file failed validation, restoring %s: %v", backup, err)
+		if restoreErr := copyFile(filename, backup, os.FileMode(0644)); restoreErr != nil {
+			glog.Errorf("failed to restore config file from %s: %v", backup, restoreErr)
+		}
+		return err
+	}
+	if err = commitConfigChange(filename, rev, requestID); err != nil {
+		glog.Warningf("unable to commit config file change to git: %v", err)
+	}
 	return nil
 }
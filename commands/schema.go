@@ -0,0 +1,230 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+const (
+	// ChartSchemaFile - name of the values schema file in a chart's top level directory
+	ChartSchemaFile = "values.schema.json"
+	// RegisteredSchemaSuffix - file name suffix of registered schemas, see LoadChartSchemas()
+	RegisteredSchemaSuffix = ".schema.json"
+	// ApprScheme - URL scheme of the charts helm fetches through the app
+	// registry plugin's downloader
+	ApprScheme = "appr://"
+)
+
+var (
+	// chart name to registered values schema, see LoadChartSchemas()
+	chartSchemas = make(map[string][]byte)
+	// fetch charts to read their values schema, see SetSchemaFetch()
+	schemaFetch = true
+	// how long a chart fetch may take, a slow registry skips the validation
+	// rather than holding the API request
+	schemaFetchTimeout = time.Minute
+	// chart location and version to the values schema read from the fetched
+	// chart, nil for a chart without one, see Schema()
+	fetchedSchemas      = make(map[string][]byte)
+	fetchedSchemasMutex sync.Mutex
+)
+
+// SetSchemaFetch enable true/false fetching of charts to read their values schema
+func SetSchemaFetch(enable bool) {
+	schemaFetch = enable
+}
+
+// LoadChartSchemas - register the values schema for known charts, read from
+// the files named <chart-name>.schema.json in dir.  A registered schema is
+// used in place of the schema in the chart itself.
+func LoadChartSchemas(dir string) error {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		glog.Warningf("unable to read chart schema directory: %v", err)
+		return err
+	}
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), RegisteredSchemaSuffix) {
+			continue
+		}
+		schema, err := ioutil.ReadFile(path.Join(dir, file.Name()))
+		if err != nil {
+			glog.Warningf("unable to read chart schema file: %v", err)
+			return err
+		}
+		if _, err = gojsonschema.NewSchema(gojsonschema.NewBytesLoader(schema)); err != nil {
+			glog.Warningf("invalid chart schema file %s: %v", file.Name(), err)
+			return err
+		}
+		chartSchemas[strings.TrimSuffix(file.Name(), RegisteredSchemaSuffix)] = schema
+	}
+	return nil
+}
+
+// ValuesSchemaError - chart values that do not match the chart's values
+// schema, with one violation per field, of the form "field.path: description".
+type ValuesSchemaError struct {
+	Chart      string
+	Violations []string
+}
+
+func (e *ValuesSchemaError) Error() string {
+	return fmt.Sprintf("chart %s values do not match the chart's values schema: %s",
+		e.Chart, strings.Join(e.Violations, "; "))
+}
+
+// ValidateValues - validate values against the JSON schema, returning a
+// *ValuesSchemaError that lists any violations.
+func ValidateValues(chart string, schema []byte, values map[string]interface{}) error {
+	result, err := gojsonschema.Validate(gojsonschema.NewBytesLoader(schema), gojsonschema.NewGoLoader(values))
+	if err != nil {
+		return fmt.Errorf("chart %s values schema is invalid: %v", chart, err)
+	}
+	if result.Valid() {
+		return nil
+	}
+	violations := make([]string, 0, len(result.Errors()))
+	for _, violation := range result.Errors() {
+		violations = append(violations, violation.String())
+	}
+	return &ValuesSchemaError{Chart: chart, Violations: violations}
+}
+
+// schemaFromArchive - read the values schema from a chart archive (.tgz), only
+// the chart's own schema is read, not those of its subcharts.  Nil when the
+// chart has no schema.
+func schemaFromArchive(filename string) ([]byte, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+	archive := tar.NewReader(gz)
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		// chart archives hold a single top level directory, named for the chart
+		parts := strings.Split(strings.TrimPrefix(header.Name, "./"), "/")
+		if len(parts) == 2 && parts[1] == ChartSchemaFile {
+			return ioutil.ReadAll(archive)
+		}
+	}
+}
+
+// Schema - the chart's values schema: the registered schema for the chart if
+// there is one, otherwise the values schema in the chart itself, which is
+// fetched from the registry, with helm fetch, before the context is done or
+// the fetch times out.  A chart version is fetched once, its schema is kept
+// for the next validation.  Nil when the chart has no schema.
+func (r GenericDriver) Schema(ctx context.Context) ([]byte, error) {
+	if schema, ok := chartSchemas[path.Base(r.ChartLocation)]; ok {
+		return schema, nil
+	}
+	if !schemaFetch {
+		return nil, nil
+	}
+	key := r.ChartLocation + "@" + r.Version
+	fetchedSchemasMutex.Lock()
+	schema, ok := fetchedSchemas[key]
+	fetchedSchemasMutex.Unlock()
+	if ok {
+		return schema, nil
+	}
+	schema, err := r.fetchSchema(ctx)
+	if err != nil {
+		return schema, err
+	}
+	fetchedSchemasMutex.Lock()
+	fetchedSchemas[key] = schema
+	fetchedSchemasMutex.Unlock()
+	return schema, nil
+}
+
+// fetchSchema - the values schema in the chart itself, fetched from the
+// registry, see Schema().
+func (r GenericDriver) fetchSchema(ctx context.Context) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, schemaFetchTimeout)
+	defer cancel()
+	if output, err := registryLoginContext(ctx, r); err != nil {
+		return output, err
+	}
+
+	dir, err := ioutil.TempDir(os.TempDir(), "chartfetch")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	arguments := []string{HelmFetch,
+		ApprScheme + r.ChartLocation,
+		HelmArgVersion, r.Version,
+		"--destination", dir,
+	}
+	if _, err := ExecuteContext(ctx, Helm, arguments); err != nil {
+		return nil, err
+	}
+	archives, err := filepath.Glob(path.Join(dir, "*.tgz"))
+	if err != nil || len(archives) == 0 {
+		return nil, err
+	}
+	return schemaFromArchive(archives[0])
+}
+
+// ValidateValues - merge the chart's values, as for Install(), and validate
+// them against the chart's values schema.  Charts without a schema are not
+// validated.  A chart that can't be fetched in time is not validated either,
+// the install will report the failure.
+func (r GenericDriver) ValidateValues(ctx context.Context) error {
+	values, err := mergedValues(&r)
+	if err != nil {
+		return err
+	}
+	schema, err := r.Schema(ctx)
+	if err != nil {
+		glog.Warningf("unable to read chart %s values schema, values not validated: %v", r.ChartLocation, err)
+		return nil
+	}
+	if schema == nil {
+		return nil
+	}
+	return ValidateValues(path.Base(r.ChartLocation), schema, values)
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+const testSchema = `{
+	"type": "object",
+	"required": ["replicas"],
+	"properties": {
+		"replicas": {"type": "integer", "minimum": 1},
+		"image": {
+			"type": "object",
+			"properties": {"tag": {"type": "string"}}
+		}
+	}
+}`
+
+func TestValidateValues(t *testing.T) {
+	values := map[string]interface{}{
+		"replicas": int64(3),
+		"image":    map[string]interface{}{"tag": "2.0"},
+	}
+	if err := ValidateValues("test", []byte(testSchema), values); err != nil {
+		t.Errorf("ValidateValues(%v) have %v, want nil", values, err)
+	}
+
+	values = map[string]interface{}{"image": map[string]interface{}{"tag": 2}}
+	err := ValidateValues("test", []byte(testSchema), values)
+	schemaErr, ok := err.(*ValuesSchemaError)
+	if !ok {
+		t.Fatalf("ValidateValues(%v) have %v, want *ValuesSchemaError", values, err)
+	}
+	if len(schemaErr.Violations) != 2 {
+		t.Errorf("ValidateValues(%v) have violations %v, want 2", values, schemaErr.Violations)
+	}
+	if !strings.Contains(err.Error(), "image.tag: ") {
+		t.Errorf("ValidateValues(%v) have %q, want field path image.tag", values, err)
+	}
+
+	if err := ValidateValues("test", []byte(`{"type": 7}`), values); err == nil {
+		t.Errorf("ValidateValues(invalid schema) have nil, want error")
+	}
+}
+
+func TestSchemaFromArchive(t *testing.T) {
+	file, err := ioutil.TempFile(os.TempDir(), "chart")
+	if err != nil {
+		t.Fatalf("TempFile() have error %v", err)
+	}
+	defer os.Remove(file.Name())
+	gz := gzip.NewWriter(file)
+	archive := tar.NewWriter(gz)
+	for name, content := range map[string]string{
+		"test/Chart.yaml":                          "name: test\n",
+		"test/charts/sub/" + ChartSchemaFile:       `{"type": "string"}`,
+		"test/" + ChartSchemaFile:                  testSchema,
+		"test/templates/deployment.yaml":           "kind: Deployment\n",
+		"test/charts/sub/templates/configmap.yaml": "kind: ConfigMap\n",
+	} {
+		archive.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content))})
+		archive.Write([]byte(content))
+	}
+	archive.Close()
+	gz.Close()
+	file.Close()
+
+	schema, err := schemaFromArchive(file.Name())
+	if err != nil || string(schema) != testSchema {
+		t.Errorf("schemaFromArchive() have %q, %v, want chart's schema", schema, err)
+	}
+}
+
+func TestLoadChartSchemas(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "schemas")
+	if err != nil {
+		t.Fatalf("TempDir() have error %v", err)
+	}
+	defer os.RemoveAll(dir)
+	ioutil.WriteFile(path.Join(dir, "test"+RegisteredSchemaSuffix), []byte(testSchema), 0644)
+	ioutil.WriteFile(path.Join(dir, "README.md"), []byte("schemas\n"), 0644)
+	if err := LoadChartSchemas(dir); err != nil {
+		t.Fatalf("LoadChartSchemas() have error %v, want nil", err)
+	}
+	defer delete(chartSchemas, "test")
+
+	chart := GenericDriver{ChartLocation: "quay.io/samsung_cnct/test", SetConfig: "replicas=0"}
+	err = chart.ValidateValues(context.Background())
+	if _, ok := err.(*ValuesSchemaError); !ok {
+		t.Errorf("ValidateValues() have %v, want *ValuesSchemaError", err)
+	}
+	chart.SetConfig = "replicas=2"
+	if err := chart.ValidateValues(context.Background()); err != nil {
+		t.Errorf("ValidateValues() have %v, want nil", err)
+	}
+}
+
+func TestFetchedSchemaCache(t *testing.T) {
+	// a chart version fetched before isn't fetched again
+	fetchedSchemas["quay.io/samsung_cnct/cached@1.0.0"] = []byte(testSchema)
+	defer delete(fetchedSchemas, "quay.io/samsung_cnct/cached@1.0.0")
+
+	chart := GenericDriver{ChartLocation: "quay.io/samsung_cnct/cached", Version: "1.0.0", SetConfig: "replicas=0"}
+	if _, ok := chart.ValidateValues(context.Background()).(*ValuesSchemaError); !ok {
+		t.Errorf("ValidateValues() of a cached chart version, want *ValuesSchemaError")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := chart.ValidateValues(ctx); err == nil {
+		t.Errorf("ValidateValues() of a cached chart version, after the request is done, have nil, want *ValuesSchemaError")
+	}
+}
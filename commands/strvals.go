@@ -0,0 +1,198 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Parsing for helm's --set argument string syntax, which is:
+//
+//	name=value,outer.inner=value,list={a,b,c},list[0]=value,list[1].name=value
+//
+// A backslash escapes the character that follows it, so that commas, dots,
+// equal signs and brackets can be used in names and values.  As with helm,
+// the values true, false, null and integers are typed, all others are strings.
+
+// ParseSet - parse a --set argument string and merge the result in to values.
+// Keys set in the string replace the same keys already present in values.
+func ParseSet(s string, values map[string]interface{}) error {
+	p := &setParser{sc: bytes.NewBufferString(s)}
+	for {
+		err := p.key(values)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed parsing --set data %q: %v", s, err)
+		}
+	}
+}
+
+type setParser struct {
+	sc *bytes.Buffer
+}
+
+func (p *setParser) key(data map[string]interface{}) error {
+	k, last, err := runesUntil(p.sc, "=[,.")
+	if err != nil {
+		if len(k) == 0 {
+			return err
+		}
+		return fmt.Errorf("key %q has no value", k)
+	}
+	if k == "" {
+		return fmt.Errorf("empty key name before %q", last)
+	}
+	switch last {
+	case '[':
+		list, _ := data[k].([]interface{})
+		list, err = p.listItem(list)
+		data[k] = list
+		return err
+	case '=':
+		val, err := p.value()
+		data[k] = val
+		return err
+	case '.':
+		inner, ok := data[k].(map[string]interface{})
+		if !ok {
+			inner = make(map[string]interface{})
+		}
+		err = p.key(inner)
+		if len(inner) == 0 {
+			return fmt.Errorf("key map %q has no value", k)
+		}
+		data[k] = inner
+		return err
+	}
+	return fmt.Errorf("key %q has no value", k)
+}
+
+// value reads either a {list} or a scalar, returns io.EOF at end of input.
+func (p *setParser) value() (interface{}, error) {
+	r, _, err := p.sc.ReadRune()
+	if err != nil {
+		return "", io.EOF
+	}
+	if r != '{' {
+		p.sc.UnreadRune()
+		v, _, err := runesUntil(p.sc, ",")
+		return typedValue(v), err
+	}
+	list := []interface{}{}
+	for {
+		v, last, err := runesUntil(p.sc, ",}")
+		if err != nil {
+			return list, errors.New("list value missing closing '}'")
+		}
+		list = append(list, typedValue(v))
+		if last == '}' {
+			break
+		}
+	}
+	r, _, err = p.sc.ReadRune()
+	if err != nil {
+		return list, io.EOF
+	}
+	if r != ',' {
+		return list, fmt.Errorf("unexpected %q after list value", r)
+	}
+	return list, nil
+}
+
+func (p *setParser) listItem(list []interface{}) ([]interface{}, error) {
+	idx, _, err := runesUntil(p.sc, "]")
+	if err != nil {
+		return list, errors.New("list index missing closing ']'")
+	}
+	i, err := strconv.Atoi(idx)
+	if err != nil || i < 0 {
+		return list, fmt.Errorf("invalid list index %q", idx)
+	}
+	for len(list) <= i {
+		list = append(list, nil)
+	}
+	r, _, err := p.sc.ReadRune()
+	if err != nil {
+		return list, fmt.Errorf("list index %d has no value", i)
+	}
+	switch r {
+	case '=':
+		val, err := p.value()
+		list[i] = val
+		return list, err
+	case '.':
+		inner, ok := list[i].(map[string]interface{})
+		if !ok {
+			inner = make(map[string]interface{})
+		}
+		err = p.key(inner)
+		list[i] = inner
+		return list, err
+	case '[':
+		inner, _ := list[i].([]interface{})
+		inner, err = p.listItem(inner)
+		list[i] = inner
+		return list, err
+	}
+	return list, fmt.Errorf("unexpected %q after list index %d", r, i)
+}
+
+// runesUntil reads up to the first unescaped rune in stop, which is consumed
+// and returned as last.  At the end of input err is io.EOF.
+func runesUntil(in io.RuneReader, stop string) (string, rune, error) {
+	var v []rune
+	for {
+		r, _, err := in.ReadRune()
+		if err != nil {
+			return string(v), r, io.EOF
+		}
+		if r == '\\' {
+			next, _, err := in.ReadRune()
+			if err != nil {
+				return string(v), next, io.EOF
+			}
+			v = append(v, next)
+			continue
+		}
+		if strings.ContainsRune(stop, r) {
+			return string(v), r, nil
+		}
+		v = append(v, r)
+	}
+}
+
+func typedValue(v string) interface{} {
+	switch strings.ToLower(v) {
+	case "true":
+		return true
+	case "false":
+		return false
+	case "null":
+		return nil
+	}
+	if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return i
+	}
+	return v
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/ghodss/yaml"
+)
+
+func TestParseSet(t *testing.T) {
+	tests := []struct {
+		set  string
+		want string
+	}{
+		{"name=value", `{"name": "value"}`},
+		{"a=1,b=true,c=null,d=", `{"a": 1, "b": true, "c": null, "d": ""}`},
+		{"outer.inner=value,outer.other=x", `{"outer": {"inner": "value", "other": "x"}}`},
+		{"list={a,b,c}", `{"list": ["a", "b", "c"]}`},
+		{"list={a,b},name=x", `{"list": ["a", "b"], "name": "x"}`},
+		{"list[0]=a,list[2]=c", `{"list": ["a", null, "c"]}`},
+		{"list[0].name=a,list[0].value=b", `{"list": [{"name": "a", "value": "b"}]}`},
+		{`name=a\,b,host\.name=c`, `{"name": "a,b", "host.name": "c"}`},
+		{"", `{}`},
+	}
+	for _, test := range tests {
+		have := make(map[string]interface{})
+		if err := ParseSet(test.set, have); err != nil {
+			t.Errorf("ParseSet(%q) have error %v, want nil", test.set, err)
+			continue
+		}
+		var want map[string]interface{}
+		json.Unmarshal([]byte(test.want), &want)
+		haveJSON, _ := json.Marshal(have)
+		wantJSON, _ := json.Marshal(want)
+		if string(haveJSON) != string(wantJSON) {
+			t.Errorf("ParseSet(%q) have %s, want %s", test.set, haveJSON, wantJSON)
+		}
+	}
+}
+
+func TestParseSetErrors(t *testing.T) {
+	for _, set := range []string{"name", "name,a=b", "a.=b", "list[x]=a", "list={a,b", "list[0]"} {
+		if err := ParseSet(set, make(map[string]interface{})); err == nil {
+			t.Errorf("ParseSet(%q) have nil, want error", set)
+		}
+	}
+}
+
+func TestBuildValuesOrder(t *testing.T) {
+	values := map[string]interface{}{"image": map[string]interface{}{"tag": "0.1", "repository": "mongo"}}
+	r := GenericDriver{
+		Values:          values,
+		JSONValues:      `{"image": {"tag": "1.0", "pullPolicy": "Always"}, "nodeSelector": {"krak8s.io/node-pool": "other"}}`,
+		SetConfig:       "image.tag=2.0,replicas=3,nodeSelector.nodepool=mine",
+		CustomerName:    "neptune",
+		SchedulingPaths: &SchedulingPaths{NodeSelector: "nodeSelector"},
+	}
+	if err := buildValues(&r); err != nil {
+		t.Fatalf("buildValues() have error %v, want nil", err)
+	}
+	var have, want map[string]interface{}
+	yaml.Unmarshal(r.YAMLValues, &have)
+	json.Unmarshal([]byte(`{"image": {"tag": "2.0", "pullPolicy": "Always", "repository": "mongo"}, "replicas": 3,
+		"nodeSelector": {"krak8s.io/node-pool": "neptuneNodes", "nodepool": "mine"}}`), &want)
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("buildValues() have %v, want %v", have, want)
+	}
+	if image := values["image"].(map[string]interface{}); image["tag"] != "0.1" {
+		t.Errorf("buildValues() modified the driver's values, have tag %v, want 0.1", image["tag"])
+	}
+
+	r = GenericDriver{SetConfig: "replicas=3"}
+	if err := buildValues(&r); err != nil {
+		t.Fatalf("buildValues(set only) have error %v, want nil", err)
+	}
+	if string(r.YAMLValues) != "replicas: 3\n" {
+		t.Errorf("buildValues(set only) have %q, want %q", r.YAMLValues, "replicas: 3\n")
+	}
+}
@@ -0,0 +1,240 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/glog"
+)
+
+// SchedulingPaths - the dotted key paths in a chart's values at which the
+// tenant scheduling values are injected.  An empty path disables injection
+// of that particular value for the chart.
+type SchedulingPaths struct {
+	Affinity     string
+	Tolerations  string
+	NodeSelector string
+}
+
+// DefaultSchedulingPaths - the key paths used by most charts, matching the
+// top level pod spec fields of the same names.
+var DefaultSchedulingPaths = SchedulingPaths{
+	Affinity:     "affinity",
+	Tolerations:  "tolerations",
+	NodeSelector: "nodeSelector",
+}
+
+// chart name to scheduling path overrides, see LoadSchedulingPaths()
+var chartSchedulingPaths = make(map[string]SchedulingPaths)
+
+// LoadSchedulingPaths - read the per chart scheduling key path overrides from
+// a YAML file of the form:
+//
+//	chart-name:
+//	  affinity: scheduling.affinity
+//	  tolerations: scheduling.tolerations
+//	  nodeSelector: ""
+//
+// Keys that are omitted for a chart keep their default path, keys that are
+// set to the empty string are not injected for that chart.
+func LoadSchedulingPaths(filename string) error {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		glog.Warningf("unable to read chart scheduling paths file: %v", err)
+		return err
+	}
+	overrides := make(map[string]map[string]string)
+	if err = yaml.Unmarshal(data, &overrides); err != nil {
+		glog.Warningf("unable to parse chart scheduling paths file: %v", err)
+		return err
+	}
+	for chart, keys := range overrides {
+		paths := DefaultSchedulingPaths
+		if val, ok := keys["affinity"]; ok {
+			paths.Affinity = val
+		}
+		if val, ok := keys["tolerations"]; ok {
+			paths.Tolerations = val
+		}
+		if val, ok := keys["nodeSelector"]; ok {
+			paths.NodeSelector = val
+		}
+		chartSchedulingPaths[chart] = paths
+	}
+	return nil
+}
+
+// ChartSchedulingPaths - return the scheduling key paths for the named chart.
+func ChartSchedulingPaths(chart string) SchedulingPaths {
+	if paths, ok := chartSchedulingPaths[chart]; ok {
+		return paths
+	}
+	return DefaultSchedulingPaths
+}
+
+// TenantScheduling - build the values that pin a chart's pods to the
+// customer's node pool: a required node affinity and a node selector for the
+// NodePoolLabel, and a toleration for the customer taint, both written for
+// the node pool by AddNodePool().
+func TenantScheduling(customer string, paths SchedulingPaths) map[string]interface{} {
+	return NodePoolScheduling(customer, NodePoolName(customer), paths)
+}
+
+// NodePoolScheduling - TenantScheduling() for the named node pool of the
+// customer, see ProjectNodePoolName().
+func NodePoolScheduling(customer, nodePool string, paths SchedulingPaths) map[string]interface{} {
+	values := make(map[string]interface{})
+	if paths.Affinity != "" {
+		setValuePath(values, paths.Affinity, map[string]interface{}{
+			"nodeAffinity": map[string]interface{}{
+				"requiredDuringSchedulingIgnoredDuringExecution": map[string]interface{}{
+					"nodeSelectorTerms": []interface{}{
+						map[string]interface{}{
+							"matchExpressions": []interface{}{
+								map[string]interface{}{
+									"key":      NodePoolLabel,
+									"operator": "In",
+									"values":   []interface{}{nodePool},
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+	if paths.Tolerations != "" {
+		setValuePath(values, paths.Tolerations, []interface{}{
+			map[string]interface{}{
+				"key":      TenantTaintKey,
+				"operator": "Equal",
+				"value":    customer,
+				"effect":   TenantTaintEffect,
+			},
+		})
+	}
+	if paths.NodeSelector != "" {
+		setValuePath(values, paths.NodeSelector, map[string]interface{}{
+			NodePoolLabel: nodePool,
+		})
+	}
+	return values
+}
+
+// setValuePath - set value at the dotted key path, creating (or replacing
+// non map values with) intermediate maps as required.
+func setValuePath(values map[string]interface{}, path string, value interface{}) {
+	keys := strings.Split(path, ".")
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := values[key].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			values[key] = next
+		}
+		values = next
+	}
+	values[keys[len(keys)-1]] = value
+}
+
+// MergeValues - deep merge src in to dst and return dst.  Maps are merged
+// recursively, lists present in both are combined by appending the src
+// elements that dst does not already contain, and for any other value src
+// replaces dst.
+func MergeValues(dst, src map[string]interface{}) map[string]interface{} {
+	if dst == nil {
+		dst = make(map[string]interface{})
+	}
+	for key, srcVal := range src {
+		dstVal, found := dst[key]
+		if !found {
+			dst[key] = srcVal
+			continue
+		}
+		switch s := srcVal.(type) {
+		case map[string]interface{}:
+			if d, ok := dstVal.(map[string]interface{}); ok {
+				dst[key] = MergeValues(d, s)
+				continue
+			}
+		case []interface{}:
+			if d, ok := dstVal.([]interface{}); ok {
+				dst[key] = appendMissing(d, s)
+				continue
+			}
+		}
+		dst[key] = srcVal
+	}
+	return dst
+}
+
+func appendMissing(dst, src []interface{}) []interface{} {
+	for _, s := range src {
+		found := false
+		for _, d := range dst {
+			if reflect.DeepEqual(d, s) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			dst = append(dst, s)
+		}
+	}
+	return dst
+}
+
+// UnmarshalValues - parse a chart values YAML document, of which a JSON
+// document is a subset.  An empty document gives empty values.
+func UnmarshalValues(doc string) (map[string]interface{}, error) {
+	values := make(map[string]interface{})
+	if err := yaml.Unmarshal([]byte(doc), &values); err != nil {
+		return nil, fmt.Errorf("values are not a YAML map: %v", err)
+	}
+	return values, nil
+}
+
+// copyValues - return a deep copy of values, so that merging in to the copy
+// leaves the original untouched.
+func copyValues(values map[string]interface{}) map[string]interface{} {
+	if values == nil {
+		return nil
+	}
+	dst := make(map[string]interface{}, len(values))
+	for key, val := range values {
+		dst[key] = copyValue(val)
+	}
+	return dst
+}
+
+func copyValue(val interface{}) interface{} {
+	switch v := val.(type) {
+	case map[string]interface{}:
+		return copyValues(v)
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i := range v {
+			list[i] = copyValue(v[i])
+		}
+		return list
+	}
+	return val
+}
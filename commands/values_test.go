@@ -0,0 +1,97 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMergeValues(t *testing.T) {
+	var dst, src, want map[string]interface{}
+	json.Unmarshal([]byte(`{"image": {"tag": "1.0", "pullPolicy": "Always"},
+		"tolerations": [{"key": "dedicated", "value": "db"}], "replicas": 1}`), &dst)
+	json.Unmarshal([]byte(`{"image": {"tag": "2.0"},
+		"tolerations": [{"key": "dedicated", "value": "db"}, {"key": "customer", "value": "neptune"}],
+		"replicas": {"min": 3}}`), &src)
+	json.Unmarshal([]byte(`{"image": {"tag": "2.0", "pullPolicy": "Always"},
+		"tolerations": [{"key": "dedicated", "value": "db"}, {"key": "customer", "value": "neptune"}],
+		"replicas": {"min": 3}}`), &want)
+
+	have := MergeValues(dst, src)
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("MergeValues() have %v, want %v", have, want)
+	}
+	if have := MergeValues(nil, src); !reflect.DeepEqual(have, src) {
+		t.Errorf("MergeValues(nil) have %v, want %v", have, src)
+	}
+}
+
+func TestTenantScheduling(t *testing.T) {
+	values := TenantScheduling("neptune", DefaultSchedulingPaths)
+	if len(values) != 3 {
+		t.Errorf("TenantScheduling() have %d keys, want 3", len(values))
+	}
+	selector, ok := values["nodeSelector"].(map[string]interface{})
+	if !ok || selector[NodePoolLabel] != "neptuneNodes" {
+		t.Errorf("TenantScheduling() have nodeSelector %v, want %s: neptuneNodes", values["nodeSelector"], NodePoolLabel)
+	}
+	tolerations, ok := values["tolerations"].([]interface{})
+	if !ok || len(tolerations) != 1 {
+		t.Fatalf("TenantScheduling() have tolerations %v, want 1 toleration", values["tolerations"])
+	}
+	if toleration := tolerations[0].(map[string]interface{}); toleration["value"] != "neptune" {
+		t.Errorf("TenantScheduling() have toleration value %v, want neptune", toleration["value"])
+	}
+
+	values = NodePoolScheduling("neptune", ProjectNodePoolName("neptune", "db"), DefaultSchedulingPaths)
+	if selector := values["nodeSelector"].(map[string]interface{}); selector[NodePoolLabel] != "neptune-dbNodes" {
+		t.Errorf("NodePoolScheduling(db) have nodeSelector %v, want %s: neptune-dbNodes", selector, NodePoolLabel)
+	}
+
+	paths := SchedulingPaths{Affinity: "scheduling.affinity", Tolerations: "scheduling.tolerations"}
+	values = TenantScheduling("neptune", paths)
+	scheduling, ok := values["scheduling"].(map[string]interface{})
+	if !ok || len(values) != 1 {
+		t.Fatalf("TenantScheduling(%v) have %v, want only scheduling key", paths, values)
+	}
+	if _, ok := scheduling["affinity"]; !ok {
+		t.Errorf("TenantScheduling(%v) have %v, want scheduling.affinity", paths, scheduling)
+	}
+	if _, ok := scheduling["tolerations"]; !ok {
+		t.Errorf("TenantScheduling(%v) have %v, want scheduling.tolerations", paths, scheduling)
+	}
+}
+
+func TestUnmarshalValues(t *testing.T) {
+	values, err := UnmarshalValues("image:\n  tag: latest\nreplicas: 2\n")
+	if err != nil {
+		t.Fatalf("UnmarshalValues() have error %v, want nil", err)
+	}
+	if image, ok := values["image"].(map[string]interface{}); !ok || image["tag"] != "latest" {
+		t.Errorf("UnmarshalValues() have image %v, want tag: latest", values["image"])
+	}
+	if values, err := UnmarshalValues(""); err != nil || len(values) != 0 {
+		t.Errorf("UnmarshalValues(``) have %v, %v, want empty values", values, err)
+	}
+	for _, doc := range []string{"- a\n- b\n", "image: [tag", "just a string"} {
+		if _, err := UnmarshalValues(doc); err == nil {
+			t.Errorf("UnmarshalValues(%q) have nil, want error", doc)
+		}
+	}
+}
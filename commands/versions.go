@@ -0,0 +1,305 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/blang/semver"
+	"github.com/golang/glog"
+)
+
+// Chart version constraints select from a chart's released versions, the
+// highest version that satisfies the constraint is used.  A constraint is
+// "latest", or one or more space (or comma) separated terms that must all be
+// satisfied, with "||" between alternatives:
+//
+//	1.2.3     exactly 1.2.3
+//	1.2, 1.x  any 1.2.z, any 1.y.z
+//	~1.2.3    >=1.2.3 <1.3.0, and ~1.2 is >=1.2.0 <1.3.0
+//	^1.2.3    >=1.2.3 <2.0.0, and ^0.2.3 is >=0.2.3 <0.3.0
+//	>=1.0 <2  the comparisons =, !=, >, >=, < and <=, missing parts are 0
+//
+// Pre-release versions are only selected when the constraint names one.
+
+// LatestVersion - the version constraint selecting the highest released version
+const LatestVersion = "latest"
+
+// versionRange - a single constraint term as a predicate on versions
+type versionRange func(semver.Version) bool
+
+// VersionConstraint - a parsed chart version constraint.
+type VersionConstraint struct {
+	text       string
+	alternates [][]versionRange
+	prerelease bool
+}
+
+func (c *VersionConstraint) String() string {
+	return c.text
+}
+
+// ParseVersionConstraint - parse a chart version constraint, see above.
+func ParseVersionConstraint(constraint string) (*VersionConstraint, error) {
+	c := &VersionConstraint{text: strings.TrimSpace(constraint)}
+	if c.text == "" || c.text == LatestVersion {
+		c.alternates = [][]versionRange{{}}
+		return c, nil
+	}
+	for _, alternate := range strings.Split(c.text, "||") {
+		terms := strings.Fields(strings.Replace(alternate, ",", " ", -1))
+		if len(terms) == 0 {
+			return nil, fmt.Errorf("invalid version constraint %q: empty alternative", constraint)
+		}
+		ranges := []versionRange{}
+		for i := 0; i < len(terms); i++ {
+			term := terms[i]
+			// allow a space between the operator and the version, ">= 1.0"
+			if strings.Trim(term, "=!<>~^") == "" && i+1 < len(terms) {
+				i++
+				term += terms[i]
+			}
+			r, err := c.parseTerm(term)
+			if err != nil {
+				return nil, fmt.Errorf("invalid version constraint %q: %v", constraint, err)
+			}
+			ranges = append(ranges, r)
+		}
+		c.alternates = append(c.alternates, ranges)
+	}
+	return c, nil
+}
+
+func (c *VersionConstraint) parseTerm(term string) (versionRange, error) {
+	op := term[:len(term)-len(strings.TrimLeft(term, "=!<>~^"))]
+	v, parts, err := parseVersionPrefix(term[len(op):])
+	if err != nil {
+		return nil, err
+	}
+	if len(v.Pre) > 0 {
+		c.prerelease = true
+	}
+	switch op {
+	case "", "=", "==":
+		if parts == 3 {
+			return func(o semver.Version) bool { return o.EQ(v) }, nil
+		}
+		return between(v, bump(v, parts)), nil
+	case "!=":
+		if parts == 3 {
+			return func(o semver.Version) bool { return o.NE(v) }, nil
+		}
+		next := bump(v, parts)
+		return func(o semver.Version) bool { return o.LT(v) || o.GTE(next) }, nil
+	case ">":
+		return func(o semver.Version) bool { return o.GT(v) }, nil
+	case ">=":
+		return func(o semver.Version) bool { return o.GTE(v) }, nil
+	case "<":
+		return func(o semver.Version) bool { return o.LT(v) }, nil
+	case "<=":
+		return func(o semver.Version) bool { return o.LTE(v) }, nil
+	case "~":
+		if parts == 1 {
+			return between(v, bump(v, 1)), nil
+		}
+		return between(v, bump(v, 2)), nil
+	case "^":
+		switch {
+		case v.Major > 0 || parts == 1:
+			return between(v, bump(v, 1)), nil
+		case v.Minor > 0 || parts == 2:
+			return between(v, bump(v, 2)), nil
+		}
+		return between(v, bump(v, 3)), nil
+	}
+	return nil, fmt.Errorf("unknown operator %q", op)
+}
+
+// parseVersionPrefix - parse a full or partial version, "1", "1.2", "1.2.x",
+// "v1.2.3", returning the version with missing parts set to 0 and the number
+// of parts given.
+func parseVersionPrefix(s string) (semver.Version, int, error) {
+	s = strings.TrimPrefix(s, "v")
+	if v, err := semver.Parse(s); err == nil {
+		return v, 3, nil
+	}
+	fields := strings.Split(s, ".")
+	if len(fields) > 3 {
+		return semver.Version{}, 0, fmt.Errorf("invalid version %q", s)
+	}
+	var nums [3]uint64
+	parts := 0
+	for _, field := range fields {
+		if field == "x" || field == "X" || field == "*" {
+			break
+		}
+		n, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return semver.Version{}, 0, fmt.Errorf("invalid version %q", s)
+		}
+		nums[parts] = n
+		parts++
+	}
+	if parts == 0 {
+		return semver.Version{}, 0, fmt.Errorf("invalid version %q", s)
+	}
+	return semver.Version{Major: nums[0], Minor: nums[1], Patch: nums[2]}, parts, nil
+}
+
+// bump - the first version after all of those that match v to the given
+// number of parts, bump(1.2.0, 2) is 1.3.0.
+func bump(v semver.Version, parts int) semver.Version {
+	switch parts {
+	case 1:
+		return semver.Version{Major: v.Major + 1}
+	case 2:
+		return semver.Version{Major: v.Major, Minor: v.Minor + 1}
+	}
+	return semver.Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1}
+}
+
+func between(low, high semver.Version) versionRange {
+	return func(o semver.Version) bool { return o.GTE(low) && o.LT(high) }
+}
+
+// Check - true if the version satisfies the constraint.
+func (c *VersionConstraint) Check(v semver.Version) bool {
+	if len(v.Pre) > 0 && !c.prerelease {
+		return false
+	}
+	for _, ranges := range c.alternates {
+		ok := true
+		for _, r := range ranges {
+			if !r(v) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Resolve - the highest of versions that satisfies the constraint, returned
+// as it appears in versions.  Versions that aren't semver are ignored.
+func (c *VersionConstraint) Resolve(versions []string) (string, error) {
+	var best *semver.Version
+	resolved := ""
+	for _, version := range versions {
+		v, err := semver.Parse(strings.TrimPrefix(version, "v"))
+		if err != nil || !c.Check(v) {
+			continue
+		}
+		if best == nil || v.GT(*best) {
+			best = &v
+			resolved = version
+		}
+	}
+	if best == nil {
+		return "", fmt.Errorf("no chart version satisfies %q", c.text)
+	}
+	return resolved, nil
+}
+
+// Exact - the version the constraint selects when it's a single, complete
+// version, such as 1.2.3 or =1.2.3.
+func (c *VersionConstraint) Exact() (string, bool) {
+	version := strings.TrimSpace(strings.TrimLeft(c.text, "="))
+	if _, err := semver.Parse(strings.TrimPrefix(version, "v")); err != nil {
+		return "", false
+	}
+	return version, true
+}
+
+// ResolveVersion - parse the constraint and resolve it against versions.
+func ResolveVersion(constraint string, versions []string) (string, error) {
+	c, err := ParseVersionConstraint(constraint)
+	if err != nil {
+		return "", err
+	}
+	return c.Resolve(versions)
+}
+
+// registry API client, requests are bounded so that a slow registry fails
+// the API request rather than hanging it.
+var registryClient = &http.Client{Timeout: 30 * time.Second}
+
+// ChartVersions - list the released versions of the chart in the app registry
+// (the chart repository's package index) at server.  The server is a host
+// name, as for chart locations, or a URL (with scheme) for a plain http
+// registry.  The request is abandoned when the context is done.  With dry run
+// enabled no request is made and versions is nil.
+func ChartVersions(ctx context.Context, server, registry, name, username, password string) ([]string, error) {
+	if dryrun {
+		return nil, nil
+	}
+	base := server
+	if !strings.Contains(server, "://") {
+		base = "https://" + server
+	}
+	url := base + "/cnr/api/v1/packages/" + registry + "/" + name
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if username != "" && password != "" {
+		req.SetBasicAuth(username, password)
+	}
+	resp, err := registryClient.Do(req.WithContext(ctx))
+	if err != nil {
+		glog.Warningf("unable to list chart versions: %v", err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("chart %s/%s/%s versions: registry returned %s", server, registry, name, resp.Status)
+	}
+	releases := []struct {
+		Release string `json:"release"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+		return nil, fmt.Errorf("chart %s/%s/%s versions: %v", server, registry, name, err)
+	}
+	versions := make([]string, 0, len(releases))
+	for _, release := range releases {
+		versions = append(versions, release.Release)
+	}
+	return versions, nil
+}
+
+// VersionLess - true if version a is lower than version b, false if either
+// isn't a semver version.
+func VersionLess(a, b string) bool {
+	va, err := semver.Parse(strings.TrimPrefix(a, "v"))
+	if err != nil {
+		return false
+	}
+	vb, err := semver.Parse(strings.TrimPrefix(b, "v"))
+	if err != nil {
+		return false
+	}
+	return va.LT(vb)
+}
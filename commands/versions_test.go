@@ -0,0 +1,98 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+var testVersions = []string{"0.1.0", "0.2.1", "0.2.3", "1.0.0", "1.2.0", "1.2.5", "v1.3.0",
+	"1.10.0", "2.0.0-beta.1", "not-a-version"}
+
+func TestResolveVersion(t *testing.T) {
+	tests := []struct {
+		constraint string
+		want       string
+	}{
+		{"latest", "1.10.0"},
+		{"", "1.10.0"},
+		{"1.2.0", "1.2.0"},
+		{"1.2", "1.2.5"},
+		{"1.x", "1.10.0"},
+		{"~1.2", "1.2.5"},
+		{"~1.2.1", "1.2.5"},
+		{"^1.2.0", "1.10.0"},
+		{"^0.2.1", "0.2.3"},
+		{">=1.0 <2", "1.10.0"},
+		{">= 1.0, < 1.3", "1.2.5"},
+		{"<1.0.0 || 1.3", "v1.3.0"},
+		{"!=1.10.0 >1.2.5", "v1.3.0"},
+		{">=2.0.0-beta", "2.0.0-beta.1"},
+	}
+	for _, test := range tests {
+		have, err := ResolveVersion(test.constraint, testVersions)
+		if err != nil || have != test.want {
+			t.Errorf("ResolveVersion(%q) have %q, %v, want %q", test.constraint, have, err, test.want)
+		}
+	}
+
+	for _, constraint := range []string{"~3", ">1.10.0"} {
+		if have, err := ResolveVersion(constraint, testVersions); err == nil {
+			t.Errorf("ResolveVersion(%q) have %q, want no matching version error", constraint, have)
+		}
+	}
+	for _, constraint := range []string{"one", "=>1.0", "1.2.3.4", "1.0 ||"} {
+		if _, err := ParseVersionConstraint(constraint); err == nil {
+			t.Errorf("ParseVersionConstraint(%q) have nil, want error", constraint)
+		}
+	}
+}
+
+func TestVersionConstraintExact(t *testing.T) {
+	for constraint, want := range map[string]string{"1.2.3": "1.2.3", "=1.2.3": "1.2.3", "v1.2.3": "v1.2.3", "1.2": "", "~1.2.3": "", "latest": ""} {
+		c, err := ParseVersionConstraint(constraint)
+		if err != nil {
+			t.Fatalf("ParseVersionConstraint(%q) have error %v", constraint, err)
+		}
+		if have, ok := c.Exact(); have != want || ok != (want != "") {
+			t.Errorf("Exact(%q) have %q, %t, want %q", constraint, have, ok, want)
+		}
+	}
+}
+
+func TestChartVersions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cnr/api/v1/packages/samsung_cnct/mongodb" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`[{"release": "1.0.0", "mediaType": "helm"}, {"release": "1.1.0", "mediaType": "helm"}]`))
+	}))
+	defer server.Close()
+
+	versions, err := ChartVersions(context.Background(), server.URL, "samsung_cnct", "mongodb", "", "")
+	if err != nil || !reflect.DeepEqual(versions, []string{"1.0.0", "1.1.0"}) {
+		t.Errorf("ChartVersions() have %v, %v, want [1.0.0 1.1.0]", versions, err)
+	}
+	if _, err := ChartVersions(context.Background(), server.URL, "samsung_cnct", "missing", "", ""); err == nil {
+		t.Errorf("ChartVersions(missing) have nil, want error")
+	}
+}
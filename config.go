@@ -21,6 +21,7 @@ import (
 	"krak8s/commands"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/golang/glog"
 	flag "github.com/spf13/pflag"
@@ -39,6 +40,27 @@ type config struct {
 	krakenKubeConfig *string
 	krakenCommand    *string
 	krakenInDocker   *bool
+	clusterTargets   *string
+	tenantServer     *string
+	schedulingPaths  *string
+	schemaDir        *string
+	schemaFetch      *bool
+	historyMax       *int
+	historyMaxAge    *time.Duration
+	configGit        *bool
+	configGitRemote  *string
+	configLockStale  *time.Duration
+	configLockWait   *time.Duration
+	apiKeys          *string
+	jwtKeys          *string
+	noAuth           *bool
+	admins           *string
+	auditLog         *string
+	credentialsKey   *string
+	listenAddress    *string
+	tlsCertFile      *string
+	tlsKeyFile       *string
+	tlsClientCAFile  *string
 	dryrun           *bool
 	debug            *bool
 }
@@ -55,6 +77,27 @@ func newConfig() *config {
 		krakenKubeConfig: flag.String("kraken-kubeconfig", commands.DefaultKubeConfig, "kraken confiuration yaml: deployment.clusters[0].nodePools.kubeConfig"),
 		krakenCommand:    flag.String("kraken-command", commands.K2, "command to run to execute kraken operations, either `k2`, or `k2cli` only"),
 		krakenInDocker:   flag.Bool("kraken-in-docker", false, "run kraken operations in docker"),
+		clusterTargets:   flag.String("cluster-targets", "", "yaml file of the cluster targets, each a kraken configuration, kubeconfig and context, that projects can be placed on"),
+		tenantServer:     flag.String("tenant-api-server", "", "API server URL of the kubeconfigs issued to the default cluster target's tenants, the URL krak8s reaches the cluster at when not set"),
+		schedulingPaths:  flag.String("chart-scheduling-paths", "", "yaml file of per chart value key paths for tenant affinity, tolerations, and nodeSelector"),
+		schemaDir:        flag.String("chart-schema-dir", "", "directory of registered chart values schemas, named <chart-name>.schema.json"),
+		schemaFetch:      flag.Bool("chart-schema-fetch", true, "fetch charts to validate application values against the chart's values.schema.json"),
+		historyMax:       flag.Int("config-history-max", commands.DefaultConfigHistoryMax, "number of kraken configuration revisions kept, 0 keeps all revisions"),
+		historyMaxAge:    flag.Duration("config-history-max-age", 0, "age after which kraken configuration revisions are removed, 0 keeps revisions of any age"),
+		configGit:        flag.Bool("kraken-config-git", false, "commit each kraken configuration change to a git repository in the kraken configuration directory"),
+		configGitRemote:  flag.String("kraken-config-git-remote", "", "git remote to push kraken configuration commits to"),
+		configLockStale:  flag.Duration("kraken-config-lock-stale", commands.DefaultConfigLockStale, "age after which a held kraken configuration lock is reported as stale"),
+		configLockWait:   flag.Duration("kraken-config-lock-wait", commands.DefaultConfigLockWait, "time a kraken configuration change waits for a lock held by another owner before it fails"),
+		apiKeys:          flag.String("api-keys", "", "yaml file of API keys by caller name, or secret:<namespace>/<name> for a Kubernetes Secret of API keys by caller name"),
+		jwtKeys:          flag.String("jwt-keys", "", "file of the JWT verification key, an HMAC secret or PEM RSA public keys, or secret:<namespace>/<name> for a Kubernetes Secret of keys"),
+		noAuth:           flag.Bool("no-auth", false, "serve the API without authentication"),
+		admins:           flag.String("admins", "", "comma separated names of the callers that are admins of every project and of the kraken configuration"),
+		credentialsKey:   flag.String("credentials-key", "", "file of the key sealing the registry credentials stored, or secret:<namespace>/<name> for a Kubernetes Secret of keys"),
+		auditLog:         flag.String("audit-log", "", "file the audit log of the create, update, and delete requests is appended to, audit.log in the kraken configuration directory when not set"),
+		listenAddress:    flag.String("listen-address", ":8080", "address the API server listens on"),
+		tlsCertFile:      flag.String("tls-cert-file", "", "file of the API server's PEM certificate, the API is served over HTTPS when set, and the certificate reloaded when the file changes"),
+		tlsKeyFile:       flag.String("tls-key-file", "", "file of the PEM private key of the --tls-cert-file certificate"),
+		tlsClientCAFile:  flag.String("tls-client-ca-file", "", "file of the PEM CA certificates that the client certificates, required when set, are verified with"),
 		dryrun:           flag.Bool("dry-run", false, "don't actually execute backend commands"),
 		debug:            flag.Bool("debug", false, "enable debug output"),
 	}
@@ -65,10 +108,26 @@ func (cfg *config) String() string {
 		"health-check: %t, version: %t, kraken-config-file: %s, "+
 		"kraken-config-dir: %s, kraken-nodepool-keypair: %s, "+
 		"kraken-kubeconfig: %s, kraken-command: %s, kraken-in-docker: %t, "+
+		"cluster-targets: %s, tenant-api-server: %s, chart-scheduling-paths: %s, chart-schema-dir: %s, "+
+		"chart-schema-fetch: %t, config-history-max: %d, "+
+		"config-history-max-age: %s, kraken-config-git: %t, "+
+		"kraken-config-git-remote: %s, kraken-config-lock-stale: %s, "+
+		"kraken-config-lock-wait: %s, "+
+		"api-keys: %s, jwt-keys: %s, no-auth: %t, admins: %s, "+
+		"audit-log: %s, credentials-key: %s, listen-address: %s, "+
+		"tls-cert-file: %s, tls-key-file: %s, tls-client-ca-file: %s, "+
 		"dry-run: %t, debug: %t",
 		*cfg.kubeconfig, *cfg.proxy, *cfg.healthCheck, *cfg.version,
 		*cfg.krakenConfigFile, *cfg.krakenConfigDir, *cfg.krakenKeyPair,
-		*cfg.krakenKubeConfig, *cfg.krakenCommand, *cfg.krakenInDocker, *cfg.dryrun, *cfg.debug)
+		*cfg.krakenKubeConfig, *cfg.krakenCommand, *cfg.krakenInDocker,
+		*cfg.clusterTargets, *cfg.tenantServer, *cfg.schedulingPaths, *cfg.schemaDir, *cfg.schemaFetch,
+		*cfg.historyMax, *cfg.historyMaxAge, *cfg.configGit,
+		*cfg.configGitRemote, *cfg.configLockStale,
+		*cfg.configLockWait,
+		*cfg.apiKeys, *cfg.jwtKeys, *cfg.noAuth, *cfg.admins,
+		*cfg.auditLog, *cfg.credentialsKey, *cfg.listenAddress,
+		*cfg.tlsCertFile, *cfg.tlsKeyFile, *cfg.tlsClientCAFile,
+		*cfg.dryrun, *cfg.debug)
 }
 
 // For any configuration members that contain environment variables as values, expand them.
@@ -79,20 +138,52 @@ func (cfg *config) envExpand() {
 	*cfg.krakenKeyPair = os.ExpandEnv(*cfg.krakenKeyPair)
 	*cfg.krakenKubeConfig = os.ExpandEnv(*cfg.krakenKubeConfig)
 	*cfg.krakenCommand = os.ExpandEnv(*cfg.krakenCommand)
+	*cfg.clusterTargets = os.ExpandEnv(*cfg.clusterTargets)
+	*cfg.schedulingPaths = os.ExpandEnv(*cfg.schedulingPaths)
+	*cfg.schemaDir = os.ExpandEnv(*cfg.schemaDir)
+	*cfg.configGitRemote = os.ExpandEnv(*cfg.configGitRemote)
+	*cfg.apiKeys = os.ExpandEnv(*cfg.apiKeys)
+	*cfg.jwtKeys = os.ExpandEnv(*cfg.jwtKeys)
+	*cfg.auditLog = os.ExpandEnv(*cfg.auditLog)
+	*cfg.credentialsKey = os.ExpandEnv(*cfg.credentialsKey)
+	*cfg.tlsCertFile = os.ExpandEnv(*cfg.tlsCertFile)
+	*cfg.tlsKeyFile = os.ExpandEnv(*cfg.tlsKeyFile)
+	*cfg.tlsClientCAFile = os.ExpandEnv(*cfg.tlsClientCAFile)
 }
 
 var envSupport = map[string]bool{
-	"kubeconfig":              true,
-	"proxy":                   true,
-	"version":                 false,
-	"health-check":            true,
-	"kraken-config-file":      true,
-	"kraken-config-dir":       true,
-	"kraken-nodepool-keypair": true,
-	"kraken-kubeconfig":       true,
-	"kraken-command":          true,
-	"dry-run":                 false,
-	"debug":                   false,
+	"kubeconfig":               true,
+	"proxy":                    true,
+	"version":                  false,
+	"health-check":             true,
+	"kraken-config-file":       true,
+	"kraken-config-git":        true,
+	"kraken-config-git-remote": true,
+	"kraken-config-lock-stale": true,
+	"kraken-config-lock-wait":  true,
+	"kraken-config-dir":        true,
+	"kraken-nodepool-keypair":  true,
+	"kraken-kubeconfig":        true,
+	"kraken-command":           true,
+	"chart-scheduling-paths":   true,
+	"cluster-targets":          true,
+	"tenant-api-server":        true,
+	"chart-schema-dir":         true,
+	"chart-schema-fetch":       true,
+	"config-history-max":       true,
+	"config-history-max-age":   true,
+	"api-keys":                 true,
+	"jwt-keys":                 true,
+	"no-auth":                  false,
+	"admins":                   true,
+	"audit-log":                true,
+	"credentials-key":          true,
+	"listen-address":           true,
+	"tls-cert-file":            true,
+	"tls-key-file":             true,
+	"tls-client-ca-file":       true,
+	"dry-run":                  false,
+	"debug":                    false,
 }
 
 func variableName(name string) string {
@@ -79,6 +79,48 @@ func TestNewConfig(t *testing.T) {
 	if !validateStringFlag("krakenCommand", commands.K2, cfg.krakenCommand, t) {
 		t.Error("TestNewConfig() want valid krakenCommand")
 	}
+	if !validateStringFlag("clusterTargets", "", cfg.clusterTargets, t) {
+		t.Error("TestNewConfig() want valid clusterTargets")
+	}
+	if !validateStringFlag("schedulingPaths", "", cfg.schedulingPaths, t) {
+		t.Error("TestNewConfig() want valid schedulingPaths")
+	}
+	if !validateStringFlag("schemaDir", "", cfg.schemaDir, t) {
+		t.Error("TestNewConfig() want valid schemaDir")
+	}
+	if !validateBoolFlag("schemaFetch", true, cfg.schemaFetch, t) {
+		t.Error("TestNewConfig() want valid schemaFetch")
+	}
+	if cfg.historyMax == nil || *cfg.historyMax != commands.DefaultConfigHistoryMax {
+		t.Error("TestNewConfig() want valid historyMax")
+	}
+	if cfg.historyMaxAge == nil || *cfg.historyMaxAge != 0 {
+		t.Error("TestNewConfig() want valid historyMaxAge")
+	}
+	if !validateBoolFlag("configGit", false, cfg.configGit, t) {
+		t.Error("TestNewConfig() want valid configGit")
+	}
+	if cfg.configLockStale == nil || *cfg.configLockStale != commands.DefaultConfigLockStale {
+		t.Error("TestNewConfig() want valid configLockStale")
+	}
+	if cfg.configLockWait == nil || *cfg.configLockWait != commands.DefaultConfigLockWait {
+		t.Error("TestNewConfig() want valid configLockWait")
+	}
+	if !validateStringFlag("configGitRemote", "", cfg.configGitRemote, t) {
+		t.Error("TestNewConfig() want valid configGitRemote")
+	}
+	if !validateStringFlag("listenAddress", ":8080", cfg.listenAddress, t) {
+		t.Error("TestNewConfig() want valid listenAddress")
+	}
+	if !validateStringFlag("tlsCertFile", "", cfg.tlsCertFile, t) {
+		t.Error("TestNewConfig() want valid tlsCertFile")
+	}
+	if !validateStringFlag("tlsKeyFile", "", cfg.tlsKeyFile, t) {
+		t.Error("TestNewConfig() want valid tlsKeyFile")
+	}
+	if !validateStringFlag("tlsClientCAFile", "", cfg.tlsClientCAFile, t) {
+		t.Error("TestNewConfig() want valid tlsClientCAFile")
+	}
 	if !validateBoolFlag("debug", false, cfg.debug, t) {
 		t.Error("TestNewConfig() want valid debug")
 	}
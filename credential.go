@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"krak8s/app"
+
+	"github.com/goadesign/goa"
+	"github.com/golang/glog"
+)
+
+// CredentialController implements the credential resource.
+type CredentialController struct {
+	*goa.Controller
+	ds      *DataStore
+	backend *Runner
+}
+
+// NewCredentialController creates a credential controller.
+func NewCredentialController(service *goa.Service, store *DataStore, backend *Runner) *CredentialController {
+	return &CredentialController{
+		Controller: service.NewController("CredentialController"),
+		ds:         store,
+		backend:    backend,
+	}
+}
+
+// MarshalCredentialObject to credential media type, without its password
+func MarshalCredentialObject(proj *ProjectObject, obj *CredentialObject, apps []*ApplicationObject) *app.Credential {
+	mt := &app.Credential{
+		Project:      proj.OID,
+		Name:         obj.Name,
+		Server:       obj.Server,
+		Username:     obj.Username,
+		Applications: []string{},
+		CreatedAt:    obj.CreatedAt,
+		UpdatedAt:    obj.UpdatedAt,
+	}
+	for _, appObj := range apps {
+		mt.Applications = append(mt.Applications, appObj.OID)
+	}
+	return mt
+}
+
+// redeployable - true if rotating the credential redeploys the application,
+// a deployed application whose chart krak8s deploys with helm.
+func redeployable(obj *ApplicationObject) bool {
+	return obj.Status.State == ApplicationDeployed && obj.Deployer != DeployerKraken && obj.ChartName != "mongodb-replicaset"
+}
+
+// Delete runs the delete action.
+func (c *CredentialController) Delete(ctx *app.DeleteCredentialContext) error {
+	// CredentialController_Delete: start_implement
+	proj, ok := c.ds.Project(ctx.Projectid)
+	if !ok {
+		return ctx.NotFound()
+	}
+	if _, ok = c.ds.ProjectCredential(proj, ctx.Name); !ok {
+		return ctx.NotFound()
+	}
+	if apps := c.ds.CredentialApplications(proj, ctx.Name); len(apps) > 0 {
+		return ctx.BadRequest(goa.ErrBadRequest(fmt.Errorf("credential %s is used by %d applications", ctx.Name, len(apps))))
+	}
+	c.ds.DeleteProjectCredential(proj, ctx.Name)
+	return ctx.NoContent()
+	// CredentialController_Delete: end_implement
+}
+
+// Get runs the get action.
+func (c *CredentialController) Get(ctx *app.GetCredentialContext) error {
+	// CredentialController_Get: start_implement
+	proj, ok := c.ds.Project(ctx.Projectid)
+	if !ok {
+		return ctx.NotFound()
+	}
+	cred, ok := c.ds.ProjectCredential(proj, ctx.Name)
+	if !ok {
+		return ctx.NotFound()
+	}
+	return ctx.OK(MarshalCredentialObject(proj, cred, c.ds.CredentialApplications(proj, cred.Name)))
+	// CredentialController_Get: end_implement
+}
+
+// List runs the list action.
+func (c *CredentialController) List(ctx *app.ListCredentialContext) error {
+	// CredentialController_List: start_implement
+	proj, ok := c.ds.Project(ctx.Projectid)
+	if !ok {
+		return ctx.NotFound()
+	}
+	collection := app.CredentialCollection{}
+	for _, cred := range c.ds.ProjectCredentials(proj) {
+		collection = append(collection, MarshalCredentialObject(proj, cred, c.ds.CredentialApplications(proj, cred.Name)))
+	}
+	return ctx.OK(collection)
+	// CredentialController_List: end_implement
+}
+
+// Update runs the update action.
+func (c *CredentialController) Update(ctx *app.UpdateCredentialContext) error {
+	// CredentialController_Update: start_implement
+	proj, ok := c.ds.Project(ctx.Projectid)
+	if !ok {
+		return ctx.NotFound()
+	}
+	old, rotated := c.ds.ProjectCredential(proj, ctx.Name)
+	apps := c.ds.CredentialApplications(proj, ctx.Name)
+	if rotated && old.Server != ctx.Payload.Server && len(apps) > 0 {
+		return ctx.BadRequest(goa.ErrBadRequest(fmt.Errorf("credential %s is used by applications of server %s", ctx.Name, old.Server)))
+	}
+	cred, err := c.ds.UpdateProjectCredential(proj, ctx.Name, ctx.Payload.Server, ctx.Payload.Username, ctx.Payload.Password)
+	if err != nil {
+		glog.Errorf("unable to seal the password of credential %s: %v", ctx.Name, err)
+		return ctx.InternalServerError()
+	}
+
+	// Log in with the rotated credential, ahead of the redeployments, which
+	// also log in.
+	if rotated {
+		c.backend.CredentialRequest(ctx, c.ds, proj, cred)
+		for _, obj := range apps {
+			if !ctx.Redeploy || !redeployable(obj) {
+				continue
+			}
+			ns, ok := c.ds.Namespace(obj.NamespaceID)
+			if !ok {
+				continue
+			}
+			c.backend.ChartRequest(ctx, UpdateChart, c.ds, proj, ns, obj)
+		}
+	}
+	return ctx.OK(MarshalCredentialObject(proj, cred, apps))
+	// CredentialController_Update: end_implement
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// SealedPrefix - prefix of the credentials sealed by a CredentialCipher,
+// sealed:<key name>:<base64 nonce and ciphertext>.  Whether a stored
+// credential is sealed is recorded with it, not told from the prefix, which a
+// plain text credential may have too.
+const SealedPrefix = "sealed:"
+
+// CredentialCipher - seals the credentials stored in the data store with
+// AES-256-GCM, keyed with the SHA-256 digest of each key of a key source.
+// Credentials are sealed with the key whose name sorts last, and opened with
+// the key named in them, so keys are rotated by adding a key named after the
+// current one.
+type CredentialCipher struct {
+	keys    map[string]cipher.AEAD
+	current string
+}
+
+// NewCredentialCipher - the cipher of the keys of the key source, a file
+// holding a key, or the data items of a Kubernetes Secret each holding one.
+func NewCredentialCipher(source KeySource) (*CredentialCipher, error) {
+	keys, err := source.Keys()
+	if err != nil {
+		return nil, err
+	}
+	c := &CredentialCipher{keys: make(map[string]cipher.AEAD)}
+	names := []string{}
+	for name, key := range keys {
+		key = bytes.TrimSpace(key)
+		if len(key) == 0 || strings.Contains(name, ":") {
+			return nil, fmt.Errorf("credentials key %q is empty, or its name has a colon", name)
+		}
+		digest := sha256.Sum256(key)
+		block, err := aes.NewCipher(digest[:])
+		if err != nil {
+			return nil, err
+		}
+		if c.keys[name], err = cipher.NewGCM(block); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return nil, errors.New("no credentials keys")
+	}
+	sort.Strings(names)
+	c.current = names[len(names)-1]
+	return c, nil
+}
+
+// SealedWith - true if the sealed credential is sealed with the cipher's
+// current key.
+func (c *CredentialCipher) SealedWith(sealed string) bool {
+	return c != nil && strings.HasPrefix(sealed, SealedPrefix+c.current+":")
+}
+
+// Seal - the plain text credential, sealed with the current key.
+func (c *CredentialCipher) Seal(plaintext string) (string, error) {
+	if c == nil {
+		return "", errors.New("no credentials keys to seal the credential with")
+	}
+	aead := c.keys[c.current]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), []byte(c.current))
+	return SealedPrefix + c.current + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Open - the plain text of the sealed credential.
+func (c *CredentialCipher) Open(sealed string) (string, error) {
+	if !strings.HasPrefix(sealed, SealedPrefix) {
+		return "", errors.New("sealed credential is malformed")
+	}
+	parts := strings.SplitN(strings.TrimPrefix(sealed, SealedPrefix), ":", 2)
+	if len(parts) != 2 {
+		return "", errors.New("sealed credential is malformed")
+	}
+	if c == nil {
+		return "", errors.New("sealed credential, and no credentials keys")
+	}
+	aead, ok := c.keys[parts[0]]
+	if !ok {
+		return "", fmt.Errorf("credential is sealed with key %s, which isn't one of the credentials keys", parts[0])
+	}
+	data, err := base64.StdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", err
+	}
+	if len(data) < aead.NonceSize() {
+		return "", errors.New("sealed credential is malformed")
+	}
+	plaintext, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], []byte(parts[0]))
+	if err != nil {
+		return "", fmt.Errorf("unable to open credential sealed with key %s: %v", parts[0], err)
+	}
+	return string(plaintext), nil
+}
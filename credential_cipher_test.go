@@ -0,0 +1,157 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCredentialCipher(t *testing.T) {
+	old, err := NewCredentialCipher(staticKeys{"2017-09": []byte("5c1e0a9d\n")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sealed, err := old.Seal("hunter2")
+	if err != nil || !old.SealedWith(sealed) || strings.Contains(sealed, "hunter2") {
+		t.Fatalf("Seal(), have: %q %v", sealed, err)
+	}
+	if opened, err := old.Open(sealed); err != nil || opened != "hunter2" {
+		t.Errorf("Open(), have: %q %v", opened, err)
+	}
+	if _, err := old.Open("plain"); err == nil {
+		t.Errorf("Open() plain text, want: error")
+	}
+	if _, err := old.Open(sealed[:len(sealed)-4] + "AAAA"); err == nil {
+		t.Errorf("Open() tampered, want: error")
+	}
+	var none *CredentialCipher
+	if _, err := none.Open(sealed); err == nil {
+		t.Errorf("Open() without keys, want: error")
+	}
+	if _, err := none.Seal("hunter2"); err == nil {
+		t.Errorf("Seal() without keys, want: error")
+	}
+
+	// the key named last seals, the others still open
+	rotated, err := NewCredentialCipher(staticKeys{"2017-09": []byte("5c1e0a9d"), "2017-10": []byte("a7f3b218")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rotated.SealedWith(sealed) {
+		t.Errorf("SealedWith() the old key, want: false")
+	}
+	if opened, err := rotated.Open(sealed); err != nil || opened != "hunter2" {
+		t.Errorf("Open() with the old key, have: %q %v", opened, err)
+	}
+	if resealed, _ := rotated.Seal("hunter2"); !strings.HasPrefix(resealed, SealedPrefix+"2017-10:") {
+		t.Errorf("Seal() rotated, have: %q", resealed)
+	}
+}
+
+func TestSetCredentialCipher(t *testing.T) {
+	ds := NewDataStore("")
+	go ds.Archiver()
+	ns := ds.NewNamespace("ns-1")
+	password := "hunter2"
+	app := ds.NewApplication(ns.OID, "app", "quay.io", "samsung_cnct", "redis", "1.0.0", "1.0.0",
+		nil, nil, &password, nil, nil, nil, nil, nil, nil)
+	if app.Password != password || app.PasswordSealed {
+		t.Fatalf("NewApplication() without a cipher, have: %q", app.Password)
+	}
+	// a plain text password that looks sealed is still plain text
+	lookalike := SealedPrefix + "key:aGVsbG8="
+	other := ds.NewApplication(ns.OID, "other", "quay.io", "samsung_cnct", "redis", "1.0.0", "1.0.0",
+		nil, nil, &lookalike, nil, nil, nil, nil, nil, nil)
+	if opened, err := ds.ApplicationPassword(other); err != nil || opened != lookalike {
+		t.Errorf("ApplicationPassword() sealed prefix, have: %q %v", opened, err)
+	}
+
+	c, err := NewCredentialCipher(staticKeys{"key": []byte("5c1e0a9d")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ds.SetCredentialCipher(c); err != nil {
+		t.Fatal(err)
+	}
+	if !app.PasswordSealed || !c.SealedWith(app.Password) {
+		t.Errorf("SetCredentialCipher(), have: %q", app.Password)
+	}
+	if opened, err := ds.ApplicationPassword(app); err != nil || opened != password {
+		t.Errorf("ApplicationPassword(), have: %q %v", opened, err)
+	}
+	if opened, err := ds.ApplicationPassword(other); err != nil || opened != lookalike {
+		t.Errorf("ApplicationPassword() sealed prefix, have: %q %v", opened, err)
+	}
+}
+
+func TestProjectCredential(t *testing.T) {
+	ds := NewDataStore("")
+	go ds.Archiver()
+	proj := ds.NewProject("acme", "")
+	ns := ds.NewNamespace("ns-1")
+	proj.Namespaces = append(proj.Namespaces, &ObjectLink{OID: ns.OID})
+
+	created, err := ds.UpdateProjectCredential(proj, "deployer", "quay.io", "acme+deployer", "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := "deployer"
+	app := ds.NewApplication(ns.OID, "app", "quay.io", "samsung_cnct", "redis", "1.0.0", "1.0.0",
+		nil, nil, nil, &name, nil, nil, nil, nil, nil)
+	ns.Applications = append(ns.Applications, &ObjectLink{OID: app.OID})
+	other := ds.NewApplication(ns.OID, "other", "quay.io", "samsung_cnct", "redis", "1.0.0", "1.0.0",
+		nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	ns.Applications = append(ns.Applications, &ObjectLink{OID: other.OID})
+
+	if apps := ds.CredentialApplications(proj, "deployer"); len(apps) != 1 || apps[0] != app {
+		t.Errorf("CredentialApplications(), have: %v, want: [%s]", apps, app.OID)
+	}
+	if username, password, err := ds.ApplicationLogin(proj, app); err != nil || username != "acme+deployer" || password != "hunter2" {
+		t.Errorf("ApplicationLogin(), have: %q %q %v", username, password, err)
+	}
+
+	// sealed once there's a cipher, and rotated in place of the old one
+	c, err := NewCredentialCipher(staticKeys{"key": []byte("5c1e0a9d")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ds.SetCredentialCipher(c); err != nil {
+		t.Fatal(err)
+	}
+	if !c.SealedWith(created.Password) {
+		t.Errorf("SetCredentialCipher() credential, have: %q", created.Password)
+	}
+	rotated, err := ds.UpdateProjectCredential(proj, "deployer", "quay.io", "acme+deployer", "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.SealedWith(rotated.Password) || !rotated.CreatedAt.Equal(created.CreatedAt) {
+		t.Errorf("UpdateProjectCredential() rotated, have: %+v", rotated)
+	}
+	if _, password, err := ds.ApplicationLogin(proj, app); err != nil || password != "correct horse" {
+		t.Errorf("ApplicationLogin() rotated, have: %q %v", password, err)
+	}
+
+	ds.DeleteProjectCredential(proj, "deployer")
+	if _, _, err := ds.ApplicationLogin(proj, app); err == nil {
+		t.Errorf("ApplicationLogin() deleted credential, want: error")
+	}
+	if creds := ds.ProjectCredentials(proj); len(creds) != 0 {
+		t.Errorf("ProjectCredentials(), have: %d, want: 0", len(creds))
+	}
+}
@@ -45,6 +45,13 @@ var Cluster = MediaType("application/cluster+json", func() {
 			Description("The related namespace's generated unique id, not the namespace's name")
 			Example("da9871c7")
 		})
+		Attribute("name", String, "Name of the node pool within the namespace, empty for the namespace's default node pool")
+		Attribute("node_pool", String, "Name of the Kraken node pool")
+		Attribute("node_config", String, "Kraken definitions.nodeConfigs entry of the project's nodes")
+		Attribute("os_config", String, "Kraken definitions.osConfigs entry of the project's nodes")
+		Attribute("container_config", String, "Kraken definitions.containerConfigs entry of the project's nodes")
+		Attribute("labels", HashOf(String, String), "Extra labels of the project's nodes")
+		Attribute("taints", ArrayOf(NodeTaint), "Extra taints of the project's nodes")
 		Required("id", "type", "nodePoolSize", "created_at", "updated_at", "state", "namespace_id")
 	})
 
@@ -56,6 +63,38 @@ var Cluster = MediaType("application/cluster+json", func() {
 		Attribute("updated_at")
 		Attribute("state")
 		Attribute("namespace_id")
+		Attribute("name")
+		Attribute("node_pool")
+		Attribute("node_config")
+		Attribute("os_config")
+		Attribute("container_config")
+		Attribute("labels")
+		Attribute("taints")
+	})
+})
+
+// ClusterPlan is the media type of the plan for a cluster resource request.
+var ClusterPlan = MediaType("application/cluster.plan+json", func() {
+	Description("The Kraken configuration change and k2 command a cluster resource request would run")
+	Attributes(func() {
+		Attribute("action", String, "Requested change to the project's node pool", func() {
+			Enum("create", "update", "delete")
+		})
+		Attribute("node_pool", String, "Name of the project's node pool", func() {
+			Example("myprojectNodes")
+		})
+		Attribute("config_diff", String, "Unified diff of the Kraken configuration file, empty if the file is unchanged")
+		Attribute("command", ArrayOf(String), "The k2 command line")
+		Attribute("environment", ArrayOf(String), "Environment variables set for the k2 command, NAME=value")
+		Required("action", "node_pool", "command")
+	})
+
+	View("default", func() {
+		Attribute("action")
+		Attribute("node_pool")
+		Attribute("config_diff")
+		Attribute("command")
+		Attribute("environment")
 	})
 })
 
@@ -93,11 +132,15 @@ var Application = MediaType("application/application+json", func() {
 		Attribute("registry", String, "Application registry identifier")
 		Attribute("name", String, "Application chart name")
 		Attribute("version", String, "Application chart version (tag) string")
+		Attribute("version_constraint", String, "Application chart version constraint the version was resolved from")
 		Attribute("channel", String, "Application chart's channel")
 		Attribute("username", String, "Registry server username")
-		Attribute("password", String, "Registry server password")
+		Attribute("credential", String, "Name of the project's registry credential the application logs in with")
 		Attribute("config", String, "Application chart config --set argument string")
 		Attribute("json_values", String, "Application chart's json values stringr")
+		Attribute("values", HashOf(String, Any), "Application chart values, merged from values_yaml and values")
+		Attribute("cluster", String, "Name of the cluster resource whose node pool the application is scheduled on")
+		Attribute("deployer", String, "How the application is deployed, by helm or by k2 from the Kraken configuration's helmConfigs")
 		Attribute("status", func() {
 			Attribute("deployed_at", DateTime, "Last deployment time")
 			Attribute("state", func() {
@@ -113,7 +156,7 @@ var Application = MediaType("application/application+json", func() {
 		})
 		Attribute("created_at", DateTime, "Date of creation")
 		Attribute("updated_at", DateTime, "Date of last update")
-		Required("id", "type", "namespace_id", "deployment_name", "server", "registry", "name", "version", "channel", "username", "password", "config", "json_values", "status", "created_at", "updated_at")
+		Required("id", "type", "namespace_id", "deployment_name", "server", "registry", "name", "version", "channel", "username", "config", "json_values", "status", "created_at", "updated_at")
 	})
 
 	View("default", func() {
@@ -125,16 +168,261 @@ var Application = MediaType("application/application+json", func() {
 		Attribute("registry")
 		Attribute("name")
 		Attribute("version")
+		Attribute("version_constraint")
 		Attribute("channel")
 		Attribute("username")
+		Attribute("credential")
 		Attribute("config")
 		Attribute("json_values")
+		Attribute("values")
+		Attribute("cluster")
+		Attribute("deployer")
 		Attribute("status")
 		Attribute("created_at")
 		Attribute("updated_at")
 	})
 })
 
+// ApplicationVersion is the application chart version status media type.
+var ApplicationVersion = MediaType("application/application.version+json", func() {
+	Description("Application chart version status, the installed version and the newer versions available")
+	Attributes(func() {
+		Attribute("id", String, "generated resource unique id (8 character hexadecimal value)", func() {
+			Example("e1ea1660")
+		})
+		Attribute("namespace_id", String, func() {
+			Description("The related namespace's generated unique id, not the namespace's name")
+			Example("da9871c7")
+		})
+		Attribute("deployment_name", String, "Cluster application deployment name")
+		Attribute("name", String, "Application chart name")
+		Attribute("version", String, "Installed application chart version", func() {
+			Example("1.2.0")
+		})
+		Attribute("version_constraint", String, "Application chart version constraint the version was resolved from", func() {
+			Example("~1.2")
+		})
+		Attribute("constraint_version", String, "Highest available chart version that satisfies the version constraint", func() {
+			Example("1.2.5")
+		})
+		Attribute("latest_version", String, "Highest available chart version", func() {
+			Example("2.0.0")
+		})
+		Required("id", "namespace_id", "deployment_name", "name", "version", "latest_version")
+	})
+
+	View("default", func() {
+		Attribute("id")
+		Attribute("namespace_id")
+		Attribute("deployment_name")
+		Attribute("name")
+		Attribute("version")
+		Attribute("version_constraint")
+		Attribute("constraint_version")
+		Attribute("latest_version")
+	})
+})
+
+// ConfigRevision is the Kraken configuration file revision media type.
+var ConfigRevision = MediaType("application/config.revision+json", func() {
+	Description("A saved revision of the Kraken configuration file, and the operation that replaced it")
+	Attributes(func() {
+		Attribute("id", String, "Revision id, the unix time the revision was saved", func() {
+			Example("1508400000")
+		})
+		Attribute("time", DateTime, "Date the revision was saved")
+		Attribute("operation", String, "Operation that replaced the revision", func() {
+			Enum("add", "update", "delete", "restore", "unknown")
+		})
+		Attribute("project", String, "Name of the project whose node pool the operation changed", func() {
+			Example("myproject")
+		})
+		Attribute("restored", String, "Id of the revision restored, for the restore operation", func() {
+			Example("1508300000")
+		})
+		Attribute("update", String, "State of the cluster update of a restore operation's node pool changes", func() {
+			Enum("pending", "applied", "failed")
+		})
+		Required("id", "time", "operation")
+	})
+
+	View("default", func() {
+		Attribute("id")
+		Attribute("time")
+		Attribute("operation")
+		Attribute("project")
+		Attribute("restored")
+		Attribute("update")
+	})
+})
+
+// AuditEntry is the audit log entry media type.
+var AuditEntry = MediaType("application/audit.entry+json", func() {
+	Description("An entry of the audit log, of a create, update, or delete API request or of a backend command it ran")
+	Attributes(func() {
+		Attribute("time", DateTime, "Date of the request's response, or the command's completion")
+		Attribute("request_id", String, "goa request id of the API request, shared by the commands it ran", func() {
+			Example("Kx3dPvGqTi-42")
+		})
+		Attribute("kind", String, "Kind of the entry", func() {
+			Enum("request", "command")
+		})
+		Attribute("caller", String, "API key name or JWT subject of the caller", func() {
+			Example("alice")
+		})
+		Attribute("action", String, "Controller and action of the request", func() {
+			Example("ClusterController.delete")
+		})
+		Attribute("method", String, "HTTP method of the request", func() {
+			Example("DELETE")
+		})
+		Attribute("path", String, "URL path of the request", func() {
+			Example("/v1/projects/3d2e5f7a/cluster/a1b2c3d4")
+		})
+		Attribute("objects", ArrayOf(String), "Oids of the objects the request targeted or created", func() {
+			Example([]string{"3d2e5f7a", "a1b2c3d4"})
+		})
+		Attribute("payload", HashOf(String, Any), "Request payload, with its secrets redacted", func() {
+			Example(map[string]interface{}{"name": "myapp", "password": "REDACTED"})
+		})
+		Attribute("status", Integer, "HTTP status of the response", func() {
+			Example(204)
+		})
+		Attribute("outcome", String, "Outcome of the request or command", func() {
+			Enum("success", "failure", "denied")
+		})
+		Attribute("error", String, "Error of a failed request or command", func() {
+			Example("exit status 1")
+		})
+		Attribute("command", ArrayOf(String), "Backend command and its arguments", func() {
+			Example([]string{"helm", "delete", "--purge", "myapp"})
+		})
+		Required("request_id", "time", "kind", "outcome")
+	})
+
+	View("default", func() {
+		Attribute("time")
+		Attribute("request_id")
+		Attribute("kind")
+		Attribute("caller")
+		Attribute("action")
+		Attribute("method")
+		Attribute("path")
+		Attribute("objects")
+		Attribute("payload")
+		Attribute("status")
+		Attribute("outcome")
+		Attribute("error")
+		Attribute("command")
+	})
+})
+
+// ConfigDiff is the Kraken configuration file revision diff media type.
+var ConfigDiff = MediaType("application/config.diff+json", func() {
+	Description("The differences between two revisions of the Kraken configuration file")
+	Attributes(func() {
+		Attribute("from", String, "Revision id diffed from", func() {
+			Example("1508400000")
+		})
+		Attribute("to", String, "Revision id diffed to, current for the configuration file", func() {
+			Example("current")
+		})
+		Attribute("diff", String, "Unified diff, empty if the revisions are the same")
+		Required("from", "to")
+	})
+
+	View("default", func() {
+		Attribute("from")
+		Attribute("to")
+		Attribute("diff")
+	})
+})
+
+// Kubeconfig is the project's tenant kubeconfig media type.
+var Kubeconfig = MediaType("application/kubeconfig+json", func() {
+	Description("A kubeconfig giving the project's tenants access to its Kubernetes namespaces")
+	Attributes(func() {
+		Attribute("project", String, "The project resource unique oid", func() {
+			Example("30299bea")
+		})
+		Attribute("service_account", String, "Namespace/name of the project's ServiceAccount", func() {
+			Example("krak8s-tenants/project-30299bea")
+		})
+		Attribute("namespaces", ArrayOf(String), "Kubernetes namespaces the ServiceAccount can access", func() {
+			Example([]string{"acme-prod", "acme-staging"})
+		})
+		Attribute("kubeconfig", String, "kubeconfig with the ServiceAccount's token, in YAML")
+		Required("project", "service_account", "namespaces", "kubeconfig")
+	})
+
+	View("default", func() {
+		Attribute("project")
+		Attribute("service_account")
+		Attribute("namespaces")
+		Attribute("kubeconfig")
+	})
+})
+
+// ProjectMember is the media type of a caller's role on a project.
+var ProjectMember = MediaType("application/member+json", func() {
+	Description("A caller bound to a role on a project, the project's admins are its owners")
+	Attributes(func() {
+		Attribute("project", String, "The project resource unique oid", func() {
+			Example("30299bea")
+		})
+		Attribute("caller", String, "API key name or JWT subject of the caller", func() {
+			Example("alice")
+		})
+		Attribute("role", String, "Role of the caller on the project", func() {
+			Enum("admin", "operator", "viewer")
+			Example("operator")
+		})
+		Required("project", "caller", "role")
+	})
+
+	View("default", func() {
+		Attribute("project")
+		Attribute("caller")
+		Attribute("role")
+	})
+})
+
+// Credential is the media type of a project's named registry credential, its
+// password is write only.
+var Credential = MediaType("application/credential+json", func() {
+	Description("A named registry credential of a project, that the project's applications log in with")
+	Attributes(func() {
+		Attribute("project", String, "The project resource unique oid", func() {
+			Example("30299bea")
+		})
+		Attribute("name", String, "Name of the credential", func() {
+			Example("quay-deployer")
+		})
+		Attribute("server", String, "Registry host server the credential logs in to", func() {
+			Example("quay.io")
+		})
+		Attribute("username", String, "Registry server username", func() {
+			Example("samsung_cnct+deployer")
+		})
+		Attribute("applications", ArrayOf(String), "Ids of the applications using the credential", func() {
+			Example([]string{"e1ea1660"})
+		})
+		Attribute("created_at", DateTime, "Date of creation")
+		Attribute("updated_at", DateTime, "Date of the last rotation")
+		Required("project", "name", "server", "username", "applications", "created_at", "updated_at")
+	})
+
+	View("default", func() {
+		Attribute("project")
+		Attribute("name")
+		Attribute("server")
+		Attribute("username")
+		Attribute("applications")
+		Attribute("created_at")
+		Attribute("updated_at")
+	})
+})
+
 // NamespaceRef is the namespace resource reference media type.
 var NamespaceRef = MediaType("application/namespace.ref+json", func() {
 	Description("Users and tennants of the system are represented as the type Project")
@@ -173,8 +461,14 @@ var Namespace = MediaType("application/namespace+json", func() {
 		})
 		Attribute("created_at", DateTime, "Date of creation")
 
-		Attribute("resources", ClusterRef, "cluster resource associated with namespace")
+		Attribute("resources", CollectionOf(ClusterRef), "cluster resources associated with namespace")
 		Attribute("applications", CollectionOf(ApplicationRef), "applications associated with namespace")
+		Attribute("phase", String, "phase of the Kubernetes namespace", func() {
+			Enum("Active", "Terminating", "Failed")
+			Example("Active")
+		})
+		Attribute("quota", NamespaceQuota, "resource quota of the namespace")
+		Attribute("usage", NamespaceQuota, "current usage of the namespace's resource quota")
 
 		Required("id", "type", "name", "created_at", "resources", "applications")
 	})
@@ -186,6 +480,9 @@ var Namespace = MediaType("application/namespace+json", func() {
 		Attribute("created_at")
 		Attribute("resources")
 		Attribute("applications")
+		Attribute("phase")
+		Attribute("quota")
+		Attribute("usage")
 	})
 })
 
@@ -207,6 +504,19 @@ var Project = MediaType("application/project+json", func() {
 		Attribute("created_at", DateTime, "Date of creation")
 
 		Attribute("namespaces", CollectionOf(NamespaceRef), "namespace associations for this project")
+		Attribute("target", String, "cluster target the project is placed on, the default target when not given", func() {
+			Example("east")
+		})
+		Attribute("isolated", Boolean, "deny ingress traffic to the project's namespaces from outside of the project and its peers", func() {
+			Example(true)
+		})
+		Attribute("peers", ArrayOf(NetworkPeer), "additional sources of ingress traffic allowed in an isolated project")
+		Attribute("owners", ArrayOf(String), "callers with the admin role on the project", func() {
+			Example([]string{"alice"})
+		})
+		Attribute("members", HashOf(String, String), "roles of the project's other callers, operator or viewer, by caller", func() {
+			Example(map[string]string{"bob": "viewer"})
+		})
 
 		Required("id", "type", "name", "created_at", "namespaces")
 	})
@@ -217,5 +527,10 @@ var Project = MediaType("application/project+json", func() {
 		Attribute("name")
 		Attribute("created_at")
 		Attribute("namespaces")
+		Attribute("target")
+		Attribute("isolated")
+		Attribute("peers")
+		Attribute("owners")
+		Attribute("members")
 	})
 })
@@ -10,7 +10,7 @@ import (
 // The top level resource is /projects which contains a collection of the existing
 // users in the system (users and projects are synonyms).
 var _ = Resource("project", func() {
-	Description("Manage {create, delete}, and get a project, or all projects")
+	Description("Manage {create, update, delete}, and get a project, or all projects")
 
 	DefaultMedia(Project)
 	BasePath("/projects")
@@ -37,6 +37,9 @@ var _ = Resource("project", func() {
 		Description("Create a new project entry with the provided name.")
 		Payload(func() {
 			Member("name")
+			Member("target")
+			Member("isolated")
+			Member("peers")
 			Required("name")
 		})
 		Response(Created, Project)
@@ -44,6 +47,19 @@ var _ = Resource("project", func() {
 		Response(InternalServerError)
 	})
 
+	Action("update", func() {
+		Routing(PUT("/:projectid"))
+		Description("Update the network isolation of the project with given id.")
+		Payload(func() {
+			Member("isolated")
+			Member("peers")
+		})
+		Response(OK, Project)
+		Response(BadRequest, ErrorMedia)
+		Response(InternalServerError)
+		Response(NotFound)
+	})
+
 	Action("delete", func() {
 		Routing(DELETE("/:projectid"))
 		Response(NoContent)
@@ -53,7 +69,7 @@ var _ = Resource("project", func() {
 })
 
 var _ = Resource("namespace", func() {
-	Description("Manage {create, delete}, and get project's namespace(s)")
+	Description("Manage {create, update, delete}, and get project's namespace(s)")
 
 	Parent("project")
 	BasePath("namespaces")
@@ -65,6 +81,7 @@ var _ = Resource("namespace", func() {
 		Description("Create a namespace in the specified project")
 		Payload(func() {
 			Member("name")
+			Member("quota")
 			Required("name")
 		})
 		Response(Created, Namespace)
@@ -87,6 +104,19 @@ var _ = Resource("namespace", func() {
 		Response(OK, Namespace)
 	})
 
+	Action("update", func() {
+		Routing(PUT("/:namespaceid"))
+		Description("Update the resource quota of the specified namespace")
+		Payload(func() {
+			Member("quota")
+			Required("quota")
+		})
+		Response(OK, Namespace)
+		Response(BadRequest, ErrorMedia)
+		Response(InternalServerError)
+		Response(NotFound)
+	})
+
 	Action("delete", func() {
 		Routing(DELETE("/:namespaceid"))
 		Description("Delete the specified namespace from the project")
@@ -125,6 +155,13 @@ var _ = Resource("application", func() {
 		Response(OK, CollectionOf(Application))
 	})
 
+	Action("outdated", func() {
+		Routing(GET("/outdated"))
+		Description("Retrieve the applications in the project that have a newer chart version available.")
+		Response(NotFound)
+		Response(OK, CollectionOf(ApplicationVersion))
+	})
+
 	Action("get", func() {
 		Routing(GET("/:appid"))
 		Description("Get the status of the specified application in the project/namespace")
@@ -142,7 +179,7 @@ var _ = Resource("application", func() {
 })
 
 var _ = Resource("cluster", func() {
-	Description("Manage {create, delete}, and get cluster resources")
+	Description("Manage {create, update, delete}, and get cluster resources")
 
 	Parent("project")
 	BasePath("cluster")
@@ -152,8 +189,14 @@ var _ = Resource("cluster", func() {
 	Action("create", func() {
 		Routing(POST(""))
 		Description("Request the creation of the cluster resources in the project/namespace")
+		Params(func() {
+			Param("plan", Boolean, "Return the plan for the request without running it", func() {
+				Default(false)
+			})
+		})
 		Payload(ClusterPostBody)
 		Response(Accepted, Cluster)
+		Response(OK, ClusterPlan)
 		Response(BadRequest, ErrorMedia)
 		Response(InternalServerError)
 		Response(Conflict)
@@ -167,11 +210,223 @@ var _ = Resource("cluster", func() {
 		Response(NotFound)
 	})
 
+	Action("update", func() {
+		Routing(PUT("/:resource_id"))
+		Description("Request an update of the cluster resources in the project/namespace")
+		Params(func() {
+			Param("plan", Boolean, "Return the plan for the request without running it", func() {
+				Default(false)
+			})
+		})
+		Payload(ClusterPutBody)
+		Response(Accepted, Cluster)
+		Response(OK, ClusterPlan)
+		Response(BadRequest, ErrorMedia)
+		Response(Conflict)
+		Response(NotFound)
+	})
+
 	Action("delete", func() {
 		Routing(DELETE("/:resource_id"))
 		Description("Delete the cluster resources from the project/namespace")
+		Params(func() {
+			Param("plan", Boolean, "Return the plan for the request without running it", func() {
+				Default(false)
+			})
+		})
+		Response(NoContent)
+		Response(OK, ClusterPlan)
+		Response(NotFound)
+		Response(BadRequest, ErrorMedia)
+	})
+})
+
+var _ = Resource("kubeconfig", func() {
+	Description("Issue, rotate, and revoke the kubeconfig of a project's tenants")
+
+	Parent("project")
+	BasePath("kubeconfig")
+
+	Action("get", func() {
+		Routing(GET(""))
+		Description("Get a kubeconfig for the project's Kubernetes namespaces with the token of the project's ServiceAccount")
+		Response(OK, Kubeconfig)
+		Response(BadRequest, ErrorMedia)
+		Response(InternalServerError)
+		Response(NotFound)
+	})
+
+	Action("rotate", func() {
+		Routing(POST("/rotate"))
+		Description("Replace the token of the project's ServiceAccount, creating a revoked ServiceAccount again, the kubeconfigs issued before stop working")
+		Response(OK, Kubeconfig)
+		Response(BadRequest, ErrorMedia)
+		Response(InternalServerError)
+		Response(NotFound)
+	})
+
+	Action("revoke", func() {
+		Routing(DELETE(""))
+		Description("Delete the project's ServiceAccount and its token, the kubeconfigs issued stop working")
 		Response(NoContent)
+		Response(InternalServerError)
+		Response(NotFound)
+	})
+})
+
+var _ = Resource("member", func() {
+	Description("Manage {update, delete}, and list the roles of a project's callers")
+
+	Parent("project")
+	BasePath("members")
+
+	Action("list", func() {
+		Routing(GET(""))
+		Description("Retrieve the project's owners and members, and their roles")
+		Response(OK, CollectionOf(ProjectMember))
 		Response(NotFound)
+	})
+
+	Action("update", func() {
+		Routing(PUT("/:caller"))
+		Description("Bind the caller to a role on the project")
+		Params(func() {
+			Param("caller", String, "API key name or JWT subject of the caller")
+		})
+		Payload(func() {
+			Attribute("role", String, "Role of the caller on the project", func() {
+				Enum("admin", "operator", "viewer")
+				Example("operator")
+			})
+			Required("role")
+		})
+		Response(OK, ProjectMember)
+		Response(BadRequest, ErrorMedia)
+		Response(NotFound)
+	})
+
+	Action("delete", func() {
+		Routing(DELETE("/:caller"))
+		Description("Remove the caller's role on the project, a project keeps at least one owner")
+		Params(func() {
+			Param("caller", String, "API key name or JWT subject of the caller")
+		})
+		Response(NoContent)
+		Response(BadRequest, ErrorMedia)
+		Response(NotFound)
+	})
+})
+
+var _ = Resource("credential", func() {
+	Description("Manage {update, delete}, and get a project's named registry credentials")
+
+	Parent("project")
+	BasePath("credentials")
+
+	CanonicalActionName("get")
+
+	Action("list", func() {
+		Routing(GET(""))
+		Description("Retrieve the project's registry credentials, without their passwords")
+		Response(OK, CollectionOf(Credential))
+		Response(NotFound)
+	})
+
+	Action("get", func() {
+		Routing(GET("/:name"))
+		Description("Get the registry credential, without its password")
+		Params(func() {
+			Param("name", String, "Name of the credential")
+		})
+		Response(OK, Credential)
+		Response(NotFound)
+	})
+
+	Action("update", func() {
+		Routing(PUT("/:name"))
+		Description("Create the registry credential, or rotate it and log in to its registry again")
+		Params(func() {
+			Param("name", String, "Name of the credential", func() {
+				Pattern("^[a-z][a-z0-9-]{0,62}$")
+			})
+			Param("redeploy", Boolean, "Redeploy the helm deployed applications using the credential once it's rotated", func() {
+				Default(false)
+			})
+		})
+		Payload(CredentialPutBody)
+		Response(OK, Credential)
+		Response(BadRequest, ErrorMedia)
+		Response(InternalServerError)
+		Response(NotFound)
+	})
+
+	Action("delete", func() {
+		Routing(DELETE("/:name"))
+		Description("Delete the registry credential, unless applications use it")
+		Params(func() {
+			Param("name", String, "Name of the credential")
+		})
+		Response(NoContent)
+		Response(BadRequest, ErrorMedia)
+		Response(NotFound)
+	})
+})
+
+var _ = Resource("revision", func() {
+	Description("List, diff, and restore revisions of the Kraken configuration file")
+
+	BasePath("/config/revisions")
+
+	Action("list", func() {
+		Routing(GET(""))
+		Description("Retrieve the saved revisions of the Kraken configuration file, newest first.")
+		Response(OK, CollectionOf(ConfigRevision))
+	})
+
+	Action("diff", func() {
+		Routing(GET("/:revision/diff"))
+		Description("Get the differences from the revision to another revision, or to the current file")
+		Params(func() {
+			Param("revision", String, "Revision id")
+			Param("to", String, "Revision id to diff to, current for the configuration file", func() {
+				Default("current")
+			})
+		})
+		Response(OK, ConfigDiff)
+		Response(BadRequest, ErrorMedia)
+		Response(NotFound)
+	})
+
+	Action("restore", func() {
+		Routing(POST("/:revision/restore"))
+		Description("Replace the Kraken configuration file with the revision")
+		Params(func() {
+			Param("revision", String, "Revision id")
+			Param("update", Boolean, "Run the cluster update for the node pools the restore changes", func() {
+				Default(false)
+			})
+		})
+		Response(OK, ConfigRevision)
+		Response(BadRequest, ErrorMedia)
+		Response(NotFound)
+		Response(Conflict, ErrorMedia)
+	})
+})
+
+var _ = Resource("audit", func() {
+	Description("Query the audit log of the create, update, and delete requests, and the backend commands they ran")
+
+	BasePath("/audit")
+
+	Action("list", func() {
+		Routing(GET(""))
+		Description("Retrieve the audit log entries, oldest first, filtered by time and by object.")
+		Params(func() {
+			Param("since", DateTime, "Only entries at or after the time")
+			Param("until", DateTime, "Only entries before the time")
+			Param("object", String, "Only entries of the requests that targeted or created the object oid, and their commands")
+		})
+		Response(OK, CollectionOf(AuditEntry))
 		Response(BadRequest, ErrorMedia)
 	})
 })
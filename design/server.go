@@ -5,6 +5,19 @@ import (
 	. "github.com/goadesign/goa/design/apidsl"
 )
 
+// APIKey - API key security scheme, the keys are named after their callers.
+var APIKey = APIKeySecurity("api_key", func() {
+	Description("API key of the caller in the X-Api-Key header")
+	Header("X-Api-Key")
+})
+
+// JWT - JWT bearer token security scheme of the API's actions, the token's
+// subject is the caller.
+var JWT = JWTSecurity("jwt", func() {
+	Description("JWT bearer token in the Authorization header, signed with a krak8s JWT key, its subject is the caller.  An API key in the X-Api-Key header is accepted instead.")
+	Header("Authorization")
+})
+
 // Top level API attibutes and API specification endpoints.
 var _ = API("krak8s", func() {
 	Title("krak8s API Server")
@@ -24,6 +37,11 @@ var _ = API("krak8s", func() {
 	Consumes("application/json")
 	Produces("application/json")
 
+	// goa takes a single scheme per action, the actions declare the JWT
+	// scheme and the client and swagger specification take either, as the
+	// server does
+	Security(JWT)
+
 	ResponseTemplate(Created, func(pattern string) {
 		Description("The requested resource has been created")
 		Status(201)
@@ -40,6 +58,7 @@ var _ = API("krak8s", func() {
 // OpenAPI / Swagger tools to fetch and render the API specification easily.
 
 var _ = Resource("swagger", func() {
+	NoSecurity()
 	Description("Download the Swagger 2.0 (OpenAPI) Specification for this API")
 	Files("/swagger", "swagger/swagger.json")
 	Files("/swagger.json", "swagger/swagger.json")
@@ -47,6 +66,7 @@ var _ = Resource("swagger", func() {
 })
 
 var _ = Resource("openapi", func() {
+	NoSecurity()
 	Description("Download the OpenAPI (Swagger 2.0) Specification for this API")
 	Files("/openapi", "swagger/swagger.json")
 	Files("/openapi.json", "swagger/swagger.json")
@@ -54,6 +74,7 @@ var _ = Resource("openapi", func() {
 })
 
 var _ = Resource("health", func() {
+	NoSecurity()
 	Action("health", func() {
 		Routing(
 			GET("/healthz"),
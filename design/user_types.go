@@ -17,9 +17,88 @@ var ClusterPostBody = Type("ClusterPostBody", func() {
 		Description("The related namespace's generated unique id, not the namespace's name")
 		Example("da9871c7")
 	})
+	Attribute("name", String, func() {
+		Description("Name of the node pool within the namespace, the namespace's default node pool if not set")
+		Pattern("^[a-z][a-z0-9]{0,15}$")
+		Example("db")
+	})
+	Attribute("node_config", String, func() {
+		Description("Name of the Kraken definitions.nodeConfigs entry for the project's nodes")
+		Example("defaultAwsClusterNode")
+	})
+	Attribute("os_config", String, func() {
+		Description("Name of the Kraken definitions.osConfigs entry for the project's nodes")
+		Example("defaultCoreOs")
+	})
+	Attribute("container_config", String, func() {
+		Description("Name of the Kraken definitions.containerConfigs entry for the project's nodes")
+		Example("defaultDocker")
+	})
+	Attribute("labels", HashOf(String, String), func() {
+		Description("Extra labels for the project's nodes")
+	})
+	Attribute("taints", ArrayOf(NodeTaint), func() {
+		Description("Extra taints for the project's nodes, in addition to the project's customer taint")
+	})
 	Required("nodePoolSize", "namespace_id")
 })
 
+// NodeTaint is a taint on the nodes of a project's node pool
+var NodeTaint = Type("NodeTaint", func() {
+	Attribute("key", String, "Taint key", func() {
+		Example("gpu")
+	})
+	Attribute("value", String, "Taint value", func() {
+		Example("true")
+	})
+	Attribute("effect", String, "Taint effect", func() {
+		Enum("NoSchedule", "PreferNoSchedule", "NoExecute")
+	})
+	Required("key", "effect")
+})
+
+// ClusterPutBody is the HTTP PUT request body type to update a cluster resource
+var ClusterPutBody = Type("ClusterPutBody", func() {
+	Attribute("nodePoolSize", Integer, func() {
+		Description("The number of worker nodes in the projects resource pool")
+		Minimum(3)
+		Maximum(11)
+	})
+	Required("nodePoolSize")
+})
+
+// NamespaceQuota is the resource quota of a namespace, and its usage
+var NamespaceQuota = Type("NamespaceQuota", func() {
+	Attribute("cpu", String, "Total CPU requests and limits of the namespace's pods", func() {
+		Example("4")
+	})
+	Attribute("memory", String, "Total memory requests and limits of the namespace's pods", func() {
+		Example("16Gi")
+	})
+	Attribute("pods", Integer, "Number of pods in the namespace", func() {
+		Minimum(0)
+		Example(20)
+	})
+	Attribute("storage", String, "Total storage requests of the namespace's persistent volume claims", func() {
+		Example("100Gi")
+	})
+	Attribute("default_cpu", String, "CPU request and limit of the containers that don't set one", func() {
+		Example("250m")
+	})
+	Attribute("default_memory", String, "Memory request and limit of the containers that don't set one", func() {
+		Example("256Mi")
+	})
+})
+
+// NetworkPeer is a source of ingress traffic allowed in an isolated project
+var NetworkPeer = Type("NetworkPeer", func() {
+	Attribute("project", String, "Generated unique id of a project whose namespaces are allowed", func() {
+		Example("30299bea")
+	})
+	Attribute("namespace_labels", HashOf(String, String), "Labels of the namespaces whose pods are allowed")
+	Attribute("pod_labels", HashOf(String, String), "Labels of the pods allowed in the project's own namespaces, not combined with project or namespace_labels")
+})
+
 // ApplicationPostBody is the HTTP POST Request body type.
 var ApplicationPostBody = Type("ApplicationPostBody", func() {
 	Attribute("namespace_id", String, func() {
@@ -32,8 +111,7 @@ var ApplicationPostBody = Type("ApplicationPostBody", func() {
 		Example("samsung-mongodb-replicaset")
 	})
 	Attribute("server", String, func() {
-		Description("Application chart registry host server")
-		Default("quay.io")
+		Description("Application chart registry host server, quay.io by default, or the server of the credential the application logs in with")
 		Example("quay.io")
 	})
 	Attribute("registry", String, func() {
@@ -60,11 +138,48 @@ var ApplicationPostBody = Type("ApplicationPostBody", func() {
 	Attribute("password", String, func() {
 		Description("Registry server password")
 	})
+	Attribute("credential", String, func() {
+		Description("Name of the project's registry credential to log in with, instead of the username and password, the application's server is the credential's")
+		Pattern("^[a-z][a-z0-9-]{0,62}$")
+		Example("quay-deployer")
+	})
 	Attribute("set", String, func() {
 		Description("Application chart config --set argument string")
 	})
 	Attribute("json_values", String, func() {
 		Description("Application chart's json values string")
 	})
+	Attribute("values", HashOf(String, Any), func() {
+		Description("Application chart values object")
+	})
+	Attribute("values_yaml", String, func() {
+		Description("Application chart values YAML document")
+	})
+	Attribute("cluster", String, func() {
+		Description("Name of the namespace's cluster resource whose node pool the application is scheduled on, the default node pool if not set")
+		Pattern("^[a-z][a-z0-9]{0,15}$")
+		Example("db")
+	})
+	Attribute("deployer", String, func() {
+		Description("How the application is deployed, by helm, or by k2 from the Kraken configuration's helmConfigs, helm if not set")
+		Enum("helm", "kraken")
+		Example("kraken")
+	})
 	Required("deployment_name", "name", "version", "namespace_id")
 })
+
+// CredentialPutBody is the HTTP PUT Request body type of a registry credential.
+var CredentialPutBody = Type("CredentialPutBody", func() {
+	Attribute("server", String, func() {
+		Description("Registry host server the credential logs in to")
+		Example("quay.io")
+	})
+	Attribute("username", String, func() {
+		Description("Registry server username")
+		Example("samsung_cnct+deployer")
+	})
+	Attribute("password", String, func() {
+		Description("Registry server password, write only")
+	})
+	Required("server", "username", "password")
+})
@@ -0,0 +1,146 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"krak8s/commands"
+
+	"github.com/golang/glog"
+	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/pkg/api/errors"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+// Labels of the Kubernetes namespaces krak8s creates
+const (
+	// LabelProject - name of the project owning the namespace
+	LabelProject = "krak8s.io/project"
+	// LabelProjectOID - object id of the project owning the namespace
+	LabelProjectOID = "krak8s.io/project-oid"
+	// LabelNamespaceOID - object id of the API namespace
+	LabelNamespaceOID = "krak8s.io/namespace-oid"
+)
+
+// AnnotationNodeSelector - annotation of the node selector that the
+// PodNodeSelector admission controller adds to the pods of a namespace
+const AnnotationNodeSelector = "scheduler.alpha.kubernetes.io/node-selector"
+
+// tenantNodeSelector - the node selector of the project's namespaces, that
+// keeps their pods on the project's node pools, whatever their tolerations
+// and node selectors.
+func tenantNodeSelector(proj *ProjectObject) string {
+	return commands.TenantLabelKey + "=" + proj.Name
+}
+
+// NamespaceExistsError - the Kubernetes namespace exists and doesn't belong
+// to the project
+type NamespaceExistsError struct {
+	Name, Project string
+}
+
+func (e *NamespaceExistsError) Error() string {
+	return fmt.Sprintf("Kubernetes namespace %s exists and does not belong to project %s", e.Name, e.Project)
+}
+
+// clusterNamespaces - the namespaces client of the project's cluster target.
+func clusterNamespaces(proj *ProjectObject) (v1core.NamespaceInterface, error) {
+	clientset, err := ProjectTarget(proj).Clientset()
+	if err != nil {
+		return nil, err
+	}
+	return clientset.Core().Namespaces(), nil
+}
+
+// CreateClusterNamespace - create the Kubernetes namespace of the API
+// namespace, labeled with the project and object ids and annotated with the
+// project's node selector, and record its phase.  A namespace that exists
+// already is adopted when it belongs to the project, left over from an
+// earlier API namespace of the same name.
+func CreateClusterNamespace(client v1core.NamespaceInterface, proj *ProjectObject, ns *NamespaceObject) error {
+	kns := &v1.Namespace{
+		ObjectMeta: v1.ObjectMeta{
+			Name: ns.Name,
+			Labels: map[string]string{
+				LabelProject:      proj.Name,
+				LabelProjectOID:   proj.OID,
+				LabelNamespaceOID: ns.OID,
+			},
+			Annotations: map[string]string{AnnotationNodeSelector: tenantNodeSelector(proj)},
+		},
+	}
+	created, err := client.Create(kns)
+	if errors.IsAlreadyExists(err) {
+		created, err = client.Get(ns.Name)
+		if err == nil && created.Labels[LabelProjectOID] != proj.OID {
+			err = &NamespaceExistsError{Name: ns.Name, Project: proj.Name}
+		} else if err == nil {
+			glog.Infof("adopting existing Kubernetes namespace %s of project %s", ns.Name, proj.Name)
+			created.Labels[LabelNamespaceOID] = ns.OID
+			if created.Annotations == nil {
+				created.Annotations = map[string]string{}
+			}
+			created.Annotations[AnnotationNodeSelector] = tenantNodeSelector(proj)
+			created, err = client.Update(created)
+		}
+	}
+	if err != nil {
+		ns.Phase = NamespaceFailed
+		return err
+	}
+	ns.Phase = string(created.Status.Phase)
+	if ns.Phase == "" {
+		ns.Phase = NamespaceActive
+	}
+	return nil
+}
+
+// DeleteClusterNamespace - delete the Kubernetes namespace of the API
+// namespace.  Only a namespace labeled with the API namespace's object id is
+// deleted, one krak8s didn't create is left in place.
+func DeleteClusterNamespace(client v1core.NamespaceInterface, ns *NamespaceObject) error {
+	kns, err := client.Get(ns.Name)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if kns.Labels[LabelNamespaceOID] != ns.OID {
+		glog.Warningf("Kubernetes namespace %s was not created for namespace %s, leaving it in place", ns.Name, ns.OID)
+		return nil
+	}
+	err = client.Delete(ns.Name, &v1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	ns.Phase = NamespaceTerminating
+	return nil
+}
+
+// RefreshClusterNamespacePhase - update the recorded phase of the API
+// namespace from its Kubernetes namespace.
+func RefreshClusterNamespacePhase(client v1core.NamespaceInterface, ns *NamespaceObject) error {
+	kns, err := client.Get(ns.Name)
+	if err != nil {
+		return err
+	}
+	if kns.Status.Phase != "" {
+		ns.Phase = string(kns.Status.Phase)
+	}
+	return nil
+}
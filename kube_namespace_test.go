@@ -0,0 +1,121 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"testing"
+
+	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/pkg/api"
+	"k8s.io/client-go/pkg/api/errors"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+// fakeNamespaces - the namespaces of a cluster, the calls not used by krak8s
+// are left to the nil embedded interface.
+type fakeNamespaces struct {
+	v1core.NamespaceInterface
+	namespaces map[string]*v1.Namespace
+}
+
+func (f *fakeNamespaces) Create(ns *v1.Namespace) (*v1.Namespace, error) {
+	if _, ok := f.namespaces[ns.Name]; ok {
+		return nil, errors.NewAlreadyExists(api.Resource("namespaces"), ns.Name)
+	}
+	ns.Status.Phase = v1.NamespaceActive
+	f.namespaces[ns.Name] = ns
+	return ns, nil
+}
+
+func (f *fakeNamespaces) Update(ns *v1.Namespace) (*v1.Namespace, error) {
+	f.namespaces[ns.Name] = ns
+	return ns, nil
+}
+
+func (f *fakeNamespaces) Get(name string) (*v1.Namespace, error) {
+	if ns, ok := f.namespaces[name]; ok {
+		return ns, nil
+	}
+	return nil, errors.NewNotFound(api.Resource("namespaces"), name)
+}
+
+func (f *fakeNamespaces) Delete(name string, options *v1.DeleteOptions) error {
+	if _, ok := f.namespaces[name]; !ok {
+		return errors.NewNotFound(api.Resource("namespaces"), name)
+	}
+	delete(f.namespaces, name)
+	return nil
+}
+
+func TestClusterNamespace(t *testing.T) {
+	client := &fakeNamespaces{namespaces: map[string]*v1.Namespace{
+		"kube-system": {ObjectMeta: v1.ObjectMeta{Name: "kube-system"}},
+	}}
+	proj := &ProjectObject{OID: "30299bea", Name: "acme"}
+	ns := &NamespaceObject{OID: "da9871c7", Name: "acme-prod"}
+
+	if err := CreateClusterNamespace(client, proj, ns); err != nil {
+		t.Fatalf("CreateClusterNamespace(), err: %v", err)
+	}
+	labels := client.namespaces["acme-prod"].Labels
+	if labels[LabelProject] != "acme" || labels[LabelProjectOID] != proj.OID || labels[LabelNamespaceOID] != ns.OID {
+		t.Errorf("CreateClusterNamespace() labels, have: %v", labels)
+	}
+	if selector := client.namespaces["acme-prod"].Annotations[AnnotationNodeSelector]; selector != "krak8s.io/project=acme" {
+		t.Errorf("CreateClusterNamespace() node selector, have: %s", selector)
+	}
+	if ns.Phase != NamespaceActive {
+		t.Errorf("CreateClusterNamespace() phase, have: %s, want: %s", ns.Phase, NamespaceActive)
+	}
+
+	// the project's namespace is adopted by a new API namespace of the same
+	// name, and given the node selector it was created without
+	client.namespaces["acme-prod"].Annotations = nil
+	again := &NamespaceObject{OID: "f4d3c2b1", Name: "acme-prod"}
+	if err := CreateClusterNamespace(client, proj, again); err != nil {
+		t.Fatalf("CreateClusterNamespace() of the project's namespace, err: %v", err)
+	}
+	if oid := client.namespaces["acme-prod"].Labels[LabelNamespaceOID]; oid != again.OID {
+		t.Errorf("CreateClusterNamespace() adopted namespace oid, have: %s, want: %s", oid, again.OID)
+	}
+	if selector := client.namespaces["acme-prod"].Annotations[AnnotationNodeSelector]; selector != "krak8s.io/project=acme" {
+		t.Errorf("CreateClusterNamespace() adopted namespace node selector, have: %s", selector)
+	}
+
+	// a namespace of someone else isn't taken over, nor deleted
+	other := &NamespaceObject{OID: "0badf00d", Name: "kube-system"}
+	err := CreateClusterNamespace(client, proj, other)
+	if _, ok := err.(*NamespaceExistsError); !ok || other.Phase != NamespaceFailed {
+		t.Errorf("CreateClusterNamespace() of kube-system, want: *NamespaceExistsError and %s phase, have: %v %s", NamespaceFailed, err, other.Phase)
+	}
+	if err := DeleteClusterNamespace(client, other); err != nil {
+		t.Errorf("DeleteClusterNamespace() of kube-system, err: %v", err)
+	}
+	if _, ok := client.namespaces["kube-system"]; !ok {
+		t.Error("DeleteClusterNamespace() deleted kube-system")
+	}
+
+	if err := DeleteClusterNamespace(client, again); err != nil {
+		t.Errorf("DeleteClusterNamespace(), err: %v", err)
+	}
+	if _, ok := client.namespaces["acme-prod"]; ok || again.Phase != NamespaceTerminating {
+		t.Errorf("DeleteClusterNamespace(), want: acme-prod deleted and %s phase, have: %s", NamespaceTerminating, again.Phase)
+	}
+	if err := DeleteClusterNamespace(client, again); err != nil {
+		t.Errorf("DeleteClusterNamespace() of a deleted namespace, err: %v", err)
+	}
+}
@@ -0,0 +1,237 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"reflect"
+
+	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
+	apierrors "k8s.io/client-go/pkg/api/errors"
+	"k8s.io/client-go/pkg/api/unversioned"
+	"k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/pkg/apis/extensions/v1beta1"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// PolicyDefaultDeny - NetworkPolicy denying all ingress traffic to the
+	// pods of an isolated project's namespace
+	PolicyDefaultDeny = "krak8s-default-deny"
+	// PolicyAllowProject - NetworkPolicy allowing ingress traffic from the
+	// namespaces of the same project
+	PolicyAllowProject = "krak8s-allow-project"
+	// PolicyAllowPeers - NetworkPolicy allowing ingress traffic from the
+	// project's additional peers
+	PolicyAllowPeers = "krak8s-allow-peers"
+
+	// AnnotationNetworkPolicy - namespace annotation turning on network
+	// policy isolation, for clusters that predate default deny policies
+	AnnotationNetworkPolicy = "net.beta.kubernetes.io/network-policy"
+	// NetworkPolicyDefaultDeny - the AnnotationNetworkPolicy value of an
+	// isolated namespace
+	NetworkPolicyDefaultDeny = `{"ingress":{"isolation":"DefaultDeny"}}`
+)
+
+// NetworkPolicyInterface - the NetworkPolicies of a namespace.  The vendored
+// client-go has the NetworkPolicy type but no typed client for it.
+type NetworkPolicyInterface interface {
+	Create(*v1beta1.NetworkPolicy) (*v1beta1.NetworkPolicy, error)
+	Update(*v1beta1.NetworkPolicy) (*v1beta1.NetworkPolicy, error)
+	Delete(name string, options *v1.DeleteOptions) error
+	Get(name string) (*v1beta1.NetworkPolicy, error)
+}
+
+type networkPolicies struct {
+	client rest.Interface
+	ns     string
+}
+
+func (c *networkPolicies) Create(policy *v1beta1.NetworkPolicy) (result *v1beta1.NetworkPolicy, err error) {
+	result = &v1beta1.NetworkPolicy{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("networkpolicies").
+		Body(policy).
+		Do().
+		Into(result)
+	return
+}
+
+func (c *networkPolicies) Update(policy *v1beta1.NetworkPolicy) (result *v1beta1.NetworkPolicy, err error) {
+	result = &v1beta1.NetworkPolicy{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("networkpolicies").
+		Name(policy.Name).
+		Body(policy).
+		Do().
+		Into(result)
+	return
+}
+
+func (c *networkPolicies) Delete(name string, options *v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("networkpolicies").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+func (c *networkPolicies) Get(name string) (result *v1beta1.NetworkPolicy, err error) {
+	result = &v1beta1.NetworkPolicy{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("networkpolicies").
+		Name(name).
+		Do().
+		Into(result)
+	return
+}
+
+// ValidatePeers - check that each peer selects namespaces or pods, and not
+// both, which a NetworkPolicy peer can't, and that peer projects exist.
+func ValidatePeers(peers []NetworkPeer, projectExists func(oid string) bool) error {
+	for _, peer := range peers {
+		namespaces := peer.Project != "" || len(peer.NamespaceLabels) > 0
+		if !namespaces && len(peer.PodLabels) == 0 {
+			return errors.New("a peer needs a project, namespace_labels or pod_labels")
+		}
+		if namespaces && len(peer.PodLabels) > 0 {
+			return errors.New("a peer's pod_labels can't be combined with project or namespace_labels")
+		}
+		if peer.Project != "" && !projectExists(peer.Project) {
+			return errors.New("peer project " + peer.Project + " doesn't exist")
+		}
+	}
+	return nil
+}
+
+// networkPolicySpecs - the NetworkPolicies the namespace should have, by
+// name, none if the project isn't isolated.
+func networkPolicySpecs(proj *ProjectObject) map[string]*v1beta1.NetworkPolicySpec {
+	specs := make(map[string]*v1beta1.NetworkPolicySpec)
+	if !proj.Isolated {
+		return specs
+	}
+	// a policy selecting all of the pods without ingress rules denies all
+	specs[PolicyDefaultDeny] = &v1beta1.NetworkPolicySpec{}
+	specs[PolicyAllowProject] = &v1beta1.NetworkPolicySpec{
+		Ingress: []v1beta1.NetworkPolicyIngressRule{{
+			From: []v1beta1.NetworkPolicyPeer{{
+				NamespaceSelector: &unversioned.LabelSelector{
+					MatchLabels: map[string]string{LabelProjectOID: proj.OID},
+				},
+			}},
+		}},
+	}
+	if len(proj.Peers) == 0 {
+		return specs
+	}
+	from := []v1beta1.NetworkPolicyPeer{}
+	for _, peer := range proj.Peers {
+		if len(peer.PodLabels) > 0 {
+			from = append(from, v1beta1.NetworkPolicyPeer{
+				PodSelector: &unversioned.LabelSelector{MatchLabels: peer.PodLabels},
+			})
+			continue
+		}
+		labels := make(map[string]string)
+		for key, value := range peer.NamespaceLabels {
+			labels[key] = value
+		}
+		if peer.Project != "" {
+			labels[LabelProjectOID] = peer.Project
+		}
+		from = append(from, v1beta1.NetworkPolicyPeer{
+			NamespaceSelector: &unversioned.LabelSelector{MatchLabels: labels},
+		})
+	}
+	specs[PolicyAllowPeers] = &v1beta1.NetworkPolicySpec{
+		Ingress: []v1beta1.NetworkPolicyIngressRule{{From: from}},
+	}
+	return specs
+}
+
+// SyncNetworkPolicies - create, update or delete the NetworkPolicies of the
+// namespace, and its isolation annotation, to match its project's isolation
+// and peers.
+func SyncNetworkPolicies(namespaces v1core.NamespaceInterface, policies NetworkPolicyInterface, proj *ProjectObject, ns *NamespaceObject) error {
+	specs := networkPolicySpecs(proj)
+	for _, name := range []string{PolicyDefaultDeny, PolicyAllowProject, PolicyAllowPeers} {
+		if err := syncNetworkPolicy(policies, ns, name, specs[name]); err != nil {
+			return err
+		}
+	}
+
+	kns, err := namespaces.Get(ns.Name)
+	if err != nil {
+		return err
+	}
+	_, annotated := kns.Annotations[AnnotationNetworkPolicy]
+	if proj.Isolated == annotated {
+		return nil
+	}
+	if proj.Isolated {
+		if kns.Annotations == nil {
+			kns.Annotations = make(map[string]string)
+		}
+		kns.Annotations[AnnotationNetworkPolicy] = NetworkPolicyDefaultDeny
+	} else {
+		delete(kns.Annotations, AnnotationNetworkPolicy)
+	}
+	_, err = namespaces.Update(kns)
+	return err
+}
+
+func syncNetworkPolicy(policies NetworkPolicyInterface, ns *NamespaceObject, name string, spec *v1beta1.NetworkPolicySpec) error {
+	existing, err := policies.Get(name)
+	if apierrors.IsNotFound(err) {
+		if spec == nil {
+			return nil
+		}
+		_, err = policies.Create(&v1beta1.NetworkPolicy{
+			ObjectMeta: v1.ObjectMeta{Name: name, Labels: map[string]string{LabelNamespaceOID: ns.OID}},
+			Spec:       *spec,
+		})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if spec == nil {
+		return policies.Delete(name, &v1.DeleteOptions{})
+	}
+	if reflect.DeepEqual(existing.Spec, *spec) {
+		return nil
+	}
+	existing.Spec = *spec
+	_, err = policies.Update(existing)
+	return err
+}
+
+// syncNetworkPolicies - SyncNetworkPolicies() on the project's cluster target.
+func syncNetworkPolicies(proj *ProjectObject, ns *NamespaceObject) error {
+	clientset, err := ProjectTarget(proj).Clientset()
+	if err != nil {
+		return err
+	}
+	policies := &networkPolicies{client: clientset.Extensions().RESTClient(), ns: ns.Name}
+	return SyncNetworkPolicies(clientset.Core().Namespaces(), policies, proj, ns)
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"testing"
+
+	"k8s.io/client-go/pkg/api"
+	"k8s.io/client-go/pkg/api/errors"
+	"k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/pkg/apis/extensions/v1beta1"
+)
+
+type fakePolicies struct {
+	policies map[string]*v1beta1.NetworkPolicy
+}
+
+func (f *fakePolicies) Create(policy *v1beta1.NetworkPolicy) (*v1beta1.NetworkPolicy, error) {
+	f.policies[policy.Name] = policy
+	return policy, nil
+}
+
+func (f *fakePolicies) Update(policy *v1beta1.NetworkPolicy) (*v1beta1.NetworkPolicy, error) {
+	f.policies[policy.Name] = policy
+	return policy, nil
+}
+
+func (f *fakePolicies) Delete(name string, options *v1.DeleteOptions) error {
+	delete(f.policies, name)
+	return nil
+}
+
+func (f *fakePolicies) Get(name string) (*v1beta1.NetworkPolicy, error) {
+	if policy, ok := f.policies[name]; ok {
+		return policy, nil
+	}
+	return nil, errors.NewNotFound(api.Resource("networkpolicies"), name)
+}
+
+func TestSyncNetworkPolicies(t *testing.T) {
+	namespaces := &fakeNamespaces{namespaces: map[string]*v1.Namespace{
+		"acme-prod": {ObjectMeta: v1.ObjectMeta{Name: "acme-prod"}},
+	}}
+	policies := &fakePolicies{policies: map[string]*v1beta1.NetworkPolicy{}}
+	proj := &ProjectObject{OID: "30299bea", Name: "acme", Isolated: true}
+	ns := &NamespaceObject{OID: "da9871c7", Name: "acme-prod"}
+
+	if err := SyncNetworkPolicies(namespaces, policies, proj, ns); err != nil {
+		t.Fatalf("SyncNetworkPolicies(), err: %v", err)
+	}
+	if _, ok := policies.policies[PolicyDefaultDeny]; !ok || len(policies.policies) != 2 {
+		t.Errorf("SyncNetworkPolicies() policies, have: %v", policies.policies)
+	}
+	from := policies.policies[PolicyAllowProject].Spec.Ingress[0].From[0]
+	if from.NamespaceSelector.MatchLabels[LabelProjectOID] != proj.OID {
+		t.Errorf("SyncNetworkPolicies() allow project, have: %v", from.NamespaceSelector)
+	}
+	if value := namespaces.namespaces["acme-prod"].Annotations[AnnotationNetworkPolicy]; value != NetworkPolicyDefaultDeny {
+		t.Errorf("SyncNetworkPolicies() annotation, have: %q, want: %q", value, NetworkPolicyDefaultDeny)
+	}
+
+	proj.Peers = []NetworkPeer{
+		{Project: "7c1e9a40"},
+		{PodLabels: map[string]string{"app": "ingress"}},
+	}
+	if err := SyncNetworkPolicies(namespaces, policies, proj, ns); err != nil {
+		t.Fatalf("SyncNetworkPolicies() with peers, err: %v", err)
+	}
+	peers, ok := policies.policies[PolicyAllowPeers]
+	if !ok || len(peers.Spec.Ingress[0].From) != 2 {
+		t.Fatalf("SyncNetworkPolicies() allow peers, have: %v", peers)
+	}
+	if from := peers.Spec.Ingress[0].From; from[0].NamespaceSelector.MatchLabels[LabelProjectOID] != "7c1e9a40" || from[1].PodSelector.MatchLabels["app"] != "ingress" {
+		t.Errorf("SyncNetworkPolicies() allow peers from, have: %v", from)
+	}
+
+	proj.Isolated = false
+	if err := SyncNetworkPolicies(namespaces, policies, proj, ns); err != nil {
+		t.Fatalf("SyncNetworkPolicies() of a project no longer isolated, err: %v", err)
+	}
+	if _, ok := namespaces.namespaces["acme-prod"].Annotations[AnnotationNetworkPolicy]; ok || len(policies.policies) != 0 {
+		t.Errorf("SyncNetworkPolicies() of a project no longer isolated, have: %v", policies.policies)
+	}
+}
+
+func TestValidatePeers(t *testing.T) {
+	exists := func(oid string) bool { return oid == "7c1e9a40" }
+	if err := ValidatePeers([]NetworkPeer{{Project: "7c1e9a40", NamespaceLabels: map[string]string{"tier": "web"}}}, exists); err != nil {
+		t.Errorf("ValidatePeers(), err: %v", err)
+	}
+	for _, peer := range []NetworkPeer{
+		{},
+		{Project: "0badf00d"},
+		{Project: "7c1e9a40", PodLabels: map[string]string{"app": "ingress"}},
+	} {
+		if err := ValidatePeers([]NetworkPeer{peer}, exists); err == nil {
+			t.Errorf("ValidatePeers() of %+v, want: error", peer)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+
+	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/pkg/api/errors"
+	"k8s.io/client-go/pkg/api/resource"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+const (
+	// QuotaName - name of the ResourceQuota krak8s keeps in a namespace
+	QuotaName = "krak8s-quota"
+	// LimitRangeName - name of the LimitRange krak8s keeps in a namespace
+	LimitRangeName = "krak8s-limits"
+	// DefaultContainerCPU - CPU request and limit of the containers that
+	// don't set one, in a namespace with a CPU quota and no default_cpu
+	DefaultContainerCPU = "100m"
+	// DefaultContainerMemory - memory request and limit of the containers
+	// that don't set one, in a namespace with a memory quota and no
+	// default_memory
+	DefaultContainerMemory = "128Mi"
+)
+
+// clusterQuotas - the ResourceQuota and LimitRange clients of the namespace
+// on the project's cluster target.
+func clusterQuotas(proj *ProjectObject, ns *NamespaceObject) (v1core.ResourceQuotaInterface, v1core.LimitRangeInterface, error) {
+	clientset, err := ProjectTarget(proj).Clientset()
+	if err != nil {
+		return nil, nil, err
+	}
+	return clientset.Core().ResourceQuotas(ns.Name), clientset.Core().LimitRanges(ns.Name), nil
+}
+
+// syncNamespaceQuota - SyncNamespaceQuota() on the project's cluster target.
+func syncNamespaceQuota(proj *ProjectObject, ns *NamespaceObject) error {
+	quotas, limits, err := clusterQuotas(proj, ns)
+	if err != nil {
+		return err
+	}
+	return SyncNamespaceQuota(quotas, limits, ns)
+}
+
+// namespaceQuotaUsage - NamespaceQuotaUsage() on the project's cluster target.
+func namespaceQuotaUsage(proj *ProjectObject, ns *NamespaceObject) (*NamespaceQuota, error) {
+	quotas, _, err := clusterQuotas(proj, ns)
+	if err != nil {
+		return nil, err
+	}
+	return NamespaceQuotaUsage(quotas)
+}
+
+// ValidateQuota - check that the quota's values are Kubernetes quantities.
+func ValidateQuota(quota *NamespaceQuota) error {
+	if quota == nil {
+		return nil
+	}
+	for name, value := range map[string]string{
+		"cpu":            quota.CPU,
+		"memory":         quota.Memory,
+		"storage":        quota.Storage,
+		"default_cpu":    quota.DefaultCPU,
+		"default_memory": quota.DefaultMemory,
+	} {
+		if value == "" {
+			continue
+		}
+		if _, err := resource.ParseQuantity(value); err != nil {
+			return fmt.Errorf("quota %s %q is not a quantity: %v", name, value, err)
+		}
+	}
+	return nil
+}
+
+// quotaHard - the ResourceQuota hard limits of the quota, the cpu and memory
+// quotas limit both the requests and the limits of the namespace's pods.
+func quotaHard(quota *NamespaceQuota) v1.ResourceList {
+	hard := v1.ResourceList{}
+	if quota == nil {
+		return hard
+	}
+	if quota.CPU != "" {
+		hard[v1.ResourceRequestsCPU] = resource.MustParse(quota.CPU)
+		hard[v1.ResourceLimitsCPU] = resource.MustParse(quota.CPU)
+	}
+	if quota.Memory != "" {
+		hard[v1.ResourceRequestsMemory] = resource.MustParse(quota.Memory)
+		hard[v1.ResourceLimitsMemory] = resource.MustParse(quota.Memory)
+	}
+	if quota.Pods != nil {
+		hard[v1.ResourcePods] = *resource.NewQuantity(int64(*quota.Pods), resource.DecimalSI)
+	}
+	if quota.Storage != "" {
+		hard[v1.ResourceRequestsStorage] = resource.MustParse(quota.Storage)
+	}
+	return hard
+}
+
+// quotaDefaults - the LimitRange container defaults of the quota.  Pods
+// without requests and limits for a resource under quota are rejected, so
+// each of the cpu and memory quotas has a default.
+func quotaDefaults(quota *NamespaceQuota) v1.ResourceList {
+	defaults := v1.ResourceList{}
+	if quota == nil {
+		return defaults
+	}
+	if quota.CPU != "" {
+		value := quota.DefaultCPU
+		if value == "" {
+			value = DefaultContainerCPU
+		}
+		defaults[v1.ResourceCPU] = resource.MustParse(value)
+	}
+	if quota.Memory != "" {
+		value := quota.DefaultMemory
+		if value == "" {
+			value = DefaultContainerMemory
+		}
+		defaults[v1.ResourceMemory] = resource.MustParse(value)
+	}
+	return defaults
+}
+
+func resourceListsEqual(a, b v1.ResourceList) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, quantity := range a {
+		other, ok := b[name]
+		if !ok || quantity.Cmp(other) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// SyncNamespaceQuota - create, update or delete the ResourceQuota and
+// LimitRange of the namespace to match its quota.
+func SyncNamespaceQuota(quotas v1core.ResourceQuotaInterface, limits v1core.LimitRangeInterface, ns *NamespaceObject) error {
+	if err := syncResourceQuota(quotas, ns); err != nil {
+		return err
+	}
+	return syncLimitRange(limits, ns)
+}
+
+func syncResourceQuota(quotas v1core.ResourceQuotaInterface, ns *NamespaceObject) error {
+	hard := quotaHard(ns.Quota)
+	existing, err := quotas.Get(QuotaName)
+	if errors.IsNotFound(err) {
+		if len(hard) == 0 {
+			return nil
+		}
+		_, err = quotas.Create(&v1.ResourceQuota{
+			ObjectMeta: v1.ObjectMeta{Name: QuotaName, Labels: map[string]string{LabelNamespaceOID: ns.OID}},
+			Spec:       v1.ResourceQuotaSpec{Hard: hard},
+		})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if len(hard) == 0 {
+		return quotas.Delete(QuotaName, &v1.DeleteOptions{})
+	}
+	if resourceListsEqual(existing.Spec.Hard, hard) {
+		return nil
+	}
+	existing.Spec.Hard = hard
+	_, err = quotas.Update(existing)
+	return err
+}
+
+func syncLimitRange(limits v1core.LimitRangeInterface, ns *NamespaceObject) error {
+	defaults := quotaDefaults(ns.Quota)
+	item := v1.LimitRangeItem{Type: v1.LimitTypeContainer, Default: defaults, DefaultRequest: defaults}
+	existing, err := limits.Get(LimitRangeName)
+	if errors.IsNotFound(err) {
+		if len(defaults) == 0 {
+			return nil
+		}
+		_, err = limits.Create(&v1.LimitRange{
+			ObjectMeta: v1.ObjectMeta{Name: LimitRangeName, Labels: map[string]string{LabelNamespaceOID: ns.OID}},
+			Spec:       v1.LimitRangeSpec{Limits: []v1.LimitRangeItem{item}},
+		})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if len(defaults) == 0 {
+		return limits.Delete(LimitRangeName, &v1.DeleteOptions{})
+	}
+	if len(existing.Spec.Limits) == 1 && existing.Spec.Limits[0].Type == v1.LimitTypeContainer &&
+		resourceListsEqual(existing.Spec.Limits[0].Default, defaults) &&
+		resourceListsEqual(existing.Spec.Limits[0].DefaultRequest, defaults) {
+		return nil
+	}
+	existing.Spec.Limits = []v1.LimitRangeItem{item}
+	_, err = limits.Update(existing)
+	return err
+}
+
+// NamespaceQuotaUsage - the current usage of the namespace's quota, nil if
+// the namespace has no ResourceQuota.
+func NamespaceQuotaUsage(quotas v1core.ResourceQuotaInterface) (*NamespaceQuota, error) {
+	existing, err := quotas.Get(QuotaName)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	usage := &NamespaceQuota{}
+	used := existing.Status.Used
+	if quantity, ok := used[v1.ResourceRequestsCPU]; ok {
+		usage.CPU = quantity.String()
+	}
+	if quantity, ok := used[v1.ResourceRequestsMemory]; ok {
+		usage.Memory = quantity.String()
+	}
+	if quantity, ok := used[v1.ResourcePods]; ok {
+		pods := int(quantity.Value())
+		usage.Pods = &pods
+	}
+	if quantity, ok := used[v1.ResourceRequestsStorage]; ok {
+		usage.Storage = quantity.String()
+	}
+	return usage, nil
+}
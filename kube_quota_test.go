@@ -0,0 +1,152 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"testing"
+
+	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/pkg/api"
+	"k8s.io/client-go/pkg/api/errors"
+	"k8s.io/client-go/pkg/api/resource"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+type fakeQuotas struct {
+	v1core.ResourceQuotaInterface
+	quota *v1.ResourceQuota
+}
+
+func (f *fakeQuotas) Create(quota *v1.ResourceQuota) (*v1.ResourceQuota, error) {
+	f.quota = quota
+	return quota, nil
+}
+
+func (f *fakeQuotas) Update(quota *v1.ResourceQuota) (*v1.ResourceQuota, error) {
+	f.quota = quota
+	return quota, nil
+}
+
+func (f *fakeQuotas) Get(name string) (*v1.ResourceQuota, error) {
+	if f.quota == nil {
+		return nil, errors.NewNotFound(api.Resource("resourcequotas"), name)
+	}
+	return f.quota, nil
+}
+
+func (f *fakeQuotas) Delete(name string, options *v1.DeleteOptions) error {
+	f.quota = nil
+	return nil
+}
+
+type fakeLimits struct {
+	v1core.LimitRangeInterface
+	limits *v1.LimitRange
+}
+
+func (f *fakeLimits) Create(limits *v1.LimitRange) (*v1.LimitRange, error) {
+	f.limits = limits
+	return limits, nil
+}
+
+func (f *fakeLimits) Update(limits *v1.LimitRange) (*v1.LimitRange, error) {
+	f.limits = limits
+	return limits, nil
+}
+
+func (f *fakeLimits) Get(name string) (*v1.LimitRange, error) {
+	if f.limits == nil {
+		return nil, errors.NewNotFound(api.Resource("limitranges"), name)
+	}
+	return f.limits, nil
+}
+
+func (f *fakeLimits) Delete(name string, options *v1.DeleteOptions) error {
+	f.limits = nil
+	return nil
+}
+
+func TestSyncNamespaceQuota(t *testing.T) {
+	quotas := &fakeQuotas{}
+	limits := &fakeLimits{}
+	pods := 20
+	ns := &NamespaceObject{OID: "da9871c7", Name: "acme-prod", Quota: &NamespaceQuota{CPU: "4", Memory: "16Gi", Pods: &pods, DefaultCPU: "250m"}}
+
+	if err := SyncNamespaceQuota(quotas, limits, ns); err != nil {
+		t.Fatalf("SyncNamespaceQuota(), err: %v", err)
+	}
+	hard := quotas.quota.Spec.Hard
+	want := v1.ResourceList{
+		v1.ResourceRequestsCPU:    resource.MustParse("4"),
+		v1.ResourceLimitsCPU:      resource.MustParse("4"),
+		v1.ResourceRequestsMemory: resource.MustParse("16Gi"),
+		v1.ResourceLimitsMemory:   resource.MustParse("16Gi"),
+		v1.ResourcePods:           resource.MustParse("20"),
+	}
+	if !resourceListsEqual(hard, want) {
+		t.Errorf("SyncNamespaceQuota() hard, have: %v, want: %v", hard, want)
+	}
+	defaults := limits.limits.Spec.Limits[0].Default
+	want = v1.ResourceList{v1.ResourceCPU: resource.MustParse("250m"), v1.ResourceMemory: resource.MustParse(DefaultContainerMemory)}
+	if !resourceListsEqual(defaults, want) {
+		t.Errorf("SyncNamespaceQuota() defaults, have: %v, want: %v", defaults, want)
+	}
+
+	// a quota changed outside of krak8s is restored
+	quotas.quota.Spec.Hard[v1.ResourcePods] = resource.MustParse("200")
+	if err := SyncNamespaceQuota(quotas, limits, ns); err != nil {
+		t.Fatalf("SyncNamespaceQuota() of a changed quota, err: %v", err)
+	}
+	if pods := quotas.quota.Spec.Hard[v1.ResourcePods]; pods.Value() != 20 {
+		t.Errorf("SyncNamespaceQuota() of a changed quota pods, have: %s, want: 20", pods.String())
+	}
+
+	quotas.quota.Status.Used = v1.ResourceList{v1.ResourceRequestsCPU: resource.MustParse("1500m"), v1.ResourcePods: resource.MustParse("3")}
+	usage, err := NamespaceQuotaUsage(quotas)
+	if err != nil || usage.CPU != "1500m" || usage.Pods == nil || *usage.Pods != 3 || usage.Memory != "" {
+		t.Errorf("NamespaceQuotaUsage(), have: %+v %v", usage, err)
+	}
+
+	// storage only needs no limit range
+	ns.Quota = &NamespaceQuota{Storage: "100Gi"}
+	if err := SyncNamespaceQuota(quotas, limits, ns); err != nil {
+		t.Fatalf("SyncNamespaceQuota() of a storage quota, err: %v", err)
+	}
+	if len(quotas.quota.Spec.Hard) != 1 || limits.limits != nil {
+		t.Errorf("SyncNamespaceQuota() of a storage quota, have: %v %v", quotas.quota.Spec.Hard, limits.limits)
+	}
+
+	ns.Quota = nil
+	if err := SyncNamespaceQuota(quotas, limits, ns); err != nil {
+		t.Fatalf("SyncNamespaceQuota() of no quota, err: %v", err)
+	}
+	if quotas.quota != nil {
+		t.Errorf("SyncNamespaceQuota() of no quota, have: %v", quotas.quota)
+	}
+	if usage, err := NamespaceQuotaUsage(quotas); usage != nil || err != nil {
+		t.Errorf("NamespaceQuotaUsage() of no quota, have: %+v %v", usage, err)
+	}
+}
+
+func TestValidateQuota(t *testing.T) {
+	if err := ValidateQuota(&NamespaceQuota{CPU: "500m", Memory: "1Gi", Storage: "10G"}); err != nil {
+		t.Errorf("ValidateQuota(), err: %v", err)
+	}
+	if err := ValidateQuota(&NamespaceQuota{Memory: "lots"}); err == nil {
+		t.Error("ValidateQuota() of memory lots, want: error")
+	}
+}
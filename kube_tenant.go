@@ -0,0 +1,350 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/golang/glog"
+	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
+	extclient "k8s.io/client-go/kubernetes/typed/extensions/v1beta1"
+	rbacclient "k8s.io/client-go/kubernetes/typed/rbac/v1alpha1"
+	"k8s.io/client-go/pkg/api/errors"
+	"k8s.io/client-go/pkg/api/v1"
+	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
+	rbac "k8s.io/client-go/pkg/apis/rbac/v1alpha1"
+	"k8s.io/client-go/pkg/util/wait"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	// TenantNamespace - Kubernetes namespace of the projects' ServiceAccounts
+	TenantNamespace = "krak8s-tenants"
+	// TenantRoleName - name of the Role, and its RoleBinding, giving the
+	// project's ServiceAccount access to a namespace of the project
+	TenantRoleName = "krak8s-tenant"
+	// TenantPodSecurityRoleName - name of the Role, and its RoleBinding,
+	// letting the project's ServiceAccount, and the ServiceAccounts of a
+	// namespace of the project, run pods under the TenantPodSecurityPolicy
+	TenantPodSecurityRoleName = "krak8s-tenant-pods"
+	// TenantPodSecurityPolicy - name of the PodSecurityPolicy of the tenants'
+	// pods
+	TenantPodSecurityPolicy = "krak8s-tenant"
+)
+
+// how often, and how long, to wait for the token controller to fill in the
+// token of a ServiceAccount's token secret
+var (
+	tenantTokenInterval = 500 * time.Millisecond
+	tenantTokenTimeout  = 30 * time.Second
+)
+
+// tenantVerbs - the verbs of the objects tenants manage
+var tenantVerbs = []string{"get", "list", "watch", "create", "update", "patch", "delete", "deletecollection"}
+
+// tenantRules - tenants run their workloads in their namespaces, but only
+// read the quota, limits and network policies krak8s keeps there.  DaemonSets
+// would schedule pods outside of the project's node pools, and are left out.
+// The pods of the workloads are kept to the project's node pools by the
+// namespace's node selector, and to unprivileged pods by the
+// TenantPodSecurityPolicy, as the Role can't look in to pod specs.
+var tenantRules = []rbac.PolicyRule{
+	{
+		APIGroups: []string{""},
+		Resources: []string{"pods", "pods/attach", "pods/exec", "pods/log", "pods/portforward",
+			"services", "endpoints", "configmaps", "secrets", "persistentvolumeclaims",
+			"replicationcontrollers"},
+		Verbs: tenantVerbs,
+	},
+	{
+		APIGroups: []string{""},
+		Resources: []string{"events", "resourcequotas", "limitranges", "serviceaccounts"},
+		Verbs:     []string{"get", "list", "watch"},
+	},
+	{
+		APIGroups: []string{"apps", "extensions"},
+		Resources: []string{"deployments", "deployments/scale", "replicasets", "replicasets/scale",
+			"statefulsets", "ingresses"},
+		Verbs: tenantVerbs,
+	},
+	{
+		APIGroups: []string{"extensions"},
+		Resources: []string{"networkpolicies"},
+		Verbs:     []string{"get", "list", "watch"},
+	},
+	{
+		APIGroups: []string{"batch"},
+		Resources: []string{"jobs", "cronjobs", "scheduledjobs"},
+		Verbs:     tenantVerbs,
+	},
+	{
+		APIGroups: []string{"autoscaling"},
+		Resources: []string{"horizontalpodautoscalers"},
+		Verbs:     tenantVerbs,
+	},
+}
+
+// tenantPodSecurityRules - the use of the TenantPodSecurityPolicy, the only
+// policy the PodSecurityPolicy admission controller lets the tenants' pods
+// run under
+var tenantPodSecurityRules = []rbac.PolicyRule{
+	{
+		APIGroups:     []string{"extensions"},
+		Resources:     []string{"podsecuritypolicies"},
+		ResourceNames: []string{TenantPodSecurityPolicy},
+		Verbs:         []string{"use"},
+	},
+}
+
+// tenantPodSecurityPolicySpec - unprivileged pods, without the host's
+// network, processes or files, and only the volumes of the namespace
+var tenantPodSecurityPolicySpec = extensions.PodSecurityPolicySpec{
+	Privileged: false,
+	Volumes: []extensions.FSType{extensions.ConfigMap, extensions.DownwardAPI, extensions.EmptyDir,
+		extensions.PersistentVolumeClaim, extensions.Secret},
+	HostNetwork:        false,
+	HostPID:            false,
+	HostIPC:            false,
+	SELinux:            extensions.SELinuxStrategyOptions{Rule: extensions.SELinuxStrategyRunAsAny},
+	RunAsUser:          extensions.RunAsUserStrategyOptions{Rule: extensions.RunAsUserStrategyRunAsAny},
+	SupplementalGroups: extensions.SupplementalGroupsStrategyOptions{Rule: extensions.SupplementalGroupsStrategyRunAsAny},
+	FSGroup:            extensions.FSGroupStrategyOptions{Rule: extensions.FSGroupStrategyRunAsAny},
+}
+
+// TenantAccountName - name of the project's ServiceAccount
+func TenantAccountName(proj *ProjectObject) string {
+	return "project-" + proj.OID
+}
+
+func tenantTokenName(proj *ProjectObject) string {
+	return TenantAccountName(proj) + "-token"
+}
+
+// syncRole - create or update the Role, and its RoleBinding of the same name,
+// giving the subjects the rules.
+func syncRole(roles rbacclient.RoleInterface, bindings rbacclient.RoleBindingInterface, name string, rules []rbac.PolicyRule, subjects []rbac.Subject, labels map[string]string) error {
+	role, err := roles.Get(name)
+	if errors.IsNotFound(err) {
+		_, err = roles.Create(&rbac.Role{
+			ObjectMeta: v1.ObjectMeta{Name: name, Labels: labels},
+			Rules:      rules,
+		})
+	} else if err == nil && !reflect.DeepEqual(role.Rules, rules) {
+		role.Rules = rules
+		_, err = roles.Update(role)
+	}
+	if err != nil {
+		return err
+	}
+
+	roleRef := rbac.RoleRef{APIGroup: rbac.GroupName, Kind: "Role", Name: name}
+	binding, err := bindings.Get(name)
+	if errors.IsNotFound(err) {
+		_, err = bindings.Create(&rbac.RoleBinding{
+			ObjectMeta: v1.ObjectMeta{Name: name, Labels: labels},
+			Subjects:   subjects,
+			RoleRef:    roleRef,
+		})
+	} else if err == nil && (!reflect.DeepEqual(binding.Subjects, subjects) || binding.RoleRef != roleRef) {
+		binding.Subjects = subjects
+		binding.RoleRef = roleRef
+		_, err = bindings.Update(binding)
+	}
+	return err
+}
+
+// SyncTenantRole - create or update the Roles and RoleBindings of the
+// namespace that give the project's ServiceAccount access to it, and its
+// ServiceAccounts, those the pods of the workloads run as, the use of the
+// TenantPodSecurityPolicy.
+func SyncTenantRole(roles rbacclient.RoleInterface, bindings rbacclient.RoleBindingInterface, proj *ProjectObject, ns *NamespaceObject) error {
+	labels := map[string]string{LabelNamespaceOID: ns.OID}
+	tenant := rbac.Subject{Kind: "ServiceAccount", Name: TenantAccountName(proj), Namespace: TenantNamespace}
+	if err := syncRole(roles, bindings, TenantRoleName, tenantRules, []rbac.Subject{tenant}, labels); err != nil {
+		return err
+	}
+	pods := rbac.Subject{Kind: "Group", Name: "system:serviceaccounts:" + ns.Name}
+	return syncRole(roles, bindings, TenantPodSecurityRoleName, tenantPodSecurityRules, []rbac.Subject{tenant, pods}, labels)
+}
+
+// SyncTenantPodSecurityPolicy - create or update the TenantPodSecurityPolicy.
+// A cluster that doesn't serve PodSecurityPolicies is left without it.
+func SyncTenantPodSecurityPolicy(policies extclient.PodSecurityPolicyInterface) error {
+	policy, err := policies.Get(TenantPodSecurityPolicy)
+	if errors.IsNotFound(err) {
+		_, err = policies.Create(&extensions.PodSecurityPolicy{
+			ObjectMeta: v1.ObjectMeta{Name: TenantPodSecurityPolicy},
+			Spec:       tenantPodSecurityPolicySpec,
+		})
+		if errors.IsNotFound(err) {
+			glog.Warningf("cluster doesn't serve PodSecurityPolicies, tenant pods run without the %s policy", TenantPodSecurityPolicy)
+			return nil
+		}
+	} else if err == nil && !reflect.DeepEqual(policy.Spec, tenantPodSecurityPolicySpec) {
+		policy.Spec = tenantPodSecurityPolicySpec
+		_, err = policies.Update(policy)
+	}
+	return err
+}
+
+// CreateTenantAccount - create the TenantNamespace, the project's
+// ServiceAccount and its token secret, those that don't exist.
+func CreateTenantAccount(namespaces v1core.NamespaceInterface, accounts v1core.ServiceAccountInterface, secrets v1core.SecretInterface, proj *ProjectObject) error {
+	_, err := namespaces.Create(&v1.Namespace{ObjectMeta: v1.ObjectMeta{Name: TenantNamespace}})
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return err
+	}
+	labels := map[string]string{LabelProject: proj.Name, LabelProjectOID: proj.OID}
+	_, err = accounts.Create(&v1.ServiceAccount{
+		ObjectMeta: v1.ObjectMeta{Name: TenantAccountName(proj), Labels: labels},
+	})
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return err
+	}
+	_, err = secrets.Create(&v1.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        tenantTokenName(proj),
+			Labels:      labels,
+			Annotations: map[string]string{v1.ServiceAccountNameKey: TenantAccountName(proj)},
+		},
+		Type: v1.SecretTypeServiceAccountToken,
+	})
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+// TenantToken - the token secret of the project's ServiceAccount, once the
+// token controller has filled in its token.  A NotFound error when there is
+// no token secret, it was revoked or never created.
+func TenantToken(secrets v1core.SecretInterface, proj *ProjectObject) (*v1.Secret, error) {
+	var token *v1.Secret
+	err := wait.Poll(tenantTokenInterval, tenantTokenTimeout, func() (bool, error) {
+		secret, err := secrets.Get(tenantTokenName(proj))
+		if err != nil {
+			return false, err
+		}
+		if len(secret.Data[v1.ServiceAccountTokenKey]) == 0 {
+			return false, nil
+		}
+		token = secret
+		return true, nil
+	})
+	return token, err
+}
+
+// RevokeTenantToken - delete the token secret of the project's
+// ServiceAccount, the kubeconfigs with its token stop working.
+func RevokeTenantToken(secrets v1core.SecretInterface, proj *ProjectObject) error {
+	err := secrets.Delete(tenantTokenName(proj), &v1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// RevokeTenantAccess - delete the token secret and the project's
+// ServiceAccount.  The RoleBindings are left in place, and apply again to a
+// ServiceAccount created by the next rotation.
+func RevokeTenantAccess(accounts v1core.ServiceAccountInterface, secrets v1core.SecretInterface, proj *ProjectObject) error {
+	if err := RevokeTenantToken(secrets, proj); err != nil {
+		return err
+	}
+	err := accounts.Delete(TenantAccountName(proj), &v1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// TenantKubeconfig - a kubeconfig for the server, with the token and CA
+// certificate of the token secret, its context named after the project and
+// set to the namespace.
+func TenantKubeconfig(server, cluster string, proj *ProjectObject, namespace string, token *v1.Secret) ([]byte, error) {
+	user := TenantAccountName(proj)
+	config := clientcmdapi.NewConfig()
+	config.Clusters[cluster] = &clientcmdapi.Cluster{
+		Server:                   server,
+		CertificateAuthorityData: token.Data[v1.ServiceAccountRootCAKey],
+	}
+	config.AuthInfos[user] = &clientcmdapi.AuthInfo{Token: string(token.Data[v1.ServiceAccountTokenKey])}
+	config.Contexts[proj.Name] = &clientcmdapi.Context{Cluster: cluster, AuthInfo: user, Namespace: namespace}
+	config.CurrentContext = proj.Name
+	return clientcmd.Write(*config)
+}
+
+// syncTenantAccess - SyncTenantPodSecurityPolicy(), SyncTenantRole() of the
+// namespaces and CreateTenantAccount() on the project's cluster target.
+func syncTenantAccess(proj *ProjectObject, namespaces ...*NamespaceObject) error {
+	clientset, err := ProjectTarget(proj).Clientset()
+	if err != nil {
+		return err
+	}
+	if err = SyncTenantPodSecurityPolicy(clientset.Extensions().PodSecurityPolicies()); err != nil {
+		return err
+	}
+	for _, ns := range namespaces {
+		if err = SyncTenantRole(clientset.Rbac().Roles(ns.Name), clientset.Rbac().RoleBindings(ns.Name), proj, ns); err != nil {
+			return err
+		}
+	}
+	return CreateTenantAccount(clientset.Core().Namespaces(), clientset.Core().ServiceAccounts(TenantNamespace),
+		clientset.Core().Secrets(TenantNamespace), proj)
+}
+
+// issueTenantKubeconfig - the kubeconfig of the project's ServiceAccount on
+// the project's cluster target, with access to the namespaces, its context
+// set to the first namespace.  Only rotate changes the cluster: the
+// ServiceAccount's token is replaced, after the access of the namespaces is
+// brought up to date and the ServiceAccount created, as after a revocation.
+func issueTenantKubeconfig(proj *ProjectObject, namespaces []*NamespaceObject, rotate bool) ([]byte, error) {
+	target := ProjectTarget(proj)
+	clientset, err := target.Clientset()
+	if err != nil {
+		return nil, err
+	}
+	server, err := target.APIServer()
+	if err != nil {
+		return nil, err
+	}
+	secrets := clientset.Core().Secrets(TenantNamespace)
+	if rotate {
+		if err = RevokeTenantToken(secrets, proj); err != nil {
+			return nil, err
+		}
+		if err = syncTenantAccess(proj, namespaces...); err != nil {
+			return nil, err
+		}
+	}
+	token, err := TenantToken(secrets, proj)
+	if err != nil {
+		return nil, err
+	}
+	return TenantKubeconfig(server, target.Name, proj, namespaces[0].Name, token)
+}
+
+// revokeTenantAccess - RevokeTenantAccess() on the project's cluster target.
+func revokeTenantAccess(proj *ProjectObject) error {
+	clientset, err := ProjectTarget(proj).Clientset()
+	if err != nil {
+		return err
+	}
+	return RevokeTenantAccess(clientset.Core().ServiceAccounts(TenantNamespace), clientset.Core().Secrets(TenantNamespace), proj)
+}
@@ -0,0 +1,302 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
+	extclient "k8s.io/client-go/kubernetes/typed/extensions/v1beta1"
+	rbacclient "k8s.io/client-go/kubernetes/typed/rbac/v1alpha1"
+	"k8s.io/client-go/pkg/api"
+	"k8s.io/client-go/pkg/api/errors"
+	"k8s.io/client-go/pkg/api/v1"
+	extensions "k8s.io/client-go/pkg/apis/extensions/v1beta1"
+	rbac "k8s.io/client-go/pkg/apis/rbac/v1alpha1"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+type fakeAccounts struct {
+	v1core.ServiceAccountInterface
+	accounts map[string]*v1.ServiceAccount
+}
+
+func (f *fakeAccounts) Create(account *v1.ServiceAccount) (*v1.ServiceAccount, error) {
+	if _, ok := f.accounts[account.Name]; ok {
+		return nil, errors.NewAlreadyExists(api.Resource("serviceaccounts"), account.Name)
+	}
+	f.accounts[account.Name] = account
+	return account, nil
+}
+
+func (f *fakeAccounts) Delete(name string, options *v1.DeleteOptions) error {
+	if _, ok := f.accounts[name]; !ok {
+		return errors.NewNotFound(api.Resource("serviceaccounts"), name)
+	}
+	delete(f.accounts, name)
+	return nil
+}
+
+// fakeSecrets - token secrets, filled in on creation as the token controller
+// would with a new token each time.
+type fakeSecrets struct {
+	v1core.SecretInterface
+	secrets map[string]*v1.Secret
+	issued  int
+}
+
+func (f *fakeSecrets) Create(secret *v1.Secret) (*v1.Secret, error) {
+	if _, ok := f.secrets[secret.Name]; ok {
+		return nil, errors.NewAlreadyExists(api.Resource("secrets"), secret.Name)
+	}
+	f.issued++
+	secret.Data = map[string][]byte{
+		v1.ServiceAccountTokenKey:  []byte("token-" + strconv.Itoa(f.issued)),
+		v1.ServiceAccountRootCAKey: []byte("ca"),
+	}
+	f.secrets[secret.Name] = secret
+	return secret, nil
+}
+
+func (f *fakeSecrets) Get(name string) (*v1.Secret, error) {
+	if secret, ok := f.secrets[name]; ok {
+		return secret, nil
+	}
+	return nil, errors.NewNotFound(api.Resource("secrets"), name)
+}
+
+func (f *fakeSecrets) Delete(name string, options *v1.DeleteOptions) error {
+	if _, ok := f.secrets[name]; !ok {
+		return errors.NewNotFound(api.Resource("secrets"), name)
+	}
+	delete(f.secrets, name)
+	return nil
+}
+
+// fakePodSecurityPolicies - the PodSecurityPolicies of a cluster, or of one
+// that doesn't serve them when unserved.
+type fakePodSecurityPolicies struct {
+	extclient.PodSecurityPolicyInterface
+	policy   *extensions.PodSecurityPolicy
+	unserved bool
+}
+
+func (f *fakePodSecurityPolicies) Create(policy *extensions.PodSecurityPolicy) (*extensions.PodSecurityPolicy, error) {
+	if f.unserved {
+		return nil, errors.NewNotFound(api.Resource("podsecuritypolicies"), policy.Name)
+	}
+	f.policy = policy
+	return policy, nil
+}
+
+func (f *fakePodSecurityPolicies) Update(policy *extensions.PodSecurityPolicy) (*extensions.PodSecurityPolicy, error) {
+	f.policy = policy
+	return policy, nil
+}
+
+func (f *fakePodSecurityPolicies) Get(name string) (*extensions.PodSecurityPolicy, error) {
+	if f.policy == nil {
+		return nil, errors.NewNotFound(api.Resource("podsecuritypolicies"), name)
+	}
+	return f.policy, nil
+}
+
+// fakeRoles - the Roles and RoleBindings of a namespace by name
+type fakeRoles struct {
+	rbacclient.RoleInterface
+	roles map[string]*rbac.Role
+}
+
+func (f *fakeRoles) Create(role *rbac.Role) (*rbac.Role, error) {
+	f.roles[role.Name] = role
+	return role, nil
+}
+
+func (f *fakeRoles) Update(role *rbac.Role) (*rbac.Role, error) {
+	f.roles[role.Name] = role
+	return role, nil
+}
+
+func (f *fakeRoles) Get(name string) (*rbac.Role, error) {
+	if role, ok := f.roles[name]; ok {
+		return role, nil
+	}
+	return nil, errors.NewNotFound(api.Resource("roles"), name)
+}
+
+type fakeRoleBindings struct {
+	rbacclient.RoleBindingInterface
+	bindings map[string]*rbac.RoleBinding
+}
+
+func (f *fakeRoleBindings) Create(binding *rbac.RoleBinding) (*rbac.RoleBinding, error) {
+	f.bindings[binding.Name] = binding
+	return binding, nil
+}
+
+func (f *fakeRoleBindings) Update(binding *rbac.RoleBinding) (*rbac.RoleBinding, error) {
+	f.bindings[binding.Name] = binding
+	return binding, nil
+}
+
+func (f *fakeRoleBindings) Get(name string) (*rbac.RoleBinding, error) {
+	if binding, ok := f.bindings[name]; ok {
+		return binding, nil
+	}
+	return nil, errors.NewNotFound(api.Resource("rolebindings"), name)
+}
+
+func TestSyncTenantRole(t *testing.T) {
+	roles := &fakeRoles{roles: map[string]*rbac.Role{}}
+	bindings := &fakeRoleBindings{bindings: map[string]*rbac.RoleBinding{}}
+	proj := &ProjectObject{OID: "30299bea", Name: "acme"}
+	ns := &NamespaceObject{OID: "da9871c7", Name: "acme-prod"}
+
+	if err := SyncTenantRole(roles, bindings, proj, ns); err != nil {
+		t.Fatalf("SyncTenantRole(), err: %v", err)
+	}
+	role := roles.roles[TenantRoleName]
+	if role == nil || len(role.Rules) != len(tenantRules) {
+		t.Errorf("SyncTenantRole() role, have: %v", role)
+	}
+	for _, rule := range role.Rules {
+		for _, verb := range rule.Verbs {
+			if verb == "*" {
+				t.Errorf("SyncTenantRole() role rule %v, want no wildcard verbs", rule)
+			}
+		}
+	}
+	binding := bindings.bindings[TenantRoleName]
+	subject := binding.Subjects[0]
+	if subject.Name != "project-30299bea" || subject.Namespace != TenantNamespace || binding.RoleRef.Name != TenantRoleName {
+		t.Errorf("SyncTenantRole() binding, have: %+v", binding)
+	}
+	pods := bindings.bindings[TenantPodSecurityRoleName]
+	if pods == nil || len(pods.Subjects) != 2 || pods.Subjects[1].Kind != "Group" || pods.Subjects[1].Name != "system:serviceaccounts:acme-prod" {
+		t.Errorf("SyncTenantRole() pod security binding, have: %+v", pods)
+	}
+	if rules := roles.roles[TenantPodSecurityRoleName].Rules; len(rules) != 1 || rules[0].Verbs[0] != "use" || rules[0].ResourceNames[0] != TenantPodSecurityPolicy {
+		t.Errorf("SyncTenantRole() pod security role, have: %v", rules)
+	}
+
+	// a role changed outside of krak8s is restored
+	role.Rules = append(role.Rules, rbac.PolicyRule{APIGroups: []string{""}, Resources: []string{"resourcequotas"}, Verbs: []string{"*"}})
+	if err := SyncTenantRole(roles, bindings, proj, ns); err != nil {
+		t.Fatalf("SyncTenantRole() of a changed role, err: %v", err)
+	}
+	if len(roles.roles[TenantRoleName].Rules) != len(tenantRules) {
+		t.Errorf("SyncTenantRole() of a changed role, have: %v", roles.roles[TenantRoleName].Rules)
+	}
+}
+
+func TestSyncTenantPodSecurityPolicy(t *testing.T) {
+	policies := &fakePodSecurityPolicies{}
+	if err := SyncTenantPodSecurityPolicy(policies); err != nil {
+		t.Fatalf("SyncTenantPodSecurityPolicy(), err: %v", err)
+	}
+	if policies.policy == nil || policies.policy.Spec.Privileged || policies.policy.Spec.HostNetwork {
+		t.Errorf("SyncTenantPodSecurityPolicy() policy, have: %+v", policies.policy)
+	}
+	for _, volume := range policies.policy.Spec.Volumes {
+		if volume == extensions.HostPath || volume == extensions.All {
+			t.Errorf("SyncTenantPodSecurityPolicy() policy allows %s volumes", volume)
+		}
+	}
+
+	// a policy changed outside of krak8s is restored
+	policies.policy.Spec.Privileged = true
+	if err := SyncTenantPodSecurityPolicy(policies); err != nil || policies.policy.Spec.Privileged {
+		t.Errorf("SyncTenantPodSecurityPolicy() of a privileged policy, have: %v %+v", err, policies.policy.Spec)
+	}
+
+	if err := SyncTenantPodSecurityPolicy(&fakePodSecurityPolicies{unserved: true}); err != nil {
+		t.Errorf("SyncTenantPodSecurityPolicy() without PodSecurityPolicies, err: %v", err)
+	}
+}
+
+func TestTenantToken(t *testing.T) {
+	tenantTokenInterval = time.Millisecond
+	namespaces := &fakeNamespaces{namespaces: map[string]*v1.Namespace{}}
+	accounts := &fakeAccounts{accounts: map[string]*v1.ServiceAccount{}}
+	secrets := &fakeSecrets{secrets: map[string]*v1.Secret{}}
+	proj := &ProjectObject{OID: "30299bea", Name: "acme"}
+
+	// reading the token doesn't create the account
+	if _, err := TenantToken(secrets, proj); !errors.IsNotFound(err) {
+		t.Errorf("TenantToken() before CreateTenantAccount(), want NotFound, have: %v", err)
+	}
+	if len(accounts.accounts) != 0 || len(namespaces.namespaces) != 0 {
+		t.Errorf("TenantToken() created %v %v", accounts.accounts, namespaces.namespaces)
+	}
+
+	if err := CreateTenantAccount(namespaces, accounts, secrets, proj); err != nil {
+		t.Fatalf("CreateTenantAccount(), err: %v", err)
+	}
+	if _, ok := namespaces.namespaces[TenantNamespace]; !ok {
+		t.Errorf("CreateTenantAccount() didn't create the %s namespace", TenantNamespace)
+	}
+	token, err := TenantToken(secrets, proj)
+	if err != nil {
+		t.Fatalf("TenantToken(), err: %v", err)
+	}
+	if token.Annotations[v1.ServiceAccountNameKey] != "project-30299bea" || string(token.Data[v1.ServiceAccountTokenKey]) != "token-1" {
+		t.Errorf("TenantToken() token, have: %+v", token)
+	}
+
+	// the token is kept until rotated
+	if err = CreateTenantAccount(namespaces, accounts, secrets, proj); err != nil {
+		t.Fatalf("CreateTenantAccount() again, err: %v", err)
+	}
+	if token, err = TenantToken(secrets, proj); err != nil || string(token.Data[v1.ServiceAccountTokenKey]) != "token-1" {
+		t.Errorf("TenantToken() again, have: %v %v", token, err)
+	}
+	if err = RevokeTenantToken(secrets, proj); err != nil {
+		t.Fatalf("RevokeTenantToken(), err: %v", err)
+	}
+	if err = CreateTenantAccount(namespaces, accounts, secrets, proj); err != nil {
+		t.Fatalf("CreateTenantAccount() after RevokeTenantToken(), err: %v", err)
+	}
+	if token, err = TenantToken(secrets, proj); err != nil || string(token.Data[v1.ServiceAccountTokenKey]) != "token-2" {
+		t.Errorf("TenantToken() after RevokeTenantToken(), have: %v %v", token, err)
+	}
+
+	data, err := TenantKubeconfig("https://api.acme.example.com", "east", proj, "acme-prod", token)
+	if err != nil {
+		t.Fatalf("TenantKubeconfig(), err: %v", err)
+	}
+	config, err := clientcmd.Load(data)
+	if err != nil {
+		t.Fatalf("TenantKubeconfig() doesn't load, err: %v", err)
+	}
+	context := config.Contexts[config.CurrentContext]
+	if context == nil || context.Namespace != "acme-prod" || config.Clusters["east"].Server != "https://api.acme.example.com" ||
+		config.AuthInfos[context.AuthInfo].Token != "token-2" {
+		t.Errorf("TenantKubeconfig(), have: %s", data)
+	}
+
+	if err = RevokeTenantAccess(accounts, secrets, proj); err != nil {
+		t.Fatalf("RevokeTenantAccess(), err: %v", err)
+	}
+	if len(accounts.accounts) != 0 || len(secrets.secrets) != 0 {
+		t.Errorf("RevokeTenantAccess(), have: %v %v", accounts.accounts, secrets.secrets)
+	}
+	if err = RevokeTenantAccess(accounts, secrets, proj); err != nil {
+		t.Errorf("RevokeTenantAccess() of a revoked project, err: %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"krak8s/app"
+
+	"github.com/goadesign/goa"
+	"github.com/golang/glog"
+	"k8s.io/client-go/pkg/api/errors"
+)
+
+// KubeconfigController implements the kubeconfig resource.
+type KubeconfigController struct {
+	*goa.Controller
+	ds      *DataStore
+	backend *Runner
+}
+
+// NewKubeconfigController creates a kubeconfig controller.
+func NewKubeconfigController(service *goa.Service, store *DataStore, backend *Runner) *KubeconfigController {
+	return &KubeconfigController{
+		Controller: service.NewController("KubeconfigController"),
+		ds:         store,
+		backend:    backend,
+	}
+}
+
+// tenantNamespaces - the project's namespaces that krak8s created Kubernetes
+// namespaces for.
+func (c *KubeconfigController) tenantNamespaces(proj *ProjectObject) []*NamespaceObject {
+	namespaces := []*NamespaceObject{}
+	for _, nslink := range proj.Namespaces {
+		if ns, ok := c.ds.Namespace(nslink.OID); ok && ns.Phase != "" {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	return namespaces
+}
+
+// kubeconfig - the kubeconfig media type of the project, issued with a new
+// token when rotating.
+func (c *KubeconfigController) kubeconfig(proj *ProjectObject, namespaces []*NamespaceObject, rotate bool) (*app.Kubeconfig, error) {
+	data, err := issueTenantKubeconfig(proj, namespaces, rotate)
+	if err != nil {
+		return nil, err
+	}
+	res := &app.Kubeconfig{
+		Project:        proj.OID,
+		ServiceAccount: TenantNamespace + "/" + TenantAccountName(proj),
+		Namespaces:     []string{},
+		Kubeconfig:     string(data),
+	}
+	for _, ns := range namespaces {
+		res.Namespaces = append(res.Namespaces, ns.Name)
+	}
+	return res, nil
+}
+
+// Get runs the get action.
+func (c *KubeconfigController) Get(ctx *app.GetKubeconfigContext) error {
+	// KubeconfigController_Get: start_implement
+	proj, ok := c.ds.Project(ctx.Projectid)
+	if !ok {
+		return ctx.NotFound()
+	}
+	namespaces := c.tenantNamespaces(proj)
+	if len(namespaces) == 0 {
+		return ctx.BadRequest(goa.ErrBadRequest(fmt.Errorf("project %s has no Kubernetes namespaces", proj.Name)))
+	}
+	// the project's ServiceAccount is created with its namespaces, a revoked
+	// one is created again by a rotation, never by a get
+	res, err := c.kubeconfig(proj, namespaces, false)
+	if errors.IsNotFound(err) {
+		return ctx.NotFound()
+	}
+	if err != nil {
+		glog.Errorf("unable to issue project %s kubeconfig: %v", proj.Name, err)
+		return ctx.InternalServerError()
+	}
+	return ctx.OK(res)
+	// KubeconfigController_Get: end_implement
+}
+
+// Revoke runs the revoke action.
+func (c *KubeconfigController) Revoke(ctx *app.RevokeKubeconfigContext) error {
+	// KubeconfigController_Revoke: start_implement
+	proj, ok := c.ds.Project(ctx.Projectid)
+	if !ok {
+		return ctx.NotFound()
+	}
+	if !*krak8sCfg.dryrun {
+		if err := revokeTenantAccess(proj); err != nil {
+			glog.Errorf("unable to revoke project %s kubeconfig: %v", proj.Name, err)
+			return ctx.InternalServerError()
+		}
+	}
+	return ctx.NoContent()
+	// KubeconfigController_Revoke: end_implement
+}
+
+// Rotate runs the rotate action.
+func (c *KubeconfigController) Rotate(ctx *app.RotateKubeconfigContext) error {
+	// KubeconfigController_Rotate: start_implement
+	proj, ok := c.ds.Project(ctx.Projectid)
+	if !ok {
+		return ctx.NotFound()
+	}
+	namespaces := c.tenantNamespaces(proj)
+	if len(namespaces) == 0 {
+		return ctx.BadRequest(goa.ErrBadRequest(fmt.Errorf("project %s has no Kubernetes namespaces", proj.Name)))
+	}
+	res, err := c.kubeconfig(proj, namespaces, true)
+	if err != nil {
+		glog.Errorf("unable to rotate project %s kubeconfig: %v", proj.Name, err)
+		return ctx.InternalServerError()
+	}
+	return ctx.OK(res)
+	// KubeconfigController_Rotate: end_implement
+}
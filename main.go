@@ -132,6 +132,7 @@ func main() {
 	if err != nil {
 		panic(err.Error())
 	}
+	SetDefaultTarget(clientset)
 
 	backend := NewRunner()
 	go backend.ProcessRequests()
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"errors"
+	"krak8s/app"
+	"sort"
+
+	"github.com/goadesign/goa"
+)
+
+// MemberController implements the member resource.
+type MemberController struct {
+	*goa.Controller
+	ds      *DataStore
+	backend *Runner
+}
+
+// NewMemberController creates a member controller.
+func NewMemberController(service *goa.Service, store *DataStore, backend *Runner) *MemberController {
+	return &MemberController{
+		Controller: service.NewController("MemberController"),
+		ds:         store,
+		backend:    backend,
+	}
+}
+
+// MarshalMembers to member media types, the owners first
+func MarshalMembers(proj *ProjectObject) app.MemberCollection {
+	collection := app.MemberCollection{}
+	for _, owner := range proj.Owners {
+		collection = append(collection, &app.Member{Project: proj.OID, Caller: owner, Role: RoleAdmin})
+	}
+	callers := make([]string, 0, len(proj.Members))
+	for caller := range proj.Members {
+		callers = append(callers, caller)
+	}
+	sort.Strings(callers)
+	for _, caller := range callers {
+		collection = append(collection, &app.Member{Project: proj.OID, Caller: caller, Role: proj.Members[caller]})
+	}
+	return collection
+}
+
+// lastOwner - true if the caller is the project's only owner.
+func lastOwner(proj *ProjectObject, caller string) bool {
+	return len(proj.Owners) == 1 && proj.Owners[0] == caller
+}
+
+// Delete runs the delete action.
+func (c *MemberController) Delete(ctx *app.DeleteMemberContext) error {
+	// MemberController_Delete: start_implement
+	proj, ok := c.ds.Project(ctx.Projectid)
+	if !ok || proj.MemberRole(ctx.Caller) == "" {
+		return ctx.NotFound()
+	}
+	if lastOwner(proj, ctx.Caller) {
+		return ctx.BadRequest(goa.ErrBadRequest(errors.New("a project keeps at least one owner")))
+	}
+	c.ds.DeleteProjectMember(proj, ctx.Caller)
+	return ctx.NoContent()
+	// MemberController_Delete: end_implement
+}
+
+// List runs the list action.
+func (c *MemberController) List(ctx *app.ListMemberContext) error {
+	// MemberController_List: start_implement
+	proj, ok := c.ds.Project(ctx.Projectid)
+	if !ok {
+		return ctx.NotFound()
+	}
+	return ctx.OK(MarshalMembers(proj))
+	// MemberController_List: end_implement
+}
+
+// Update runs the update action.
+func (c *MemberController) Update(ctx *app.UpdateMemberContext) error {
+	// MemberController_Update: start_implement
+	proj, ok := c.ds.Project(ctx.Projectid)
+	if !ok {
+		return ctx.NotFound()
+	}
+	if ctx.Payload.Role != RoleAdmin && lastOwner(proj, ctx.Caller) {
+		return ctx.BadRequest(goa.ErrBadRequest(errors.New("a project keeps at least one owner")))
+	}
+	c.ds.UpdateProjectMember(proj, ctx.Caller, ctx.Payload.Role)
+	return ctx.OK(&app.Member{Project: proj.OID, Caller: ctx.Caller, Role: ctx.Payload.Role})
+	// MemberController_Update: end_implement
+}
@@ -5,6 +5,7 @@ import (
 	"krak8s/app"
 
 	"github.com/goadesign/goa"
+	"github.com/golang/glog"
 )
 
 // NamespaceController implements the namespace resource.
@@ -31,6 +32,53 @@ func MarshaApplicationRef(obj *ObjectLink) *app.ApplicationRef {
 	}
 }
 
+// MarshalNamespaceQuota to namespace quota user type
+func MarshalNamespaceQuota(obj *NamespaceQuota) *app.NamespaceQuota {
+	if obj == nil {
+		return nil
+	}
+	quota := &app.NamespaceQuota{Pods: obj.Pods}
+	for _, field := range []struct {
+		value string
+		dest  **string
+	}{
+		{obj.CPU, &quota.CPU},
+		{obj.Memory, &quota.Memory},
+		{obj.Storage, &quota.Storage},
+		{obj.DefaultCPU, &quota.DefaultCPU},
+		{obj.DefaultMemory, &quota.DefaultMemory},
+	} {
+		if field.value != "" {
+			value := field.value
+			*field.dest = &value
+		}
+	}
+	return quota
+}
+
+// UnmarshalNamespaceQuota from namespace quota user type
+func UnmarshalNamespaceQuota(quota *app.NamespaceQuota) *NamespaceQuota {
+	if quota == nil {
+		return nil
+	}
+	obj := &NamespaceQuota{Pods: quota.Pods}
+	for _, field := range []struct {
+		value *string
+		dest  *string
+	}{
+		{quota.CPU, &obj.CPU},
+		{quota.Memory, &obj.Memory},
+		{quota.Storage, &obj.Storage},
+		{quota.DefaultCPU, &obj.DefaultCPU},
+		{quota.DefaultMemory, &obj.DefaultMemory},
+	} {
+		if field.value != nil {
+			*field.dest = *field.value
+		}
+	}
+	return obj
+}
+
 // MarshalNamespaceObject to project media type
 func MarshalNamespaceObject(obj *NamespaceObject) *app.Namespace {
 	ns := &app.Namespace{
@@ -39,11 +87,19 @@ func MarshalNamespaceObject(obj *NamespaceObject) *app.Namespace {
 		Name:      obj.Name,
 		CreatedAt: obj.CreatedAt,
 	}
+	if obj.Phase != "" {
+		ns.Phase = &obj.Phase
+	}
+	ns.Quota = MarshalNamespaceQuota(obj.Quota)
 
-	if obj.Resources != nil {
-		ns.Resources = &app.ClusterRef{Oid: obj.Resources.OID, URL: obj.Resources.URL}
+	count := len(obj.Resources)
+	if count > 0 {
+		ns.Resources = make(app.ClusterRefCollection, count)
+		for i, link := range obj.Resources {
+			ns.Resources[i] = &app.ClusterRef{Oid: link.OID, URL: link.URL}
+		}
 	}
-	count := len(obj.Applications)
+	count = len(obj.Applications)
 	if count > 0 {
 		ns.Applications = make(app.ApplicationRefCollection, count)
 		i := 0
@@ -62,12 +118,45 @@ func (c *NamespaceController) Create(ctx *app.CreateNamespaceContext) error {
 	if !ok {
 		return ctx.NotFound()
 	}
+	quota := UnmarshalNamespaceQuota(ctx.Payload.Quota)
+	if err := ValidateQuota(quota); err != nil {
+		return ctx.BadRequest(goa.ErrBadRequest(err))
+	}
 	ns := c.ds.NewNamespace(ctx.Payload.Name)
 	if ns == nil {
 		return ctx.InternalServerError()
 	}
+	c.ds.UpdateNamespaceQuota(ns, quota)
+	if !*krak8sCfg.dryrun {
+		client, err := clusterNamespaces(proj)
+		if err == nil {
+			err = CreateClusterNamespace(client, proj, ns)
+		}
+		if err != nil {
+			glog.Errorf("unable to create Kubernetes namespace %s: %v", ns.Name, err)
+			c.ds.DeleteNamespace(ns)
+			if _, ok := err.(*NamespaceExistsError); ok {
+				return ctx.BadRequest(goa.ErrBadRequest(err))
+			}
+			return ctx.InternalServerError()
+		}
+		err = syncNamespaceQuota(proj, ns)
+		if err == nil && proj.Isolated {
+			err = syncNetworkPolicies(proj, ns)
+		}
+		if err == nil {
+			err = syncTenantAccess(proj, ns)
+		}
+		if err != nil {
+			glog.Errorf("unable to create Kubernetes namespace %s quota, network policies and tenant access: %v", ns.Name, err)
+			DeleteClusterNamespace(client, ns)
+			c.ds.DeleteNamespace(ns)
+			return ctx.InternalServerError()
+		}
+	}
 	url := APIVersion + APIProjects + ctx.Projectid + APINamespaces + ns.OID
 	proj.Namespaces = append(proj.Namespaces, &ObjectLink{OID: ns.OID, URL: url})
+	AuditObjects(ctx, ns.OID)
 	return ctx.Created(MarshalNamespaceObject(ns))
 	// NamespaceController_Create: end_implement
 }
@@ -100,14 +189,14 @@ func (c *NamespaceController) Delete(ctx *app.DeleteNamespaceContext) error {
 
 	for _, applink := range ns.Applications {
 		if app, ok := c.ds.Application(applink.OID); ok {
-			c.backend.ChartRequest(RemoveChart, c.ds, proj, ns, app)
+			c.backend.ChartRequest(ctx, RemoveChart, c.ds, proj, ns, app)
 		}
 	}
-	if ns.Resources != nil {
-		if res, ok := c.ds.Resource(ns.Resources.OID); ok {
-			c.backend.ProjectRequest(RemoveProject, c.ds, proj, ns, res)
-		}
+	for _, res := range c.ds.NamespaceResources(ns) {
+		c.backend.ProjectRequest(ctx, RemoveProject, c.ds, proj, ns, res)
 	}
+	// queued after the application removals, which run first
+	c.backend.NamespaceRequest(ctx, RemoveNamespace, c.ds, proj, ns)
 	c.ds.DeleteNamespace(ns)
 
 	copy(proj.Namespaces[index:], proj.Namespaces[index+1:])
@@ -118,17 +207,65 @@ func (c *NamespaceController) Delete(ctx *app.DeleteNamespaceContext) error {
 	// NamespaceController_Delete: end_implement
 }
 
+// Update runs the update action.
+func (c *NamespaceController) Update(ctx *app.UpdateNamespaceContext) error {
+	// NamespaceController_Update: start_implement
+	proj, ok := c.ds.Project(ctx.Projectid)
+	if !ok {
+		return ctx.NotFound()
+	}
+	ns, ok := c.ds.Namespace(ctx.Namespaceid)
+	if !ok || !proj.HasNamespaceLink(ns.OID) {
+		return ctx.NotFound()
+	}
+	quota := UnmarshalNamespaceQuota(ctx.Payload.Quota)
+	if err := ValidateQuota(quota); err != nil {
+		return ctx.BadRequest(goa.ErrBadRequest(err))
+	}
+	previous := ns.Quota
+	ns.Quota = quota
+	if !*krak8sCfg.dryrun {
+		if err := syncNamespaceQuota(proj, ns); err != nil {
+			glog.Errorf("unable to update Kubernetes namespace %s quota: %v", ns.Name, err)
+			ns.Quota = previous
+			return ctx.InternalServerError()
+		}
+	}
+	c.ds.UpdateNamespaceQuota(ns, quota)
+	return ctx.OK(MarshalNamespaceObject(ns))
+	// NamespaceController_Update: end_implement
+}
+
 // Get runs the get action.
 func (c *NamespaceController) Get(ctx *app.GetNamespaceContext) error {
 	// NamespaceController_Get: start_implement
-	if _, ok := c.ds.Project(ctx.Projectid); !ok {
+	proj, ok := c.ds.Project(ctx.Projectid)
+	if !ok {
 		return ctx.NotFound()
 	}
 	ns, ok := c.ds.Namespace(ctx.Namespaceid)
-	if !ok {
+	if !ok || !proj.HasNamespaceLink(ns.OID) {
 		return ctx.NotFound()
 	}
+	var usage *NamespaceQuota
+	if !*krak8sCfg.dryrun && ns.Phase != "" {
+		client, err := clusterNamespaces(proj)
+		if err == nil {
+			err = RefreshClusterNamespacePhase(client, ns)
+		}
+		if err != nil {
+			glog.Warningf("unable to get Kubernetes namespace %s phase: %v", ns.Name, err)
+		}
+		// restore a ResourceQuota or LimitRange changed outside of krak8s
+		if err = syncNamespaceQuota(proj, ns); err != nil {
+			glog.Warningf("unable to sync Kubernetes namespace %s quota: %v", ns.Name, err)
+		}
+		if usage, err = namespaceQuotaUsage(proj, ns); err != nil {
+			glog.Warningf("unable to get Kubernetes namespace %s quota usage: %v", ns.Name, err)
+		}
+	}
 	res := MarshalNamespaceObject(ns)
+	res.Usage = MarshalNamespaceQuota(usage)
 	return ctx.OK(res)
 	// NamespaceController_Get: end_implement
 }
@@ -1,9 +1,11 @@
 package main
 
 import (
+	"fmt"
 	"krak8s/app"
 
 	"github.com/goadesign/goa"
+	"github.com/golang/glog"
 )
 
 // ProjectController implements the project resource.
@@ -30,6 +32,35 @@ func MarshaNamespaceRef(obj *ObjectLink) *app.NamespaceRef {
 	}
 }
 
+// MarshalNetworkPeer to network peer user type
+func MarshalNetworkPeer(obj NetworkPeer) *app.NetworkPeer {
+	peer := &app.NetworkPeer{
+		NamespaceLabels: obj.NamespaceLabels,
+		PodLabels:       obj.PodLabels,
+	}
+	if obj.Project != "" {
+		project := obj.Project
+		peer.Project = &project
+	}
+	return peer
+}
+
+// UnmarshalNetworkPeers from network peer user types
+func UnmarshalNetworkPeers(peers []*app.NetworkPeer) []NetworkPeer {
+	objs := make([]NetworkPeer, 0, len(peers))
+	for _, peer := range peers {
+		if peer == nil {
+			continue
+		}
+		obj := NetworkPeer{NamespaceLabels: peer.NamespaceLabels, PodLabels: peer.PodLabels}
+		if peer.Project != nil {
+			obj.Project = *peer.Project
+		}
+		objs = append(objs, obj)
+	}
+	return objs
+}
+
 // MarshalProjectObject to project media type
 func MarshalProjectObject(obj *ProjectObject) *app.Project {
 	proj := &app.Project{
@@ -38,6 +69,19 @@ func MarshalProjectObject(obj *ProjectObject) *app.Project {
 		Name:      obj.Name,
 		CreatedAt: obj.CreatedAt,
 	}
+	if obj.Target != "" {
+		proj.Target = &obj.Target
+	}
+	if obj.Isolated {
+		proj.Isolated = &obj.Isolated
+	}
+	for _, peer := range obj.Peers {
+		proj.Peers = append(proj.Peers, MarshalNetworkPeer(peer))
+	}
+	proj.Owners = obj.Owners
+	if len(obj.Members) > 0 {
+		proj.Members = obj.Members
+	}
 
 	count := len(obj.Namespaces)
 	if count > 0 {
@@ -54,14 +98,75 @@ func MarshalProjectObject(obj *ProjectObject) *app.Project {
 // Create runs the create action.
 func (c *ProjectController) Create(ctx *app.CreateProjectContext) error {
 	// ProjectController_Create: start_implement
-	proj := c.ds.NewProject(ctx.Payload.Name)
+	target := ""
+	if ctx.Payload.Target != nil {
+		target = *ctx.Payload.Target
+		if _, ok := LookupTarget(target); !ok {
+			return ctx.BadRequest(goa.ErrBadRequest(fmt.Errorf("cluster target %s is not registered", target)))
+		}
+	}
+	peers := UnmarshalNetworkPeers(ctx.Payload.Peers)
+	if err := ValidatePeers(peers, c.projectExists); err != nil {
+		return ctx.BadRequest(goa.ErrBadRequest(err))
+	}
+	proj := c.ds.NewProject(ctx.Payload.Name, target)
 	if proj == nil {
 		return ctx.InternalServerError()
 	}
+	if ctx.Payload.Isolated != nil || len(peers) > 0 {
+		c.ds.UpdateProjectNetwork(proj, ctx.Payload.Isolated != nil && *ctx.Payload.Isolated, peers)
+	}
+	// the caller creating the project is its first owner
+	if caller := ContextCaller(ctx); caller != nil {
+		c.ds.UpdateProjectMember(proj, caller.Name, RoleAdmin)
+	}
+	AuditObjects(ctx, proj.OID)
 	return ctx.Created(MarshalProjectObject(proj))
 	// ProjectController_Create: end_implement
 }
 
+func (c *ProjectController) projectExists(oid string) bool {
+	_, ok := c.ds.Project(oid)
+	return ok
+}
+
+// Update runs the update action.
+func (c *ProjectController) Update(ctx *app.UpdateProjectContext) error {
+	// ProjectController_Update: start_implement
+	proj, ok := c.ds.Project(ctx.Projectid)
+	if !ok {
+		return ctx.NotFound()
+	}
+	isolated := proj.Isolated
+	if ctx.Payload.Isolated != nil {
+		isolated = *ctx.Payload.Isolated
+	}
+	peers := proj.Peers
+	if ctx.Payload.Peers != nil {
+		peers = UnmarshalNetworkPeers(ctx.Payload.Peers)
+	}
+	if err := ValidatePeers(peers, c.projectExists); err != nil {
+		return ctx.BadRequest(goa.ErrBadRequest(err))
+	}
+	c.ds.UpdateProjectNetwork(proj, isolated, peers)
+
+	// the namespaces that krak8s created Kubernetes namespaces for
+	if !*krak8sCfg.dryrun {
+		for _, nslink := range proj.Namespaces {
+			ns, ok := c.ds.Namespace(nslink.OID)
+			if !ok || ns.Phase == "" {
+				continue
+			}
+			if err := syncNetworkPolicies(proj, ns); err != nil {
+				glog.Errorf("unable to update Kubernetes namespace %s network policies: %v", ns.Name, err)
+				return ctx.InternalServerError()
+			}
+		}
+	}
+	return ctx.OK(MarshalProjectObject(proj))
+	// ProjectController_Update: end_implement
+}
+
 // Delete runs the delete action.
 func (c *ProjectController) Delete(ctx *app.DeleteProjectContext) error {
 	// ProjectController_Delete: start_implement
@@ -75,17 +180,21 @@ func (c *ProjectController) Delete(ctx *app.DeleteProjectContext) error {
 			for _, applink := range ns.Applications {
 				if app, ok := c.ds.Application(applink.OID); ok {
 					if app.Status.State == ApplicationDeployed {
-						c.backend.ChartRequest(RemoveChart, c.ds, proj, ns, app)
+						c.backend.ChartRequest(ctx, RemoveChart, c.ds, proj, ns, app)
 					}
 				}
 			}
-			if ns.Resources != nil {
-				if res, ok := c.ds.Resource(ns.Resources.OID); ok {
-					if res.State == ResourceErrorStarting || res.State == ResourceActive || res.State == ResourceErrorDeleting {
-						c.backend.ProjectRequest(RemoveProject, c.ds, proj, ns, res)
-					}
+			for _, res := range c.ds.NamespaceResources(ns) {
+				if res.State == ResourceErrorStarting || res.State == ResourceActive || res.State == ResourceErrorDeleting {
+					c.backend.ProjectRequest(ctx, RemoveProject, c.ds, proj, ns, res)
 				}
 			}
+			c.backend.NamespaceRequest(ctx, RemoveNamespace, c.ds, proj, ns)
+		}
+	}
+	if !*krak8sCfg.dryrun {
+		if err := revokeTenantAccess(proj); err != nil {
+			glog.Warningf("unable to delete project %s ServiceAccount: %v", proj.Name, err)
 		}
 	}
 
@@ -110,14 +219,11 @@ func (c *ProjectController) Get(ctx *app.GetProjectContext) error {
 func (c *ProjectController) List(ctx *app.ListProjectContext) error {
 	// ProjectController_List: start_implement
 	collection := app.ProjectCollection{}
-	projects := c.ds.ProjectsCollection()
-	count := len(projects)
-	if count > 0 {
-		collection = make(app.ProjectCollection, count)
-		i := 0
-		for _, obj := range projects {
-			collection[i] = MarshalProjectObject(obj)
-			i++
+	caller := ContextCaller(ctx)
+	for _, obj := range c.ds.ProjectsCollection() {
+		// only the projects the caller has a role on
+		if ProjectRole(caller, obj) != "" {
+			collection = append(collection, MarshalProjectObject(obj))
 		}
 	}
 	return ctx.OK(collection)
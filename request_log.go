@@ -0,0 +1,90 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/goadesign/goa"
+)
+
+// requestLogCredentialHeaders - the request headers carrying the callers'
+// credentials, logged as AuditRedacted
+var requestLogCredentialHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"X-Api-Key":           true,
+	"Cookie":              true,
+}
+
+// LogRequestDetails - a middleware logging the headers, params and payload of
+// each request, as the verbose goa request logger does, but with the
+// credentials in its headers, and the secrets of its payload as in the audit
+// log, redacted.  Mount it after
+// middleware.LogRequest(false), whose request id it logs with.
+func LogRequestDetails() goa.Middleware {
+	return func(h goa.Handler) goa.Handler {
+		return func(ctx context.Context, rw http.ResponseWriter, req *http.Request) error {
+			r := goa.ContextRequest(ctx)
+			if len(r.Header) > 0 {
+				names := make([]string, 0, len(r.Header))
+				for name := range r.Header {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+				logCtx := make([]interface{}, 0, 2*len(names))
+				for _, name := range names {
+					value := strings.Join(r.Header[name], ", ")
+					if requestLogCredentialHeaders[http.CanonicalHeaderKey(name)] {
+						value = AuditRedacted
+					}
+					logCtx = append(logCtx, name, value)
+				}
+				goa.LogInfo(ctx, "headers", logCtx...)
+			}
+			if len(r.Params) > 0 {
+				names := make([]string, 0, len(r.Params))
+				for name := range r.Params {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+				logCtx := make([]interface{}, 0, 2*len(names))
+				for _, name := range names {
+					logCtx = append(logCtx, name, strings.Join(r.Params[name], ", "))
+				}
+				goa.LogInfo(ctx, "params", logCtx...)
+			}
+			if r.ContentLength > 0 && r.Payload != nil {
+				payload := RedactPayload(r.Payload)
+				names := make([]string, 0, len(payload))
+				for name := range payload {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+				logCtx := make([]interface{}, 0, 2*len(names))
+				for _, name := range names {
+					logCtx = append(logCtx, name, payload[name])
+				}
+				goa.LogInfo(ctx, "payload", logCtx...)
+			}
+			return h(ctx, rw, req)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"krak8s/app"
+	"krak8s/commands"
+	"path"
+	"strings"
+
+	"github.com/goadesign/goa"
+	"github.com/goadesign/goa/middleware"
+)
+
+// RevisionController implements the revision resource.
+type RevisionController struct {
+	*goa.Controller
+	ds      *DataStore
+	backend *Runner
+}
+
+// NewRevisionController creates a revision controller.
+func NewRevisionController(service *goa.Service, store *DataStore, backend *Runner) *RevisionController {
+	return &RevisionController{
+		Controller: service.NewController("RevisionController"),
+		ds:         store,
+		backend:    backend,
+	}
+}
+
+// ErrConflict - the error class of restores of revisions that add or remove
+// the node pools of cluster resources
+var ErrConflict = goa.NewErrorClass("conflict", 409)
+
+// MarshalConfigRevision to config revision media type
+func MarshalConfigRevision(rev *commands.ConfigRevision) *app.ConfigRevision {
+	mt := &app.ConfigRevision{
+		ID:        rev.ID,
+		Time:      rev.Time,
+		Operation: rev.Operation,
+	}
+	if rev.Project != "" {
+		mt.Project = &rev.Project
+	}
+	if rev.Restored != "" {
+		mt.Restored = &rev.Restored
+	}
+	if rev.Update != "" {
+		mt.Update = &rev.Update
+	}
+	return mt
+}
+
+func revisionConfigPath() string {
+	return path.Join(*krak8sCfg.krakenConfigDir, *krak8sCfg.krakenConfigFile)
+}
+
+// Diff runs the diff action.
+func (c *RevisionController) Diff(ctx *app.DiffRevisionContext) error {
+	// RevisionController_Diff: start_implement
+	diff, err := commands.DiffConfigRevisions(revisionConfigPath(), ctx.Revision, ctx.To)
+	if err == commands.ErrNoConfigRevision {
+		return ctx.NotFound()
+	} else if err != nil {
+		return ctx.BadRequest(goa.ErrBadRequest(err))
+	}
+	return ctx.OK(&app.ConfigDiff{From: ctx.Revision, To: ctx.To, Diff: &diff})
+	// RevisionController_Diff: end_implement
+}
+
+// List runs the list action.
+func (c *RevisionController) List(ctx *app.ListRevisionContext) error {
+	// RevisionController_List: start_implement
+	revisions, err := commands.ConfigRevisions(revisionConfigPath())
+	if err != nil {
+		return goa.ErrInternal(err)
+	}
+	res := app.ConfigRevisionCollection{}
+	for i := range revisions {
+		res = append(res, MarshalConfigRevision(&revisions[i]))
+	}
+	return ctx.OK(res)
+	// RevisionController_List: end_implement
+}
+
+// Restore runs the restore action.
+func (c *RevisionController) Restore(ctx *app.RestoreRevisionContext) error {
+	// RevisionController_Restore: start_implement
+	// the restored configuration must agree with the cluster resources
+	nodePools, changing := c.ds.TargetNodePools(DefaultTarget)
+	if len(changing) > 0 {
+		return ctx.BadRequest(goa.ErrBadRequest(fmt.Errorf("node pools %s are being created or deleted", strings.Join(changing, ", "))))
+	}
+	lock, err := commands.TryLockConfig(revisionConfigPath(), commands.ConfigOpRestore+" "+ctx.Revision)
+	if err != nil {
+		return ctx.BadRequest(goa.ErrBadRequest(err))
+	}
+	rev, changes, resized, err := commands.RestoreConfigRevision(revisionConfigPath(), ctx.Revision, middleware.ContextRequestID(ctx), nodePools)
+	if err != nil {
+		lock.Unlock()
+		if err == commands.ErrNoConfigRevision {
+			return ctx.NotFound()
+		}
+		if _, ok := err.(*commands.NodePoolConflictError); ok {
+			return ctx.Conflict(ErrConflict(err))
+		}
+		return ctx.BadRequest(goa.ErrBadRequest(err))
+	}
+	// the cluster resources take the restored node counts
+	c.ds.ResizeTargetNodePools(DefaultTarget, resized)
+	if ctx.Update && !changes.Empty() {
+		// the runner records the outcome of the update on the revision
+		err = commands.SetConfigRevisionUpdate(revisionConfigPath(), rev.ID, commands.ConfigUpdatePending)
+		lock.Unlock()
+		if err != nil {
+			return goa.ErrInternal(err)
+		}
+		rev.Update = commands.ConfigUpdatePending
+		c.backend.ConfigRequest(ctx, c.ds, rev.ID, changes)
+	} else {
+		lock.Unlock()
+	}
+	return ctx.OK(MarshalConfigRevision(rev))
+	// RevisionController_Restore: end_implement
+}
@@ -17,26 +17,46 @@ limitations under the License.
 package main
 
 import (
+	"context"
+	"fmt"
 	"krak8s/commands"
 	"krak8s/queue"
 	"os"
-	"path"
 	"sync"
 	"time"
 
+	"github.com/goadesign/goa"
+	"github.com/goadesign/goa/middleware"
 	"github.com/golang/glog"
 )
 
 // Run back end tasks with queue semantics
 
-var configFile string
-
 // RunnerSetup - needs only be called once before execting ny functions below (note: this can't be func init()\)
 func RunnerSetup() {
 	if *krak8sCfg.krakenCommand == commands.K2 {
 		commands.K2SetupEnv()
-		configFile = path.Join(*krak8sCfg.krakenConfigDir, *krak8sCfg.krakenConfigFile)
 	}
+	if *krak8sCfg.clusterTargets != "" {
+		if err := LoadClusterTargets(*krak8sCfg.clusterTargets); err != nil {
+			glog.Errorf("Using the default cluster target only: %v", err)
+		}
+	}
+	if *krak8sCfg.schedulingPaths != "" {
+		if err := commands.LoadSchedulingPaths(*krak8sCfg.schedulingPaths); err != nil {
+			glog.Errorf("Using default chart scheduling paths: %v", err)
+		}
+	}
+	if *krak8sCfg.schemaDir != "" {
+		if err := commands.LoadChartSchemas(*krak8sCfg.schemaDir); err != nil {
+			glog.Errorf("Using chart provided values schemas only: %v", err)
+		}
+	}
+	commands.SetSchemaFetch(*krak8sCfg.schemaFetch)
+	commands.SetConfigHistoryRetention(*krak8sCfg.historyMax, *krak8sCfg.historyMaxAge)
+	commands.SetConfigGit(*krak8sCfg.configGit, *krak8sCfg.configGitRemote)
+	commands.SetConfigLockStale(*krak8sCfg.configLockStale)
+	commands.SetConfigLockWait(*krak8sCfg.configLockWait)
 }
 
 // RequestType - requested tasks available
@@ -55,19 +75,25 @@ const (
 	UpdateChart
 	// RemoveChart request
 	RemoveChart
+	// UpdateConfig request, apply a restored configuration revision
+	UpdateConfig
+	// RemoveNamespace request, delete the Kubernetes namespace
+	RemoveNamespace
+	// RegistryLogin request, log in to the registry of a rotated credential
+	RegistryLogin
 )
 
 func (req RequestType) String() string {
 	return []string{
 		"AddProject",
-		"RunningAddProject",
 		"UpdateProject",
-		"RunningUpdateProject",
 		"RemoveProject",
-		"RunningRemoveProject",
 		"AddChart",
 		"UpdateChart",
 		"RemoveChart",
+		"UpdateConfig",
+		"RemoveNamespace",
+		"RegistryLogin",
 	}[req]
 }
 
@@ -106,7 +132,12 @@ type Request struct {
 	nsObj       *NamespaceObject
 	resObj      *ResourceObject
 	appObj      *ApplicationObject
+	credObj     *CredentialObject
+	nodePools   *commands.NodePoolChanges
+	revision    string
 	retryCount  int
+	requestID   string
+	caller      string
 }
 
 // NewResourceRequest creates an request for processing
@@ -133,12 +164,65 @@ func NewChartRequest(req RequestType, ds *DataStore, proj *ProjectObject, ns *Na
 	}
 }
 
+// NewConfigRequest creates an request for processing
+func NewConfigRequest(req RequestType, ds *DataStore, revision string, changes *commands.NodePoolChanges) *Request {
+	return &Request{
+		task:        queue.NewTask(),
+		dataStore:   ds,
+		nodePools:   changes,
+		revision:    revision,
+		requestType: req,
+	}
+}
+
+// NewNamespaceRequest creates an request for processing
+func NewNamespaceRequest(req RequestType, ds *DataStore, proj *ProjectObject, ns *NamespaceObject) *Request {
+	return &Request{
+		task:        queue.NewTask(),
+		dataStore:   ds,
+		projObj:     proj,
+		nsObj:       ns,
+		requestType: req,
+	}
+}
+
+// NewCredentialRequest creates an request for processing
+func NewCredentialRequest(req RequestType, ds *DataStore, proj *ProjectObject, cred *CredentialObject) *Request {
+	return &Request{
+		task:        queue.NewTask(),
+		dataStore:   ds,
+		projObj:     proj,
+		credObj:     cred,
+		requestType: req,
+	}
+}
+
+// fromAPIRequest - attribute the request to the API request of the context,
+// whose id its commands are audited with
+func (req *Request) fromAPIRequest(ctx context.Context) *Request {
+	req.requestID = middleware.ContextRequestID(ctx)
+	if caller := ContextCaller(ctx); caller != nil {
+		req.caller = caller.Name
+	}
+	return req
+}
+
+// names - the project and namespace names of the request, for logging
+func (req *Request) names() (string, string) {
+	if req.projObj == nil || req.nsObj == nil {
+		return "", ""
+	}
+	return req.projObj.Name, req.nsObj.Name
+}
+
 // Runner for request from API server to backend
 type Runner struct {
 	index           int
 	pendingRequests map[int]*Request
 	mutex           *sync.Mutex
 	sync            chan int
+	audit           *AuditLog
+	running         *Request
 }
 
 // NewRunner creates a request runner
@@ -162,42 +246,151 @@ func (r *Runner) ProcessRequests() {
 	}
 }
 
+// SetAuditLog - append the backend commands run for the requests to the
+// audit log.
+func (r *Runner) SetAuditLog(log *AuditLog) {
+	r.audit = log
+	commands.SetExecObserver(r.auditCommand)
+}
+
+// auditCommand - append the command to the audit log, attributed to the API
+// request of the context it was run with, such as a chart fetch validating an
+// application's values, or else to the running request
+func (r *Runner) auditCommand(ctx context.Context, command string, arguments []string, err error) {
+	rec := &AuditRecord{
+		Time:    time.Now(),
+		Kind:    AuditCommand,
+		Command: RedactCommand(append([]string{command}, arguments...)),
+		Outcome: AuditSuccess,
+	}
+	if apiRec := contextAuditRecord(ctx); apiRec != nil {
+		rec.RequestID, rec.Caller, rec.Action = apiRec.RequestID, apiRec.Caller, apiRec.Action
+	} else {
+		r.mutex.Lock()
+		request := r.running
+		r.mutex.Unlock()
+		if request == nil {
+			return
+		}
+		rec.RequestID, rec.Caller, rec.Action = request.requestID, request.caller, request.requestType.String()
+	}
+	if rec.RequestID == "" {
+		return
+	}
+	if err != nil {
+		rec.Outcome = AuditFailure
+		rec.Error = err.Error()
+	}
+	if aerr := r.audit.Append(rec); aerr != nil {
+		glog.Errorf("unable to append command %s to the audit log: %v", command, aerr)
+	}
+}
+
+// setRunning - the request whose commands are run
+func (r *Runner) setRunning(request *Request) {
+	r.mutex.Lock()
+	r.running = request
+	r.mutex.Unlock()
+}
+
 func (r *Runner) handle(index int) {
 	done := false
 	request := r.pendingRequests[index]
+	r.setRunning(request)
+	defer r.setRunning(nil)
 	if request.requestType >= AddProject && request.requestType <= RemoveProject {
 		done = r.handleProjects(request)
 	} else if request.requestType >= AddChart && request.requestType <= RemoveChart {
 		done = r.handleCharts(request)
+	} else if request.requestType == UpdateConfig {
+		done = r.handleConfigUpdate(request)
+	} else if request.requestType == RemoveNamespace {
+		done = r.handleNamespace(request)
+	} else if request.requestType == RegistryLogin {
+		done = r.handleRegistryLogin(request)
 	}
 	if done {
 		r.DeleteRequest(index)
 	}
 }
 
-func (r *Runner) handleProjects(request *Request) bool {
-
-	cfg := commands.NewProjectConfig(request.projObj.Name, request.resObj.NodePoolSize, request.nsObj.Name)
+// projectConfig - the Kraken configuration of the project's node pool
+func projectConfig(proj *ProjectObject, ns *NamespaceObject, res *ResourceObject) commands.ProjectConfig {
+	cfg := commands.NewProjectConfig(proj.Name, res.NodePoolSize, ns.Name)
+	cfg.Pool = res.Name
 	cfg.KeyPair = *krak8sCfg.krakenKeyPair
 	cfg.KubeConfigName = *krak8sCfg.krakenKubeConfig
+	if res.NodePool.NodeConfig != "" {
+		cfg.NodeConfig = res.NodePool.NodeConfig
+	}
+	if res.NodePool.OSConfig != "" {
+		cfg.OSConfig = res.NodePool.OSConfig
+	}
+	if res.NodePool.ContainerConfig != "" {
+		cfg.ContainerConfig = res.NodePool.ContainerConfig
+	}
+	cfg.Labels = res.NodePool.Labels
+	if len(res.NodePool.Taints) > 0 {
+		cfg.Taints = res.NodePool.commandTaints()
+	}
+	return cfg
+}
+
+// projectCommandAction - the update.sh node pool action, and the k2cli
+// command, for a project request on the named node pool
+func projectCommandAction(requestType RequestType, nodePool string) (string, []string) {
+	switch requestType {
+	case AddProject:
+		return commands.K2ExtraVarsAddNodePools, commands.ClusterUpdate(commands.K2CLIAddNodePools, []string{nodePool})
+	case UpdateProject:
+		return commands.K2ExtraVarsUpdateNodePools, commands.ClusterUpdate(commands.K2CLIUpdateNodePools, []string{nodePool})
+	}
+	return commands.K2ExtraVarsRemoveNodePools, commands.ClusterUpdate(commands.K2CLIRemoveNodePools, []string{nodePool})
+}
+
+func (r *Runner) handleProjects(request *Request) bool {
+
+	cfg := projectConfig(request.projObj, request.nsObj, request.resObj)
+	cfg.RequestID = request.requestID
 
-	var command []string
+	target := ProjectTarget(request.projObj)
+	configPath := target.ConfigPath()
+
+	// hold the configuration lock across the edit and the k2 run
+	lock, err := commands.LockConfig(configPath, fmt.Sprintf("%v %s", request.requestType, cfg.NodePoolName()))
+	if err != nil {
+		glog.Errorf("Discarding %v: configuration lock failure: %v", request.requestType, err)
+		if request.requestType == RemoveProject {
+			request.resObj.State = ResourceErrorDeleting
+		} else {
+			request.resObj.State = ResourceErrorStarting
+		}
+		request.resObj.UpdatedAt = time.Now()
+		return true
+	}
+	defer lock.Unlock()
 
-	configPath := path.Join(*krak8sCfg.krakenConfigDir, *krak8sCfg.krakenConfigFile)
 	if request.requestType == AddProject {
 
 		err := commands.AddProjectTemplate(cfg, configPath)
 		if err != nil {
 			glog.Errorf("Discarding add: configuration update failure: %v", err)
+			request.resObj.State = ResourceErrorStarting
+			request.resObj.UpdatedAt = time.Now()
 			return true
 		}
+		request.resObj.State = ResourceStarting
+		request.resObj.UpdatedAt = time.Now()
 
-		if *krak8sCfg.krakenCommand == commands.K2 {
-			command = commands.K2CmdUpdate(*krak8sCfg.krakenInDocker, commands.K2ExtraVarsAddNodePools, *krak8sCfg.krakenConfigDir, configFile, request.projObj.Name)
-		} else {
-			command = commands.ClusterUpdateAdd(request.projObj.Name)
-		}
+	} else if request.requestType == UpdateProject {
 
+		err := commands.UpdateProject(cfg, configPath)
+		if err != nil {
+			glog.Errorf("Discarding update: configuration update failure: %v", err)
+			request.resObj.State = ResourceErrorStarting
+			request.resObj.UpdatedAt = time.Now()
+			return true
+		}
 		request.resObj.State = ResourceStarting
 		request.resObj.UpdatedAt = time.Now()
 
@@ -206,15 +399,10 @@ func (r *Runner) handleProjects(request *Request) bool {
 		err := commands.DeleteProject(cfg, configPath)
 		if err != nil {
 			glog.Errorf("Discarding remove: configuration update failure: %v", err)
+			request.resObj.State = ResourceErrorDeleting
+			request.resObj.UpdatedAt = time.Now()
 			return true
 		}
-
-		if *krak8sCfg.krakenCommand == commands.K2 {
-			command = commands.K2CmdUpdate(*krak8sCfg.krakenInDocker, commands.K2ExtraVarsRemoveNodePools, *krak8sCfg.krakenConfigDir, configFile, request.projObj.Name)
-		} else {
-			command = commands.ClusterUpdateAdd(request.projObj.Name)
-		}
-
 		request.resObj.State = ResourceDeleting
 		request.resObj.UpdatedAt = time.Now()
 
@@ -222,22 +410,76 @@ func (r *Runner) handleProjects(request *Request) bool {
 		return true
 	}
 
+	action, command := projectCommandAction(request.requestType, cfg.NodePoolName())
+	if *krak8sCfg.krakenCommand == commands.K2 {
+		command = commands.K2CmdUpdateNodePools(*krak8sCfg.krakenInDocker, action, target.KrakenConfigDir, configPath, []string{cfg.NodePoolName()})
+	}
+
 	runProjectRequestWithRetries(request, command)
 
 	return true
 }
 
+// ProjectPlan - the Kraken configuration change and command that a project
+// request would run.
+type ProjectPlan struct {
+	NodePool    string
+	ConfigDiff  string
+	Command     []string
+	Environment []string
+}
+
+// PlanProject - plan an AddProject, UpdateProject or RemoveProject request, as
+// handleProjects() would run it for the resource, without changing anything.
+func (r *Runner) PlanProject(requestType RequestType, proj *ProjectObject, ns *NamespaceObject, res *ResourceObject) (*ProjectPlan, error) {
+	cfg := projectConfig(proj, ns, res)
+	target := ProjectTarget(proj)
+	configPath := target.ConfigPath()
+
+	var diff string
+	var err error
+	switch requestType {
+	case AddProject:
+		diff, err = commands.PlanAddProject(cfg, configPath)
+	case UpdateProject:
+		diff, err = commands.PlanUpdateProject(cfg, configPath)
+	case RemoveProject:
+		diff, err = commands.PlanDeleteProject(cfg, configPath)
+	default:
+		return nil, fmt.Errorf("no plan for %v request", requestType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &ProjectPlan{NodePool: cfg.NodePoolName(), ConfigDiff: diff}
+	action, command := projectCommandAction(requestType, cfg.NodePoolName())
+	plan.Command = command
+	if *krak8sCfg.krakenCommand == commands.K2 {
+		plan.Command, plan.Environment = commands.K2PlanUpdateNodePools(*krak8sCfg.krakenInDocker, action, target.KrakenConfigDir, configPath, []string{cfg.NodePoolName()})
+	}
+	return plan, nil
+}
+
+// krakenWorkDir - change to the kraken directory to run kraken commands, when
+// not run in docker, returning the function that changes back.
+func krakenWorkDir() func() {
+	if *krak8sCfg.krakenInDocker {
+		return func() {}
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		glog.Infof("error getting current working directory: %v", err)
+		return func() {}
+	}
+	os.Chdir("/kraken")
+	return func() { os.Chdir(wd) }
+}
+
 func runProjectRequestWithRetries(request *Request, command []string) {
 	// Block the command state in the queue and run the command to completion.
 	queue.Started()
-	if *krak8sCfg.krakenInDocker == false {
-		if wd, err := os.Getwd(); err == nil {
-			os.Chdir("/kraken")
-			defer os.Chdir(wd)
-		} else {
-			glog.Infof("error getting current working directory: %v", err)
-		}
-	}
+	defer krakenWorkDir()()
 
 	tries := request.retryCount
 	for tries >= 0 {
@@ -267,21 +509,180 @@ func runProjectRequestWithRetries(request *Request, command []string) {
 	queue.Done()
 }
 
+// recordConfigUpdate - record the state of the cluster update on the restored
+// configuration revision's history entry
+func recordConfigUpdate(request *Request, state string) {
+	if err := commands.SetConfigRevisionUpdate(revisionConfigPath(), request.revision, state); err != nil {
+		glog.Errorf("unable to record the %s update of configuration revision %s: %v", state, request.revision, err)
+	}
+}
+
+// handleConfigUpdate - run the cluster update for the node pools changed by a
+// restored configuration revision, one update per node pool action, on the
+// configuration file the revision was restored to.  The outcome is recorded
+// on the revision, failed when any update fails.
+func (r *Runner) handleConfigUpdate(request *Request) bool {
+	updates := []struct {
+		action, arg string
+		nodePools   []string
+	}{
+		{commands.K2ExtraVarsAddNodePools, commands.K2CLIAddNodePools, request.nodePools.Added},
+		{commands.K2ExtraVarsUpdateNodePools, commands.K2CLIUpdateNodePools, request.nodePools.Updated},
+		{commands.K2ExtraVarsRemoveNodePools, commands.K2CLIRemoveNodePools, request.nodePools.Removed},
+	}
+
+	configPath := revisionConfigPath()
+	lock, err := commands.LockConfig(configPath, fmt.Sprintf("%v", request.requestType))
+	if err != nil {
+		glog.Errorf("Discarding %v: configuration lock failure: %v", request.requestType, err)
+		recordConfigUpdate(request, commands.ConfigUpdateFailed)
+		return true
+	}
+	defer lock.Unlock()
+
+	// Block the command state in the queue and run the commands to completion.
+	queue.Started()
+	defer krakenWorkDir()()
+	state := commands.ConfigUpdateApplied
+	for _, update := range updates {
+		if len(update.nodePools) == 0 {
+			continue
+		}
+		command := commands.ClusterUpdate(update.arg, update.nodePools)
+		if *krak8sCfg.krakenCommand == commands.K2 {
+			command = commands.K2CmdUpdateNodePools(*krak8sCfg.krakenInDocker, update.action, *krak8sCfg.krakenConfigDir, configPath, update.nodePools)
+		}
+		tries := request.retryCount
+		for tries >= 0 {
+			_, err = commands.Execute(command[0], command[1:])
+			if err != nil {
+				tries--
+				glog.Errorf("command execution retry count: %v", request.retryCount)
+				glog.Errorf("command execution failed on: %v", err)
+			} else {
+				if *krak8sCfg.debug {
+					glog.Infof("command execution success, tries: %d", tries)
+				}
+				tries = -1
+			}
+		}
+		if err != nil {
+			state = commands.ConfigUpdateFailed
+		}
+	}
+	recordConfigUpdate(request, state)
+	queue.Done()
+
+	return true
+}
+
 func (r *Runner) handleCharts(request *Request) bool {
 	if request.appObj.ChartName == "mongodb-replicaset" {
 		return r.handleMongoChart(request)
 	}
+	if request.appObj.Deployer == DeployerKraken {
+		return r.handleKrakenChart(request)
+	}
 	return r.handleGenericChart(request)
 }
 
+// handleKrakenChart - add the application's chart to the helmConfigs of the
+// project target's Kraken configuration, and run the update of the
+// application's node pool for k2 to deploy it.  k2 doesn't remove charts, so
+// a removed chart is taken out of the configuration and deleted with helm.
+func (r *Runner) handleKrakenChart(request *Request) bool {
+	chart := genericChartDriver(request.dataStore, request.projObj, request.nsObj, request.appObj)
+	service, err := chart.ServiceChart()
+	if err != nil {
+		glog.Errorf("Discarding chart request: %v", err)
+		request.appObj.Status.State = ApplicationFailed
+		request.appObj.UpdatedAt = time.Now()
+		return true
+	}
+	service.Project = request.projObj.Name
+	service.RequestID = request.requestID
+	target := ProjectTarget(request.projObj)
+
+	// hold the configuration lock across the edit and the k2 run
+	lock, err := commands.LockConfig(target.ConfigPath(), fmt.Sprintf("%v %s", request.requestType, service.Name))
+	if err != nil {
+		glog.Errorf("Discarding %v: configuration lock failure: %v", request.requestType, err)
+		request.appObj.Status.State = ApplicationFailed
+		request.appObj.UpdatedAt = time.Now()
+		return true
+	}
+	defer lock.Unlock()
+
+	if request.requestType == RemoveChart {
+		request.appObj.Status.State = ApplicationDeleting
+		request.appObj.UpdatedAt = time.Now()
+		if err := commands.RemoveServiceChart(service, target.ConfigPath()); err != nil {
+			glog.Errorf("Discarding remove: configuration update failure: %v", err)
+			request.appObj.Status.State = ApplicationFailed
+			request.appObj.UpdatedAt = time.Now()
+			return true
+		}
+		return r.handleGenericChart(request)
+	} else if request.requestType != AddChart {
+		return true
+	}
+
+	request.appObj.Status.State = ApplicationUnknown
+	request.appObj.UpdatedAt = time.Now()
+	if err := commands.AddServiceChart(service, target.ConfigPath()); err != nil {
+		glog.Errorf("Discarding add: configuration update failure: %v", err)
+		request.appObj.Status.State = ApplicationFailed
+		request.appObj.UpdatedAt = time.Now()
+		return true
+	}
+
+	nodePool := chart.NodePool
+	if nodePool == "" {
+		nodePool = commands.NodePoolName(request.projObj.Name)
+	}
+	_, command := projectCommandAction(UpdateProject, nodePool)
+	if *krak8sCfg.krakenCommand == commands.K2 {
+		command = commands.K2CmdUpdateNodePools(*krak8sCfg.krakenInDocker, commands.K2ExtraVarsUpdateNodePools, target.KrakenConfigDir, target.ConfigPath(), []string{nodePool})
+	}
+
+	// Block the command state in the queue and run the command to completion.
+	queue.Started()
+	restoreWorkDir := krakenWorkDir()
+	tries := request.retryCount
+	for tries >= 0 {
+		_, err := commands.Execute(command[0], command[1:])
+		if err != nil {
+			tries--
+			glog.Errorf("kraken update retry count: %v", request.retryCount)
+			glog.Errorf("kraken update failed on: %v", err)
+			request.appObj.Status.State = ApplicationFailed
+		} else {
+			if *krak8sCfg.debug {
+				glog.Infof("command execution success, tries: %d", tries)
+			}
+			tries = -1
+			request.appObj.Status.State = ApplicationDeployed
+			request.appObj.Status.DeployedAt = time.Now()
+		}
+		request.appObj.UpdatedAt = time.Now()
+	}
+	restoreWorkDir()
+	queue.Done()
+
+	return true
+}
+
 func (r *Runner) handleMongoChart(request *Request) bool {
 
+	target := ProjectTarget(request.projObj)
 	mongo := commands.MongoReplicasetDriver{
 		DeploymentName: request.projObj.Name + "-mongodb",
 		ChartLocation:  request.appObj.Server + "/" + request.appObj.ChartRegistry + "/" + request.appObj.ChartName,
 		Namespace:      request.nsObj.Name,
 		CustomerName:   request.projObj.Name,
 		Template:       commands.MongoReplicasetTemplate,
+		KubeConfig:     target.Kubeconfig,
+		KubeContext:    target.KubeContext,
 	}
 	if request.requestType == AddChart {
 		request.appObj.Status.State = ApplicationUnknown
@@ -336,18 +737,40 @@ func (r *Runner) handleMongoChart(request *Request) bool {
 	return true
 }
 
-func (r *Runner) handleGenericChart(request *Request) bool {
+// genericChartDriver - the generic chart driver for the application.
+func genericChartDriver(ds *DataStore, proj *ProjectObject, ns *NamespaceObject, app *ApplicationObject) commands.GenericDriver {
+	target := ProjectTarget(proj)
+	username, password, err := ds.ApplicationLogin(proj, app)
+	if err != nil {
+		glog.Errorf("unable to open the registry password of application %s: %v", app.OID, err)
+	}
 	chart := commands.GenericDriver{
-		DeploymentName: request.appObj.Deployment,
-		ChartLocation:  request.appObj.Server + "/" + request.appObj.ChartRegistry + "/" + request.appObj.ChartName,
-		Version:        request.appObj.ChartVersion,
-		Server:         request.appObj.Server,
-		SetConfig:      request.appObj.Config,
-		JSONValues:     request.appObj.JSONValues,
-		Namespace:      request.nsObj.Name,
-		Username:       request.appObj.Username,
-		Password:       request.appObj.Password,
+		DeploymentName: app.Deployment,
+		ChartLocation:  app.Server + "/" + app.ChartRegistry + "/" + app.ChartName,
+		Version:        app.ChartVersion,
+		Server:         app.Server,
+		SetConfig:      app.Config,
+		Values:         app.Values,
+		JSONValues:     app.JSONValues,
+		Namespace:      ns.Name,
+		Username:       username,
+		Password:       password,
+		KubeConfig:     target.Kubeconfig,
+		KubeContext:    target.KubeContext,
 	}
+	// Schedule on to the application's node pool, the namespace's default
+	// node pool unless the application names one, if the namespace has it.
+	if res, ok := ds.ResourceObject(ns.OID, app.Cluster); ok {
+		paths := commands.ChartSchedulingPaths(app.ChartName)
+		chart.CustomerName = proj.Name
+		chart.NodePool = commands.ProjectNodePoolName(proj.Name, res.Name)
+		chart.SchedulingPaths = &paths
+	}
+	return chart
+}
+
+func (r *Runner) handleGenericChart(request *Request) bool {
+	chart := genericChartDriver(request.dataStore, request.projObj, request.nsObj, request.appObj)
 
 	if request.requestType == AddChart {
 		request.appObj.Status.State = ApplicationUnknown
@@ -373,6 +796,29 @@ func (r *Runner) handleGenericChart(request *Request) bool {
 			request.appObj.UpdatedAt = time.Now()
 		}
 		queue.Done()
+	} else if request.requestType == UpdateChart {
+		request.appObj.UpdatedAt = time.Now()
+		// Block the command state in the queue and run the command to completion.
+		queue.Started()
+		tries := request.retryCount
+		for tries >= 0 {
+			_, err := chart.Upgrade()
+			if err != nil {
+				tries--
+				glog.Errorf("upgrade retry count: %v", request.retryCount)
+				glog.Errorf("upgrade failed on: %v", err)
+				request.appObj.Status.State = ApplicationFailed
+			} else {
+				if *krak8sCfg.debug {
+					glog.Infof("command execution success, tries: %d", tries)
+				}
+				tries = -1
+				request.appObj.Status.State = ApplicationDeployed
+				request.appObj.Status.DeployedAt = time.Now()
+			}
+			request.appObj.UpdatedAt = time.Now()
+		}
+		queue.Done()
 	} else if request.requestType == RemoveChart {
 		request.appObj.Status.State = ApplicationDeleting
 		request.appObj.UpdatedAt = time.Now()
@@ -402,6 +848,68 @@ func (r *Runner) handleGenericChart(request *Request) bool {
 	return true
 }
 
+// handleNamespace - delete the Kubernetes namespace of a deleted namespace,
+// after the namespace's applications have been removed.
+func (r *Runner) handleNamespace(request *Request) bool {
+	if *krak8sCfg.dryrun {
+		return true
+	}
+	queue.Started()
+	tries := request.retryCount
+	for tries >= 0 {
+		client, err := clusterNamespaces(request.projObj)
+		if err == nil {
+			err = DeleteClusterNamespace(client, request.nsObj)
+		}
+		if err != nil {
+			tries--
+			glog.Errorf("namespace %s delete retry count: %v", request.nsObj.Name, request.retryCount)
+			glog.Errorf("namespace delete failed on: %v", err)
+		} else {
+			if *krak8sCfg.debug {
+				glog.Infof("namespace delete success, tries: %d", tries)
+			}
+			tries = -1
+		}
+	}
+	queue.Done()
+
+	return true
+}
+
+// handleRegistryLogin - log in to the registry of a rotated credential, so
+// that the charts pulled from then on are pulled with its new password.
+func (r *Runner) handleRegistryLogin(request *Request) bool {
+	password, err := request.dataStore.CredentialPassword(request.credObj)
+	if err != nil {
+		glog.Errorf("Discarding registry login: unable to open the password of credential %s: %v", request.credObj.Name, err)
+		return true
+	}
+	login := commands.GenericDriver{
+		Server:   request.credObj.Server,
+		Username: request.credObj.Username,
+		Password: password,
+	}
+	queue.Started()
+	tries := request.retryCount
+	for tries >= 0 {
+		_, err := login.Login()
+		if err != nil {
+			tries--
+			glog.Errorf("registry %s login retry count: %v", request.credObj.Server, request.retryCount)
+			glog.Errorf("registry login failed on: %v", err)
+		} else {
+			if *krak8sCfg.debug {
+				glog.Infof("registry login success, tries: %d", tries)
+			}
+			tries = -1
+		}
+	}
+	queue.Done()
+
+	return true
+}
+
 // DeleteRequest - remove request from processing pipeline
 func (r *Runner) DeleteRequest(index int) {
 	request, ok := r.pendingRequests[index]
@@ -412,14 +920,13 @@ func (r *Runner) DeleteRequest(index int) {
 	// If the queued task is already (or still) running, it can't be deleted yet.
 	status := queue.Delete(request.task.ID)
 	if status == queue.Running {
-		// Increment the request type indicate that it's running
-		request.requestType--
 		r.sync <- index
 		return
 	}
 
+	name, namespace := request.names()
 	glog.Infof("Queued task deleted: type: %s, name: %s, namespace: %s, queueing duration: %s, running duration %s",
-		request.requestType.String(), request.projObj.Name, request.nsObj.Name, queue.QueuedDuration().String(), queue.RunningDuration().String())
+		request.requestType.String(), name, namespace, queue.QueuedDuration().String(), queue.RunningDuration().String())
 
 	// ok to remove the request from the pending map
 	r.mutex.Lock()
@@ -429,8 +936,8 @@ func (r *Runner) DeleteRequest(index int) {
 }
 
 // ProjectRequest - submit project add request for processing.
-func (r *Runner) ProjectRequest(action RequestType, ds *DataStore, proj *ProjectObject, ns *NamespaceObject, res *ResourceObject) RequestStatus {
-	req := NewResourceRequest(action, ds, proj, ns, res)
+func (r *Runner) ProjectRequest(ctx context.Context, action RequestType, ds *DataStore, proj *ProjectObject, ns *NamespaceObject, res *ResourceObject) RequestStatus {
+	req := NewResourceRequest(action, ds, proj, ns, res).fromAPIRequest(ctx)
 	req.retryCount = 1
 	queue.Submit(req.task)
 
@@ -444,9 +951,91 @@ func (r *Runner) ProjectRequest(action RequestType, ds *DataStore, proj *Project
 	return Waiting
 }
 
+// ConfigRequest - submit a configuration update request, for the node pools
+// changed by restoring a configuration revision.
+func (r *Runner) ConfigRequest(ctx context.Context, ds *DataStore, revision string, changes *commands.NodePoolChanges) RequestStatus {
+	req := NewConfigRequest(UpdateConfig, ds, revision, changes).fromAPIRequest(ctx)
+	req.retryCount = 1
+	queue.Submit(req.task)
+
+	// add the request to the pending map
+	r.mutex.Lock()
+	r.index++
+	r.pendingRequests[r.index] = req
+	r.mutex.Unlock()
+	r.sync <- r.index
+
+	return Waiting
+}
+
+// NamespaceRequest - submit a namespace request, queued behind the chart and
+// project requests of the namespace.
+func (r *Runner) NamespaceRequest(ctx context.Context, action RequestType, ds *DataStore, proj *ProjectObject, ns *NamespaceObject) RequestStatus {
+	req := NewNamespaceRequest(action, ds, proj, ns).fromAPIRequest(ctx)
+	req.retryCount = 1
+	queue.Submit(req.task)
+
+	// add the request to the pending map
+	r.mutex.Lock()
+	r.index++
+	r.pendingRequests[r.index] = req
+	r.mutex.Unlock()
+	r.sync <- r.index
+
+	return Waiting
+}
+
+// CredentialRequest - submit a registry login request, for a rotated
+// credential.
+func (r *Runner) CredentialRequest(ctx context.Context, ds *DataStore, proj *ProjectObject, cred *CredentialObject) RequestStatus {
+	req := NewCredentialRequest(RegistryLogin, ds, proj, cred).fromAPIRequest(ctx)
+	req.retryCount = 1
+	queue.Submit(req.task)
+
+	// add the request to the pending map
+	r.mutex.Lock()
+	r.index++
+	r.pendingRequests[r.index] = req
+	r.mutex.Unlock()
+	r.sync <- r.index
+
+	return Waiting
+}
+
+// ValidateChart - validate the application's chart values against the chart's
+// values schema, call before submitting an AddChart or UpdateChart request.
+// The mongodb-replicaset chart's values are generated, so are not validated.
+// The chart fetch, if any, stops when the API request is done.
+func (r *Runner) ValidateChart(ctx context.Context, ds *DataStore, proj *ProjectObject, ns *NamespaceObject, app *ApplicationObject) error {
+	if app.ChartName == "mongodb-replicaset" {
+		return nil
+	}
+	ctx, cancel := requestBound(ctx)
+	defer cancel()
+	return genericChartDriver(ds, proj, ns, app).ValidateValues(ctx)
+}
+
+// requestBound - the API request's context, also done once the HTTP request
+// is, when its client goes away, which the goa contexts, derived from the
+// service's context, are not.
+func requestBound(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	if req := goa.ContextRequest(ctx); req != nil && req.Request != nil {
+		done := req.Request.Context().Done()
+		go func() {
+			select {
+			case <-done:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+	}
+	return ctx, cancel
+}
+
 // ChartRequest - submit project add request for processing.
-func (r *Runner) ChartRequest(action RequestType, ds *DataStore, proj *ProjectObject, ns *NamespaceObject, app *ApplicationObject) RequestStatus {
-	req := NewChartRequest(action, ds, proj, ns, app)
+func (r *Runner) ChartRequest(ctx context.Context, action RequestType, ds *DataStore, proj *ProjectObject, ns *NamespaceObject, app *ApplicationObject) RequestStatus {
+	req := NewChartRequest(action, ds, proj, ns, app).fromAPIRequest(ctx)
 	queue.Submit(req.task)
 
 	// add the request to the pending map
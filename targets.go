@@ -0,0 +1,179 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path"
+	"sort"
+	"sync"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/glog"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// DefaultTarget - name of the cluster target given by the krak8s flags, the
+// target of projects that don't name one.
+const DefaultTarget = "default"
+
+// ClusterTarget - a cluster that projects can be placed on: the Kraken
+// configuration k2 applies to it, the kubeconfig and context that helm and
+// the clientset reach it with, and the API server URL of the kubeconfigs
+// issued to its tenants.
+type ClusterTarget struct {
+	Name             string
+	KrakenConfigDir  string
+	KrakenConfigFile string
+	Kubeconfig       string
+	KubeContext      string
+	Server           string
+
+	lock      sync.Mutex
+	clientset *kubernetes.Clientset
+}
+
+// ConfigPath - path of the target's Kraken configuration file.
+func (t *ClusterTarget) ConfigPath() string {
+	return path.Join(t.KrakenConfigDir, t.KrakenConfigFile)
+}
+
+// Clientset - the clientset for the target's cluster, created on first use.
+func (t *ClusterTarget) Clientset() (*kubernetes.Clientset, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.clientset != nil {
+		return t.clientset, nil
+	}
+	rules := &clientcmd.ClientConfigLoadingRules{ExplicitPath: t.Kubeconfig}
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: t.KubeContext}
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, overrides).ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	addGV(config)
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	t.clientset = clientset
+	return clientset, nil
+}
+
+// APIServer - the API server URL of the kubeconfigs issued to the target's
+// tenants, the URL krak8s reaches the cluster at when the target has none.
+func (t *ClusterTarget) APIServer() (string, error) {
+	if t.Server != "" {
+		return t.Server, nil
+	}
+	clientset, err := t.Clientset()
+	if err != nil {
+		return "", err
+	}
+	u := clientset.Core().RESTClient().Get().URL()
+	return u.Scheme + "://" + u.Host, nil
+}
+
+var clusterTargets = map[string]*ClusterTarget{}
+
+// SetDefaultTarget - register the default cluster target from the krak8s
+// flags, and the clientset that krak8s created for it, if any.
+func SetDefaultTarget(clientset *kubernetes.Clientset) {
+	clusterTargets[DefaultTarget] = &ClusterTarget{
+		Name:             DefaultTarget,
+		KrakenConfigDir:  *krak8sCfg.krakenConfigDir,
+		KrakenConfigFile: *krak8sCfg.krakenConfigFile,
+		Kubeconfig:       *krak8sCfg.kubeconfig,
+		Server:           *krak8sCfg.tenantServer,
+		clientset:        clientset,
+	}
+}
+
+// LoadClusterTargets - register the cluster targets of a YAML file, keyed by
+// target name.
+func LoadClusterTargets(filename string) error {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		glog.Warningf("unable to read cluster targets file: %v", err)
+		return err
+	}
+	specs := make(map[string]map[string]string)
+	if err = yaml.Unmarshal(data, &specs); err != nil {
+		glog.Warningf("unable to parse cluster targets file: %v", err)
+		return err
+	}
+	targets := make(map[string]*ClusterTarget)
+	for name, spec := range specs {
+		if name == DefaultTarget {
+			return fmt.Errorf("cluster target %s is reserved for the krak8s flags", DefaultTarget)
+		}
+		if spec["krakenConfigDir"] == "" {
+			return fmt.Errorf("cluster target %s has no krakenConfigDir", name)
+		}
+		target := &ClusterTarget{
+			Name:             name,
+			KrakenConfigDir:  spec["krakenConfigDir"],
+			KrakenConfigFile: spec["krakenConfigFile"],
+			Kubeconfig:       spec["kubeconfig"],
+			KubeContext:      spec["kubeContext"],
+			Server:           spec["server"],
+		}
+		if target.KrakenConfigFile == "" {
+			target.KrakenConfigFile = *krak8sCfg.krakenConfigFile
+		}
+		targets[name] = target
+	}
+	for name, target := range targets {
+		clusterTargets[name] = target
+	}
+	return nil
+}
+
+// LookupTarget - the named cluster target, the default target for "".
+func LookupTarget(name string) (*ClusterTarget, bool) {
+	if name == "" {
+		name = DefaultTarget
+	}
+	if _, ok := clusterTargets[DefaultTarget]; !ok && name == DefaultTarget {
+		SetDefaultTarget(nil)
+	}
+	target, ok := clusterTargets[name]
+	return target, ok
+}
+
+// ProjectTarget - the cluster target of the project, the default target if
+// the project's target is no longer registered.
+func ProjectTarget(proj *ProjectObject) *ClusterTarget {
+	if target, ok := LookupTarget(proj.Target); ok {
+		return target
+	}
+	glog.Warningf("project %s cluster target %s is not registered, using %s", proj.Name, proj.Target, DefaultTarget)
+	target, _ := LookupTarget(DefaultTarget)
+	return target
+}
+
+// TargetNames - the names of the registered cluster targets, sorted.
+func TargetNames() []string {
+	names := []string{}
+	for name := range clusterTargets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
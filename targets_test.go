@@ -0,0 +1,102 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+)
+
+const testClusterTargets = `east:
+  krakenConfigDir: /kraken/east
+  kubeconfig: /kraken/east/admin.kubeconfig
+  kubeContext: east
+west:
+  krakenConfigDir: /kraken/west
+  krakenConfigFile: west.yaml
+`
+
+func writeTargetsFile(t *testing.T, data string) string {
+	file, err := ioutil.TempFile(os.TempDir(), "test-targets")
+	if err != nil {
+		t.Fatalf("TempFile(), err: %v", err)
+	}
+	defer file.Close()
+	if _, err := file.WriteString(data); err != nil {
+		t.Fatalf("WriteString(), err: %v", err)
+	}
+	return file.Name()
+}
+
+func TestLoadClusterTargets(t *testing.T) {
+	defer func() { clusterTargets = map[string]*ClusterTarget{} }()
+
+	filename := writeTargetsFile(t, testClusterTargets)
+	defer os.Remove(filename)
+	if err := LoadClusterTargets(filename); err != nil {
+		t.Fatalf("LoadClusterTargets(), err: %v", err)
+	}
+
+	east, ok := LookupTarget("east")
+	if !ok {
+		t.Fatal("LookupTarget(east), want: target")
+	}
+	if east.ConfigPath() != "/kraken/east/"+*krak8sCfg.krakenConfigFile {
+		t.Errorf("east ConfigPath(), have: %s", east.ConfigPath())
+	}
+	if east.Kubeconfig != "/kraken/east/admin.kubeconfig" || east.KubeContext != "east" {
+		t.Errorf("east kubeconfig, have: %s %s", east.Kubeconfig, east.KubeContext)
+	}
+	west, _ := LookupTarget("west")
+	if west == nil || west.ConfigPath() != "/kraken/west/west.yaml" {
+		t.Errorf("west ConfigPath(), have: %v", west)
+	}
+	if target, ok := LookupTarget(""); !ok || target.Name != DefaultTarget {
+		t.Errorf("LookupTarget(\"\"), want: the default target, have: %v", target)
+	}
+	if _, ok := LookupTarget("north"); ok {
+		t.Error("LookupTarget(north), want: no target")
+	}
+	if names := TargetNames(); !reflect.DeepEqual(names, []string{"default", "east", "west"}) {
+		t.Errorf("TargetNames(), have: %v", names)
+	}
+
+	proj := &ProjectObject{Name: "acme", Target: "gone"}
+	if target := ProjectTarget(proj); target.Name != DefaultTarget {
+		t.Errorf("ProjectTarget() of an unregistered target, have: %s, want: %s", target.Name, DefaultTarget)
+	}
+}
+
+func TestLoadClusterTargetsInvalid(t *testing.T) {
+	defer func() { clusterTargets = map[string]*ClusterTarget{} }()
+
+	for _, data := range []string{
+		"default:\n  krakenConfigDir: /kraken\n",
+		"east:\n  kubeContext: east\n",
+	} {
+		filename := writeTargetsFile(t, data)
+		if err := LoadClusterTargets(filename); err == nil {
+			t.Errorf("LoadClusterTargets(%q), want: error", data)
+		}
+		os.Remove(filename)
+	}
+	if _, ok := clusterTargets["east"]; ok {
+		t.Error("LoadClusterTargets() registered a target of an invalid file")
+	}
+}
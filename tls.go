@@ -0,0 +1,151 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/golang/glog"
+)
+
+// CertReloader - the API server's certificate, and the CA of the client
+// certificates it requires when one is given, read again from their files
+// whenever the files change, so that certificates rotated by cert-manager are
+// served without a restart.
+type CertReloader struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+
+	mu        sync.Mutex
+	stamp     string
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+}
+
+// NewCertReloader - the reloader of the certificate and key files, and of the
+// client CA file when it isn't empty.  The files are read, and must be valid,
+// when the reloader is created.
+func NewCertReloader(certFile, keyFile, clientCAFile string) (*CertReloader, error) {
+	if certFile == "" || keyFile == "" {
+		return nil, errors.New("a TLS certificate needs both a certificate file and a key file")
+	}
+	r := &CertReloader{
+		certFile:     certFile,
+		keyFile:      keyFile,
+		clientCAFile: clientCAFile,
+	}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// files - the files read by the reloader
+func (r *CertReloader) files() []string {
+	files := []string{r.certFile, r.keyFile}
+	if r.clientCAFile != "" {
+		files = append(files, r.clientCAFile)
+	}
+	return files
+}
+
+// fileStamp - the modification times and sizes of the files, changed when
+// any of them is replaced or rewritten
+func fileStamp(files []string) (string, error) {
+	stamp := ""
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return "", err
+		}
+		stamp += fmt.Sprintf("%s:%d:%d;", file, info.ModTime().UnixNano(), info.Size())
+	}
+	return stamp, nil
+}
+
+// reload - read the files again if they changed since they were last read.
+// Callers hold the lock, or own the reloader.
+func (r *CertReloader) reload() error {
+	stamp, err := fileStamp(r.files())
+	if err != nil {
+		return err
+	}
+	if stamp == r.stamp {
+		return nil
+	}
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("unable to load TLS certificate %s and key %s: %v", r.certFile, r.keyFile, err)
+	}
+	var clientCAs *x509.CertPool
+	if r.clientCAFile != "" {
+		pem, err := ioutil.ReadFile(r.clientCAFile)
+		if err != nil {
+			return err
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no PEM certificates in client CA file %s", r.clientCAFile)
+		}
+	}
+	if r.stamp != "" {
+		glog.Infof("reloaded TLS certificate %s", r.certFile)
+	}
+	r.stamp = stamp
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	return nil
+}
+
+// current - the certificate and client CAs, reloaded if their files changed.
+// Files that can't be read, mid rotation say, leave the ones last read in use.
+func (r *CertReloader) current() (*tls.Certificate, *x509.CertPool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.reload(); err != nil {
+		glog.Warningf("keeping the TLS certificate last loaded: %v", err)
+	}
+	return r.cert, r.clientCAs
+}
+
+// TLSConfig - the server TLS configuration, its certificate and client CAs
+// looked up on each handshake.  Client certificates are required, and
+// verified, when a client CA file is given.
+func (r *CertReloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			cert, clientCAs := r.current()
+			config := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*cert},
+			}
+			if clientCAs != nil {
+				config.ClientCAs = clientCAs
+				config.ClientAuth = tls.RequireAndVerifyClientCert
+			}
+			return config, nil
+		},
+	}
+}
@@ -0,0 +1,227 @@
+/*
+Copyright 2017 Samsung SDSA CNCT
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"testing"
+	"time"
+)
+
+// testCert - a certificate and its PEM encoded certificate and key, signed
+// by the parent, or self signed without one
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+func newTestCert(t *testing.T, name string, serial int64, isCA bool, parent *testCert) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	signer, signerKey := template, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+// writeTestFile - write the file, with a modification time after the one it
+// had, as a rotation does
+func writeTestFile(t *testing.T, file string, data []byte, mtime time.Time) {
+	if err := ioutil.WriteFile(file, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(file, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func servedSerial(t *testing.T, r *CertReloader) int64 {
+	config, err := r.TLSConfig().GetConfigForClient(&tls.ClientHelloInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(config.Certificates[0].Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert.SerialNumber.Int64()
+}
+
+func TestCertReloader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "krak8s-tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	certFile, keyFile := path.Join(dir, "tls.crt"), path.Join(dir, "tls.key")
+
+	if _, err := NewCertReloader(certFile, "", ""); err == nil {
+		t.Error("NewCertReloader() want error without a key file")
+	}
+	if _, err := NewCertReloader(certFile, keyFile, ""); err == nil {
+		t.Error("NewCertReloader() want error for missing files")
+	}
+
+	mtime := time.Now().Add(-time.Hour)
+	first := newTestCert(t, "krak8s", 1, false, nil)
+	writeTestFile(t, certFile, first.certPEM, mtime)
+	writeTestFile(t, keyFile, first.keyPEM, mtime)
+	r, err := NewCertReloader(certFile, keyFile, "")
+	if err != nil {
+		t.Fatalf("NewCertReloader() error: %v", err)
+	}
+	if serial := servedSerial(t, r); serial != 1 {
+		t.Errorf("served certificate serial want 1, have %d", serial)
+	}
+	config, _ := r.TLSConfig().GetConfigForClient(&tls.ClientHelloInfo{})
+	if config.ClientAuth != tls.NoClientCert {
+		t.Errorf("ClientAuth want NoClientCert without a client CA, have %v", config.ClientAuth)
+	}
+
+	// rotated
+	mtime = mtime.Add(time.Minute)
+	second := newTestCert(t, "krak8s", 2, false, nil)
+	writeTestFile(t, certFile, second.certPEM, mtime)
+	writeTestFile(t, keyFile, second.keyPEM, mtime)
+	if serial := servedSerial(t, r); serial != 2 {
+		t.Errorf("served certificate serial after rotation want 2, have %d", serial)
+	}
+
+	// mid rotation, the certificate doesn't match the key
+	mtime = mtime.Add(time.Minute)
+	third := newTestCert(t, "krak8s", 3, false, nil)
+	writeTestFile(t, certFile, third.certPEM, mtime)
+	if serial := servedSerial(t, r); serial != 2 {
+		t.Errorf("served certificate serial mid rotation want 2, have %d", serial)
+	}
+	writeTestFile(t, keyFile, third.keyPEM, mtime)
+	if serial := servedSerial(t, r); serial != 3 {
+		t.Errorf("served certificate serial after rotation want 3, have %d", serial)
+	}
+}
+
+func TestCertReloaderClientCA(t *testing.T) {
+	dir, err := ioutil.TempDir("", "krak8s-tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	certFile, keyFile, caFile := path.Join(dir, "tls.crt"), path.Join(dir, "tls.key"), path.Join(dir, "ca.crt")
+
+	mtime := time.Now().Add(-time.Hour)
+	ca := newTestCert(t, "krak8s-ca", 1, true, nil)
+	server := newTestCert(t, "krak8s", 2, false, ca)
+	client := newTestCert(t, "ci", 3, false, ca)
+	writeTestFile(t, certFile, server.certPEM, mtime)
+	writeTestFile(t, keyFile, server.keyPEM, mtime)
+	writeTestFile(t, caFile, []byte("not a certificate"), mtime)
+	if _, err := NewCertReloader(certFile, keyFile, caFile); err == nil {
+		t.Error("NewCertReloader() want error for a client CA file without certificates")
+	}
+	writeTestFile(t, caFile, ca.certPEM, mtime)
+	r, err := NewCertReloader(certFile, keyFile, caFile)
+	if err != nil {
+		t.Fatalf("NewCertReloader() error: %v", err)
+	}
+
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	ts.TLS = r.TLSConfig()
+	ts.StartTLS()
+	defer ts.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	get := func(certs []tls.Certificate) error {
+		c := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			RootCAs:      roots,
+			Certificates: certs,
+		}}}
+		resp, err := c.Get(ts.URL)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		return nil
+	}
+
+	if err := get(nil); err == nil {
+		t.Error("request without a client certificate want error")
+	}
+	clientCert, err := tls.X509KeyPair(client.certPEM, client.keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := get([]tls.Certificate{clientCert}); err != nil {
+		t.Errorf("request with a client certificate error: %v", err)
+	}
+	other := newTestCert(t, "intruder", 4, false, nil)
+	otherCert, err := tls.X509KeyPair(other.certPEM, other.keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := get([]tls.Certificate{otherCert}); err == nil {
+		t.Error("request with a client certificate of another CA want error")
+	}
+}